@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	"github.com/openshift/installer/pkg/asset/tls"
+	"github.com/openshift/installer/pkg/events"
+	"github.com/openshift/installer/pkg/gather/gatewayd"
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+// bootstrapJournalUnits are the bootstrap services whose journal is
+// followed while waiting for bootstrapping to complete.
+var bootstrapJournalUnits = []string{"release-image.service", "bootkube.service"}
+
+// followBootstrapJournal streams the journal of the bootstrap services from
+// systemd-journal-gatewayd on the bootstrap host until the returned stop
+// function is called. Progress is logged at info level and every journal
+// line at debug level, so that the full journal lands in the log file.
+// Streaming is best effort: if the bootstrap host cannot be found or
+// reached, the reason is logged at debug level and bootstrapping is
+// monitored through the API alone.
+func followBootstrapJournal(ctx context.Context, directory string) (stop func()) {
+	client, err := newBootstrapJournalClient(directory)
+	if err != nil {
+		logrus.Debugf("Not following the bootstrap journal: %v", err)
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		progress := gatewayd.NewProgress()
+		wait.UntilWithContext(ctx, func(ctx context.Context) {
+			err := client.Follow(ctx, bootstrapJournalUnits, func(entry gatewayd.Entry) {
+				logrus.Debugf("bootstrap %s: %s", entry.Unit, entry.Message)
+				if message, changed := progress.Observe(entry); changed {
+					logrus.Infof("Bootstrap: %s", message)
+					events.BootstrapStatusChanged("progress", message)
+				}
+			})
+			if err != nil && ctx.Err() == nil {
+				logrus.Debugf("Lost the bootstrap journal, retrying: %v", err)
+			}
+		}, 10*time.Second)
+	}()
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// newBootstrapJournalClient returns a gatewayd client for the bootstrap host
+// recorded in the Terraform state in directory.
+func newBootstrapJournalClient(directory string) (*gatewayd.Client, error) {
+	tfStateFilePath := filepath.Join(directory, terraform.StateFileName)
+	if _, err := os.Stat(tfStateFilePath); err != nil {
+		return nil, err
+	}
+
+	assetStore, err := assetstore.NewStore(directory)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create asset store")
+	}
+	config := &installconfig.InstallConfig{}
+	rootCA := &tls.RootCA{}
+	journalCertKey := &tls.JournalCertKey{}
+	for _, a := range []asset.Asset{config, rootCA, journalCertKey} {
+		if err := assetStore.Fetch(a); err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch %s", a.Name())
+		}
+	}
+
+	tfstate, err := terraform.ReadState(tfStateFilePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read state from %q", tfStateFilePath)
+	}
+	bootstrap, _, _, err := extractHostAddresses(config.Config, tfstate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the bootstrap host address")
+	}
+
+	return gatewayd.NewClient(bootstrap, journalCertKey.Cert(), journalCertKey.Key(), rootCA.Cert())
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	clientwatch "k8s.io/client-go/tools/watch"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	"github.com/openshift/installer/pkg/events"
+)
+
+// watchClusterOperatorConditions emits a clusteroperator-condition event
+// whenever the status or reason of a ClusterOperator condition changes, until
+// ctx is done or the returned stop function is called.
+func watchClusterOperatorConditions(ctx context.Context, cc *configclient.Clientset) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		seen := map[string]configv1.ClusterOperatorStatusCondition{}
+		_, err := clientwatch.UntilWithSync(
+			ctx,
+			cache.NewListWatchFromClient(cc.ConfigV1().RESTClient(), "clusteroperators", "", fields.Everything()),
+			&configv1.ClusterOperator{},
+			nil,
+			func(event watch.Event) (bool, error) {
+				switch event.Type {
+				case watch.Added, watch.Modified:
+				default:
+					return false, nil
+				}
+				co, ok := event.Object.(*configv1.ClusterOperator)
+				if !ok {
+					return false, nil
+				}
+				for _, condition := range co.Status.Conditions {
+					key := co.Name + "/" + string(condition.Type)
+					if last, ok := seen[key]; ok && last.Status == condition.Status && last.Reason == condition.Reason {
+						continue
+					}
+					seen[key] = condition
+					events.Emit(events.Event{
+						Type: events.ClusterOperatorCondition,
+						ClusterOperator: &events.ClusterOperator{
+							Name:      co.Name,
+							Condition: string(condition.Type),
+							Status:    string(condition.Status),
+							Reason:    condition.Reason,
+							Message:   condition.Message,
+						},
+					})
+				}
+				return false, nil
+			},
+		)
+		if err != nil && ctx.Err() == nil {
+			logrus.Debugf("Stopped watching ClusterOperator conditions: %v", err)
+		}
+	}()
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"crypto/x509"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -28,11 +29,15 @@ import (
 	routeclient "github.com/openshift/client-go/route/clientset/versioned"
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/asset/logging"
 	assetstore "github.com/openshift/installer/pkg/asset/store"
+	"github.com/openshift/installer/pkg/asset/store/encryption"
 	targetassets "github.com/openshift/installer/pkg/asset/targets"
 	destroybootstrap "github.com/openshift/installer/pkg/destroy/bootstrap"
+	"github.com/openshift/installer/pkg/events"
 	timer "github.com/openshift/installer/pkg/metrics/timer"
+	"github.com/openshift/installer/pkg/terraform"
 	"github.com/openshift/installer/pkg/types/baremetal"
 	cov1helpers "github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
 	"github.com/openshift/library-go/pkg/route/routeapihelpers"
@@ -44,6 +49,16 @@ type target struct {
 	assets  []asset.WritableAsset
 }
 
+var (
+	createOpts struct {
+		answers string
+	}
+
+	createClusterOpts struct {
+		diagnosticsRules string
+	}
+)
+
 // each target is a variable to preserve the order when creating subcommands and still
 // allow other functions to directly access each target individually.
 var (
@@ -95,13 +110,15 @@ var (
 
 				// FIXME: pulling the kubeconfig and metadata out of the root
 				// directory is a bit cludgy when we already have them in memory.
-				config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(rootOpts.dir, "auth", "kubeconfig"))
+				config, err := loadAdminKubeconfig(rootOpts.dir)
 				if err != nil {
-					logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
+					err = errors.Wrap(err, "loading kubeconfig")
+					pushMetrics(metricsReport("create_cluster", rootOpts.dir), err)
+					logrus.Fatal(err)
 				}
 
 				timer.StartTimer("Bootstrap Complete")
-				err = waitForBootstrapComplete(ctx, config)
+				err = waitForBootstrapComplete(ctx, config, rootOpts.dir)
 				if err != nil {
 					if err2 := logClusterOperatorConditions(ctx, config); err2 != nil {
 						logrus.Error("Attempted to gather ClusterOperator status after installation failure: ", err2)
@@ -109,6 +126,7 @@ var (
 					if err2 := runGatherBootstrapCmd(rootOpts.dir); err2 != nil {
 						logrus.Error("Attempted to gather debug logs after installation failure: ", err2)
 					}
+					pushMetrics(metricsReport("create_cluster", rootOpts.dir), err)
 					logrus.Fatal("Bootstrap failed to complete: ", err)
 				}
 				timer.StopTimer("Bootstrap Complete")
@@ -121,6 +139,7 @@ var (
 					logrus.Info("Destroying the bootstrap resources...")
 					err = destroybootstrap.Destroy(rootOpts.dir)
 					if err != nil {
+						pushMetrics(metricsReport("create_cluster", rootOpts.dir), err)
 						logrus.Fatal(err)
 					}
 				}
@@ -131,11 +150,16 @@ var (
 					if err2 := logClusterOperatorConditions(ctx, config); err2 != nil {
 						logrus.Error("Attempted to gather ClusterOperator status after installation failure: ", err2)
 					}
+					if err2 := runGatherClusterCmd(ctx, config, rootOpts.dir); err2 != nil {
+						logrus.Error("Attempted to gather debug logs after installation failure: ", err2)
+					}
 					logTroubleshootingLink()
+					pushMetrics(metricsReport("create_cluster", rootOpts.dir), err)
 					logrus.Fatal(err)
 				}
 				timer.StopTimer(timer.TotalTimeElapsed)
 				timer.LogSummary()
+				pushMetrics(metricsReport("create_cluster", rootOpts.dir), nil)
 			},
 		},
 		assets: targetassets.Cluster,
@@ -153,11 +177,16 @@ func newCreateCmd() *cobra.Command {
 		},
 	}
 
+	cmd.PersistentFlags().StringVar(&createOpts.answers, "answers", "", fmt.Sprintf("Path to a file of answers to the install-config prompts, which are then not asked (defaults to $%s)", answers.FileEnv))
+
 	for _, t := range targets {
 		t.command.Args = cobra.ExactArgs(0)
 		t.command.Run = runTargetCmd(t.assets...)
+		addEventsFlags(t.command)
 		cmd.AddCommand(t.command)
 	}
+	clusterTarget.command.PersistentFlags().StringVar(&createClusterOpts.diagnosticsRules, "diagnostics-rules", "", fmt.Sprintf("Path to a file of additional rules for diagnosing infrastructure provisioning failures (defaults to $%s)", terraform.DiagnosticsRulesEnv))
+	addBastionFlag(clusterTarget.command)
 
 	return cmd
 }
@@ -197,8 +226,23 @@ func runTargetCmd(targets ...asset.WritableAsset) func(cmd *cobra.Command, args
 		cleanup := setupFileHook(rootOpts.dir)
 		defer cleanup()
 
+		if err := terraform.LoadUserRules(createClusterOpts.diagnosticsRules); err != nil {
+			logrus.Fatal(err)
+		}
+		if err := answers.Load(createOpts.answers); err != nil {
+			logrus.Fatal(err)
+		}
+
 		err := runner(rootOpts.dir)
+		if missing := answers.Missing(); err != nil && missing != nil {
+			// The missing answers are usually what caused the error.
+			logrus.Debug(err)
+			err = missing
+		}
 		if err != nil {
+			if cmd.Name() == "cluster" {
+				pushMetrics(metricsReport("create_cluster", rootOpts.dir), err)
+			}
 			logrus.Fatal(err)
 		}
 		if cmd.Name() != "cluster" {
@@ -223,7 +267,11 @@ func addRouterCAToClusterCA(ctx context.Context, config *rest.Config, directory
 
 	routerCrtBytes := []byte(caConfigMap.Data["ca-bundle.crt"])
 	kubeconfig := filepath.Join(directory, "auth", "kubeconfig")
-	kconfig, err := clientcmd.LoadFromFile(kubeconfig)
+	data, err := encryption.ReadFile(kubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "loading kubeconfig")
+	}
+	kconfig, err := clientcmd.Load(data)
 	if err != nil {
 		return errors.Wrap(err, "loading kubeconfig")
 	}
@@ -248,13 +296,30 @@ func addRouterCAToClusterCA(ctx context.Context, config *rest.Config, directory
 		newCA := append(routerCrtBytes, clusterCABytes...)
 		c.CertificateAuthorityData = newCA
 	}
-	if err := clientcmd.WriteToFile(*kconfig, kubeconfig); err != nil {
+	data, err = clientcmd.Write(*kconfig)
+	if err != nil {
+		return errors.Wrap(err, "writing kubeconfig")
+	}
+	if err := encryption.WriteFile(kubeconfig, data, 0600); err != nil {
 		return errors.Wrap(err, "writing kubeconfig")
 	}
 	return nil
 }
 
-func waitForBootstrapComplete(ctx context.Context, config *rest.Config) (err error) {
+// loadAdminKubeconfig returns the client config of the admin kubeconfig in
+// the asset directory, decrypting the kubeconfig if it is encrypted.
+func loadAdminKubeconfig(directory string) (*rest.Config, error) {
+	data, err := encryption.ReadFile(filepath.Join(directory, "auth", "kubeconfig"))
+	if err != nil {
+		return nil, err
+	}
+	return clientcmd.RESTConfigFromKubeConfig(data)
+}
+
+func waitForBootstrapComplete(ctx context.Context, config *rest.Config, directory string) (err error) {
+	stop := followBootstrapJournal(ctx, directory)
+	defer stop()
+
 	client, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return errors.Wrap(err, "creating a Kubernetes client")
@@ -279,6 +344,7 @@ func waitForBootstrapComplete(ctx context.Context, config *rest.Config) (err err
 		version, err := discovery.ServerVersion()
 		if err == nil {
 			logrus.Infof("API %s up", version)
+			events.BootstrapStatusChanged("api-up", fmt.Sprintf("API %s up", version))
 			timer.StopTimer("API")
 			cancel()
 		} else {
@@ -317,6 +383,7 @@ func waitForBootstrapConfigMap(ctx context.Context, client *kubernetes.Clientset
 	waitCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	var lastStatus string
 	_, err := clientwatch.UntilWithSync(
 		waitCtx,
 		cache.NewListWatchFromClient(client.CoreV1().RESTClient(), "configmaps", "kube-system", fields.OneTermEqualSelector("metadata.name", "bootstrap")),
@@ -339,6 +406,10 @@ func waitForBootstrapConfigMap(ctx context.Context, client *kubernetes.Clientset
 				return false, nil
 			}
 			logrus.Debugf("Bootstrap status: %v", status)
+			if status != lastStatus {
+				events.BootstrapStatusChanged(status, "")
+				lastStatus = status
+			}
 			return status == "complete", nil
 		},
 	)
@@ -369,6 +440,11 @@ func waitForInitializedCluster(ctx context.Context, config *rest.Config) error {
 	clusterVersionContext, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	if events.Enabled() {
+		stop := watchClusterOperatorConditions(clusterVersionContext, cc)
+		defer stop()
+	}
+
 	failing := configv1.ClusterStatusConditionType("Failing")
 	timer.StartTimer("Cluster Operators")
 	var lastError string
@@ -478,11 +554,18 @@ func logComplete(directory, consoleURL string) error {
 	}
 	kubeconfig := filepath.Join(absDir, "auth", "kubeconfig")
 	pwFile := filepath.Join(absDir, "auth", "kubeadmin-password")
-	pw, err := ioutil.ReadFile(pwFile)
+	pw, err := encryption.ReadFile(pwFile)
+	if err != nil {
+		return err
+	}
+	kubeconfigData, err := ioutil.ReadFile(kubeconfig)
 	if err != nil {
 		return err
 	}
 	logrus.Info("Install complete!")
+	if encryption.IsEncrypted(kubeconfigData) {
+		logrus.Infof("The kubeconfig of the system:admin user is encrypted; run 'openshift-install --dir=%s migrate decrypt-state' to decrypt it for 'oc'", absDir)
+	}
 	logrus.Infof("To access the cluster as the system:admin user when using 'oc', run 'export KUBECONFIG=%s'", kubeconfig)
 	logrus.Infof("Access the OpenShift web-console here: %s", consoleURL)
 	logrus.Infof("Login to the console with user: %q, and password: %q", "kubeadmin", pw)
@@ -1,12 +1,21 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
+	"text/tabwriter"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	assetstore "github.com/openshift/installer/pkg/asset/store"
 	"github.com/openshift/installer/pkg/destroy"
@@ -15,10 +24,12 @@ import (
 	_ "github.com/openshift/installer/pkg/destroy/baremetal"
 	"github.com/openshift/installer/pkg/destroy/bootstrap"
 	_ "github.com/openshift/installer/pkg/destroy/gcp"
+	"github.com/openshift/installer/pkg/destroy/journal"
 	_ "github.com/openshift/installer/pkg/destroy/kubevirt"
 	_ "github.com/openshift/installer/pkg/destroy/libvirt"
 	_ "github.com/openshift/installer/pkg/destroy/openstack"
 	_ "github.com/openshift/installer/pkg/destroy/ovirt"
+	"github.com/openshift/installer/pkg/destroy/providers"
 	_ "github.com/openshift/installer/pkg/destroy/vsphere"
 	timer "github.com/openshift/installer/pkg/metrics/timer"
 	"github.com/openshift/installer/pkg/terraform"
@@ -35,35 +46,155 @@ func newDestroyCmd() *cobra.Command {
 	}
 	cmd.AddCommand(newDestroyBootstrapCmd())
 	cmd.AddCommand(newDestroyClusterCmd())
+	cmd.AddCommand(newDestroyOrphansCmd())
 	return cmd
 }
 
+var (
+	destroyClusterOpts struct {
+		dryRun  bool
+		output  string
+		timeout time.Duration
+	}
+)
+
 func newDestroyClusterCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "cluster",
 		Short: "Destroy an OpenShift cluster",
 		Args:  cobra.ExactArgs(0),
-		Run: func(_ *cobra.Command, _ []string) {
+		Run: func(cmd *cobra.Command, _ []string) {
 			cleanup := setupFileHook(rootOpts.dir)
 			defer cleanup()
 
-			err := runDestroyCmd(rootOpts.dir)
+			if !destroyClusterOpts.dryRun && cmd.Flags().Changed("dry-run-output") {
+				logrus.Fatal("--dry-run-output is only used with --dry-run")
+			}
+
+			var err error
+			if destroyClusterOpts.dryRun {
+				err = runDestroyDryRunCmd(os.Stdout, rootOpts.dir, destroyClusterOpts.output)
+			} else {
+				// The metadata and install config are removed with the
+				// cluster, so label the metrics before destroying it.
+				metrics := metricsReport("destroy_cluster", rootOpts.dir)
+				err = runDestroyCmd(rootOpts.dir)
+				pushMetrics(metrics, err)
+			}
 			if err != nil {
 				logrus.Fatal(err)
 			}
 		},
 	}
+	cmd.PersistentFlags().BoolVar(&destroyClusterOpts.dryRun, "dry-run", false, "List the resources that would be destroyed without deleting anything")
+	cmd.PersistentFlags().StringVar(&destroyClusterOpts.output, "dry-run-output", "table", "Output format for --dry-run (e.g. \"table | json\")")
+	cmd.PersistentFlags().DurationVar(&destroyClusterOpts.timeout, "timeout", 0, "Stop destroying the cluster after this long, e.g. 30m. Zero means no timeout")
+	addEventsFlags(cmd)
+	return cmd
+}
+
+// destroyContext returns a context which is canceled on the first SIGINT or
+// SIGTERM, or once timeout has passed if it is not zero. A second signal
+// terminates the installer as usual.
+func destroyContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancelSignal := context.WithCancel(context.Background())
+	cancel := cancelSignal
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+		cancel = func() {
+			cancelTimeout()
+			cancelSignal()
+		}
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-signals:
+			signal.Stop(signals)
+			logrus.Warnf("Received %s, stopping once the pending API calls complete. Send it again to exit immediately", sig)
+			cancelSignal()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel()
+	}
+}
+
+func runDestroyDryRunCmd(out io.Writer, directory string, output string) error {
+	switch output {
+	case "":
+		output = "table"
+	case "table", "json":
+	default:
+		return errors.Errorf("invalid --dry-run-output format %q", output)
+	}
+
+	destroyer, err := destroy.New(logrus.StandardLogger(), directory)
+	if err != nil {
+		return errors.Wrap(err, "Failed while preparing to destroy cluster")
+	}
+	inventory, ok := destroyer.(providers.Inventory)
+	if !ok {
+		return errors.New("dry run is not supported for this platform")
+	}
+	resources, err := inventory.Inventory(context.Background())
+	if err != nil {
+		return errors.Wrap(err, "Failed to list cluster resources")
+	}
+
+	switch output {
+	case "json":
+		if resources == nil {
+			resources = []providers.Resource{}
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(resources)
+	default:
+		w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "TYPE\tID\tOWNERSHIP\tFILTER")
+		for _, resource := range resources {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", resource.Type, resource.ID, resource.Ownership, resource.Filter)
+		}
+		return w.Flush()
+	}
 }
 
 func runDestroyCmd(directory string) error {
+	ctx, cancel := destroyContext(destroyClusterOpts.timeout)
+	defer cancel()
+
 	timer.StartTimer(timer.TotalTimeElapsed)
 	destroyer, err := destroy.New(logrus.StandardLogger(), directory)
 	if err != nil {
 		return errors.Wrap(err, "Failed while preparing to destroy cluster")
 	}
-	if err := destroyer.Run(); err != nil {
+	var destroyJournal *journal.Journal
+	if journaler, ok := destroyer.(providers.Journaler); ok {
+		destroyJournal, err = journal.Open(directory)
+		if err != nil {
+			return errors.Wrap(err, "failed to open destroy journal")
+		}
+		journaler.SetJournal(destroyJournal)
+	}
+	if err := destroyer.Run(ctx); err != nil {
+		if ctx.Err() != nil {
+			logPendingResources(destroyer, ctx.Err())
+		}
+		logDestroyFailures(destroyJournal)
+		if err2 := destroyJournal.Close(); err2 != nil {
+			logrus.Error("Failed to close destroy journal: ", err2)
+		}
 		return errors.Wrap(err, "Failed to destroy cluster")
 	}
+	if err := destroyJournal.Remove(); err != nil {
+		return errors.Wrap(err, "failed to remove destroy journal")
+	}
 
 	store, err := assetstore.NewStore(directory)
 	if err != nil {
@@ -91,8 +222,61 @@ func runDestroyCmd(directory string) error {
 	return nil
 }
 
+// logPendingResources logs the resources which remain after destroy was
+// interrupted or timed out, if the platform can list them.
+func logPendingResources(destroyer providers.Destroyer, cause error) {
+	if cause == context.DeadlineExceeded {
+		logrus.Errorf("Destroy timed out after %s", destroyClusterOpts.timeout)
+	} else {
+		logrus.Error("Destroy was interrupted")
+	}
+
+	inventory, ok := destroyer.(providers.Inventory)
+	if !ok {
+		logrus.Info("Listing the pending resources is not supported for this platform")
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	resources, err := inventory.Inventory(ctx)
+	if err != nil {
+		logrus.Error("Failed to list the pending resources: ", err)
+		return
+	}
+	if len(resources) == 0 {
+		logrus.Info("No resources are pending")
+		return
+	}
+	logrus.Errorf("%d resources were still pending:", len(resources))
+	for _, resource := range resources {
+		logrus.Errorf("  %s %s", resource.Type, resource.ID)
+	}
+}
+
+// logDestroyFailures logs the resources which the journal records as having
+// failed to delete, so that they can be cleaned up or the destroy resumed,
+// and writes them to a file in the asset directory which outlives the log.
+func logDestroyFailures(destroyJournal *journal.Journal) {
+	failures := destroyJournal.Failures()
+	path, err := destroyJournal.WriteFailures()
+	if err != nil {
+		logrus.Error("Failed to write the resources which failed to delete: ", err)
+	}
+	if len(failures) == 0 {
+		return
+	}
+	logrus.Errorf("%d resources failed to delete:", len(failures))
+	for _, failure := range failures {
+		logrus.Errorf("  %s: %s", failure.ID, failure.Error)
+	}
+	if path != "" {
+		logrus.Infof("The resources which failed to delete were written to %s", path)
+	}
+	logrus.Infof("Progress was recorded in %s; run 'destroy cluster' again to resume", journal.FileName)
+}
+
 func newDestroyBootstrapCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "bootstrap",
 		Short: "Destroy the bootstrap resources",
 		Args:  cobra.ExactArgs(0),
@@ -109,4 +293,101 @@ func newDestroyBootstrapCmd() *cobra.Command {
 			timer.LogSummary()
 		},
 	}
+	addEventsFlags(cmd)
+	return cmd
+}
+
+var (
+	destroyOrphansOpts struct {
+		olderThan time.Duration
+		platform  string
+		region    string
+		projectID string
+		dryRun    bool
+	}
+)
+
+func newDestroyOrphansCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "orphans",
+		Short: "Destroy clusters whose resources remain without an install directory",
+		Long: `Find clusters on a platform by the tags or labels that the installer
+and the cluster put on their resources, and destroy every cluster whose
+oldest resource is older than --older-than.
+
+Orphaned clusters are found on AWS by resources tagged
+kubernetes.io/cluster/<infraID>=owned, on Azure by resource groups tagged
+kubernetes.io_cluster.<infraID>=owned, and on GCP by instances labeled
+kubernetes-io-cluster-<infraID>=owned or named after the cluster's infra ID.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			err := runDestroyOrphansCmd()
+			if err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+	cmd.PersistentFlags().DurationVar(&destroyOrphansOpts.olderThan, "older-than", 24*time.Hour, "Only destroy clusters whose oldest resource is older than this")
+	cmd.PersistentFlags().StringVar(&destroyOrphansOpts.platform, "platform", "", "The platform to search for orphaned clusters (e.g. \"aws | azure | gcp\")")
+	cmd.PersistentFlags().StringVar(&destroyOrphansOpts.region, "region", "", "The region to search for orphaned clusters (required for aws and gcp)")
+	cmd.PersistentFlags().StringVar(&destroyOrphansOpts.projectID, "project", "", "The GCP project to search, defaults to the project of the credentials")
+	cmd.PersistentFlags().BoolVar(&destroyOrphansOpts.dryRun, "dry-run", false, "List the orphaned clusters without destroying them")
+	return cmd
+}
+
+func runDestroyOrphansCmd() error {
+	if destroyOrphansOpts.platform == "" {
+		return errors.New("--platform is required")
+	}
+
+	ctx, cancel := destroyContext(0)
+	defer cancel()
+
+	orphans, err := destroy.FindOrphans(
+		ctx,
+		logrus.StandardLogger(),
+		destroyOrphansOpts.platform,
+		destroyOrphansOpts.olderThan,
+		providers.OrphanOptions{
+			Region:    destroyOrphansOpts.region,
+			ProjectID: destroyOrphansOpts.projectID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if len(orphans) == 0 {
+		logrus.Infof("No clusters older than %s found", destroyOrphansOpts.olderThan)
+		return nil
+	}
+
+	if destroyOrphansOpts.dryRun {
+		for _, metadata := range orphans {
+			fmt.Println(metadata.InfraID)
+		}
+		return nil
+	}
+
+	var errs []error
+	for _, metadata := range orphans {
+		logrus.Infof("Destroying cluster %s", metadata.InfraID)
+		logger := logrus.StandardLogger().WithField("cluster", metadata.InfraID)
+		destroyer, err := destroy.NewFromMetadata(logger, metadata)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed while preparing to destroy cluster %s", metadata.InfraID))
+			continue
+		}
+		if err := destroyer.Run(ctx); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to destroy cluster %s", metadata.InfraID))
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		logrus.Infof("Destroyed cluster %s", metadata.InfraID)
+	}
+	return utilerrors.NewAggregate(errs)
 }
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+)
+
+type fakeInventory []providers.Resource
+
+func (fakeInventory) Run(context.Context) error {
+	return nil
+}
+
+func (f fakeInventory) Inventory(context.Context) ([]providers.Resource, error) {
+	return f, nil
+}
+
+// withFakeDestroyer writes the metadata of a libvirt cluster to a new asset
+// directory and makes the libvirt destroyer return destroyer. It returns the
+// directory and a function that undoes both.
+func withFakeDestroyer(t *testing.T, destroyer providers.Destroyer) (string, func()) {
+	dir, err := ioutil.TempDir("", "destroy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata := `{"clusterName": "cluster", "infraID": "cluster-abcde", "libvirt": {"uri": "qemu:///system"}}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "metadata.json"), []byte(metadata), 0640); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+
+	saved := providers.Registry["libvirt"]
+	providers.Registry["libvirt"] = func(logrus.FieldLogger, *types.ClusterMetadata) (providers.Destroyer, error) {
+		return destroyer, nil
+	}
+	return dir, func() {
+		providers.Registry["libvirt"] = saved
+		os.RemoveAll(dir)
+	}
+}
+
+func TestRunDestroyDryRunCmd(t *testing.T) {
+	resources := fakeInventory{
+		{Type: "instance", ID: "cluster-abcde-master-0", Filter: "tag kubernetes.io/cluster/cluster-abcde=owned", Ownership: providers.Owned},
+		{Type: "security-group", ID: "sg-0123456789", Filter: "tag kubernetes.io/cluster/cluster-abcde=shared", Ownership: providers.Shared},
+	}
+
+	cases := []struct {
+		name      string
+		destroyer providers.Destroyer
+		output    string
+		expected  string
+		err       string
+	}{
+		{
+			name:      "table",
+			destroyer: resources,
+			expected: `TYPE            ID                      OWNERSHIP  FILTER
+instance        cluster-abcde-master-0  owned      tag kubernetes.io/cluster/cluster-abcde=owned
+security-group  sg-0123456789           shared     tag kubernetes.io/cluster/cluster-abcde=shared
+`,
+		},
+		{
+			name:      "explicit table",
+			destroyer: resources,
+			output:    "table",
+			expected: `TYPE            ID                      OWNERSHIP  FILTER
+instance        cluster-abcde-master-0  owned      tag kubernetes.io/cluster/cluster-abcde=owned
+security-group  sg-0123456789           shared     tag kubernetes.io/cluster/cluster-abcde=shared
+`,
+		},
+		{
+			name:      "json",
+			destroyer: resources,
+			output:    "json",
+			expected: `[
+  {
+    "type": "instance",
+    "id": "cluster-abcde-master-0",
+    "filter": "tag kubernetes.io/cluster/cluster-abcde=owned",
+    "ownership": "owned"
+  },
+  {
+    "type": "security-group",
+    "id": "sg-0123456789",
+    "filter": "tag kubernetes.io/cluster/cluster-abcde=shared",
+    "ownership": "shared"
+  }
+]
+`,
+		},
+		{
+			name:      "empty json",
+			destroyer: fakeInventory(nil),
+			output:    "json",
+			expected:  "[]\n",
+		},
+		{
+			name:      "empty table",
+			destroyer: fakeInventory(nil),
+			expected:  "TYPE  ID  OWNERSHIP  FILTER\n",
+		},
+		{
+			name:      "invalid output",
+			destroyer: resources,
+			output:    "yaml",
+			err:       `^invalid --dry-run-output format "yaml"$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, cleanup := withFakeDestroyer(t, tc.destroyer)
+			defer cleanup()
+
+			out := &bytes.Buffer{}
+			err := runDestroyDryRunCmd(out, dir, tc.output)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, out.String())
+			} else {
+				assert.Regexp(t, tc.err, err)
+			}
+		})
+	}
+}
+
+type fakeDestroyer struct{}
+
+func (fakeDestroyer) Run(context.Context) error {
+	return nil
+}
+
+func TestRunDestroyDryRunCmdUnsupported(t *testing.T) {
+	dir, cleanup := withFakeDestroyer(t, fakeDestroyer{})
+	defer cleanup()
+
+	err := runDestroyDryRunCmd(&bytes.Buffer{}, dir, "")
+	assert.EqualError(t, err, "dry run is not supported for this platform")
+}
+
+func TestDestroyClusterCmdFlags(t *testing.T) {
+	defer func() {
+		destroyClusterOpts.dryRun, destroyClusterOpts.output = false, "table"
+		eventsOpts.output = ""
+	}()
+
+	cmd := newDestroyClusterCmd()
+	if !assert.NoError(t, cmd.ParseFlags([]string{"--dry-run", "--dry-run-output=json", "--output=json-events"})) {
+		return
+	}
+	assert.Equal(t, "json", destroyClusterOpts.output)
+	assert.Equal(t, "json-events", eventsOpts.output)
+	assert.NotNil(t, cmd.Flags().Lookup("events-file"))
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset"
+	assetdiff "github.com/openshift/installer/pkg/asset/diff"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	targetassets "github.com/openshift/installer/pkg/asset/targets"
+)
+
+var (
+	diffAssetsOpts struct {
+		from string
+		to   string
+	}
+)
+
+func newDiffAssetsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff-assets",
+		Short: "Show the differences between the assets of two asset directories",
+		Long: `Show the differences between the assets of two asset directories.
+
+The assets are loaded from the state file and the files of each directory, and
+compared semantically: YAML and JSON are compared with their keys sorted,
+Ignition configs are compared with the files they write decoded, and secrets
+are replaced with a hash of their contents.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			// The command only reads the asset directories, so it does not
+			// write a log file in any of them.
+			d, err := diffAssets(diffAssetsOpts.from, diffAssetsOpts.to)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			fmt.Print(d)
+		},
+	}
+	cmd.Flags().StringVar(&diffAssetsOpts.from, "from", "", "asset directory to compare from")
+	cmd.Flags().StringVar(&diffAssetsOpts.to, "to", "", "asset directory to compare to")
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+// diffAssets returns a diff of the files of the targeted assets in the from
+// and to directories.
+func diffAssets(from, to string) (string, error) {
+	fromFiles, err := loadAssetFiles(from)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to load assets from %s", from)
+	}
+	toFiles, err := loadAssetFiles(to)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to load assets from %s", to)
+	}
+	return assetdiff.Files(fromFiles, toFiles)
+}
+
+// loadAssetFiles returns the files of the targeted assets that are present
+// in the store in directory, keyed by their file names. Assets are loaded but
+// never generated.
+func loadAssetFiles(directory string) (map[string][]byte, error) {
+	if _, err := os.Stat(directory); err != nil {
+		return nil, err
+	}
+	store, err := assetstore.NewStore(directory)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create asset store")
+	}
+
+	files := map[string][]byte{}
+	seen := map[reflect.Type]bool{}
+	for _, targets := range [][]asset.WritableAsset{
+		targetassets.InstallConfig,
+		targetassets.Manifests,
+		targetassets.ManifestTemplates,
+		targetassets.IgnitionConfigs,
+		targetassets.Cluster,
+	} {
+		for _, a := range targets {
+			if seen[reflect.TypeOf(a)] {
+				continue
+			}
+			seen[reflect.TypeOf(a)] = true
+
+			loaded, err := store.Load(a)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to load %s", a.Name())
+			}
+			if loaded == nil {
+				continue
+			}
+			for _, f := range loaded.(asset.WritableAsset).Files() {
+				files[f.Filename] = f.Data
+			}
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/events"
+)
+
+// eventsFileName is the name of the file in the asset directory which
+// --output=json-events writes to by default.
+const eventsFileName = ".openshift_install_events.json"
+
+var (
+	eventsOpts struct {
+		output string
+		file   string
+	}
+)
+
+// addEventsFlags adds the flags which enable the event stream to a command
+// which emits events, and starts writing the events before the command runs.
+func addEventsFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&eventsOpts.output, "output", "", "output format (e.g. \"json-events\" to write progress events to --events-file)")
+	cmd.Flags().StringVar(&eventsOpts.file, "events-file", "", fmt.Sprintf("file which --output=json-events writes to (defaults to %s in the asset directory)", eventsFileName))
+	cmd.PreRun = func(_ *cobra.Command, _ []string) {
+		if err := setupEvents(rootOpts.dir); err != nil {
+			logrus.Fatal(err)
+		}
+	}
+}
+
+// setupEvents starts writing events to a file if they were requested. The
+// file is appended to, like the log, and stays open until the installer
+// exits.
+func setupEvents(directory string) error {
+	switch eventsOpts.output {
+	case "":
+		if eventsOpts.file != "" {
+			return errors.New("--events-file is only used with --output=json-events")
+		}
+		return nil
+	case "json-events":
+	default:
+		return errors.Errorf("invalid output format %q (the only format is \"json-events\")", eventsOpts.output)
+	}
+
+	path := eventsOpts.file
+	if path == "" {
+		if err := os.MkdirAll(directory, 0755); err != nil {
+			return errors.Wrap(err, "failed to create base directory for events")
+		}
+		path = filepath.Join(directory, eventsFileName)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return errors.Wrap(err, "failed to open events file")
+	}
+	events.SetOutput(f)
+	logrus.AddHook(&events.FatalHook{})
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/events"
+)
+
+func TestSetupEvents(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer events.SetOutput(nil)
+
+	cases := []struct {
+		name   string
+		output string
+		file   string
+		path   string
+		err    string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:   "default file",
+			output: "json-events",
+			path:   filepath.Join(dir, eventsFileName),
+		},
+		{
+			name:   "events file",
+			output: "json-events",
+			file:   filepath.Join(dir, "events"),
+			path:   filepath.Join(dir, "events"),
+		},
+		{
+			name: "events file without output",
+			file: filepath.Join(dir, "events"),
+			err:  `^--events-file is only used with --output=json-events$`,
+		},
+		{
+			name:   "invalid output",
+			output: "json",
+			err:    `^invalid output format "json" \(the only format is "json-events"\)$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			eventsOpts.output, eventsOpts.file = tc.output, tc.file
+			defer func() { eventsOpts.output, eventsOpts.file = "", "" }()
+			events.SetOutput(nil)
+
+			err := setupEvents(dir)
+			if tc.err != "" {
+				assert.Regexp(t, tc.err, err)
+				assert.False(t, events.Enabled())
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			if tc.path == "" {
+				assert.False(t, events.Enabled())
+				return
+			}
+
+			events.PhaseStarted("Total")
+			data, err := ioutil.ReadFile(tc.path)
+			if assert.NoError(t, err) {
+				var event events.Event
+				assert.NoError(t, json.Unmarshal(data, &event))
+				assert.Equal(t, events.PhaseStart, event.Type)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io/ioutil"
@@ -17,12 +18,15 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	gossh "golang.org/x/crypto/ssh"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 
+	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	assetstore "github.com/openshift/installer/pkg/asset/store"
 	"github.com/openshift/installer/pkg/asset/tls"
+	gathercluster "github.com/openshift/installer/pkg/gather/cluster"
 	"github.com/openshift/installer/pkg/gather/ssh"
 	"github.com/openshift/installer/pkg/terraform"
 	gatheraws "github.com/openshift/installer/pkg/terraform/gather/aws"
@@ -60,11 +64,17 @@ to debug the installation failures`,
 		},
 	}
 	cmd.AddCommand(newGatherBootstrapCmd())
+	cmd.AddCommand(newGatherClusterCmd())
 	return cmd
 }
 
+// knownHostsFileName is the name of the known_hosts file, in the asset
+// directory, of the control plane hosts that were trusted on first use.
+const knownHostsFileName = ".openshift_install_known_hosts"
+
 var (
 	gatherBootstrapOpts struct {
+		bastion   string
 		bootstrap string
 		masters   []string
 		sshKeys   []string
@@ -88,9 +98,60 @@ func newGatherBootstrapCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&gatherBootstrapOpts.bootstrap, "bootstrap", "", "Hostname or IP of the bootstrap host")
 	cmd.PersistentFlags().StringArrayVar(&gatherBootstrapOpts.masters, "master", []string{}, "Hostnames or IPs of all control plane hosts")
 	cmd.PersistentFlags().StringArrayVar(&gatherBootstrapOpts.sshKeys, "key", []string{}, "Path to SSH private keys that should be used for authentication. If no key was provided, SSH private keys from user's environment will be used")
+	addBastionFlag(cmd)
 	return cmd
 }
 
+// addBastionFlag adds the flag for the jump hosts to reach the bootstrap host
+// through to cmd.
+func addBastionFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&gatherBootstrapOpts.bastion, "bastion", "", "Jump host to reach the bootstrap host through when gathering its logs, as user@host[:port]. Multiple comma-separated jump hosts are connected through in order, like ssh's ProxyJump")
+}
+
+func newGatherClusterCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cluster",
+		Short: "Gather debugging data for a cluster that failed to complete installation",
+		Long: `Gather debugging data for a cluster that failed to complete installation.
+
+Once the bootstrap host is gone, debugging data is gathered through the cluster's
+API with the admin kubeconfig in the asset directory: the ClusterVersion,
+ClusterOperators, nodes, machines, machine sets, events and the logs of failing
+pods in the platform namespaces.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			config, err := loadAdminKubeconfig(rootOpts.dir)
+			if err != nil {
+				logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
+			}
+			if err := runGatherClusterCmd(context.Background(), config, rootOpts.dir); err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+}
+
+func runGatherClusterCmd(ctx context.Context, config *rest.Config, directory string) error {
+	logrus.Info("Pulling debug logs from the cluster")
+	gatherer, err := gathercluster.NewGatherer(config)
+	if err != nil {
+		return err
+	}
+	file, err := gatherer.Gather(ctx, directory, time.Now().Format("20060102150405"))
+	if err != nil {
+		return err
+	}
+	path, err := filepath.Abs(file)
+	if err != nil {
+		return errors.Wrap(err, "failed to stat log file")
+	}
+	logrus.Infof("Cluster gather logs captured here %q", path)
+	return nil
+}
+
 func runGatherBootstrapCmd(directory string) error {
 	assetStore, err := assetstore.NewStore(directory)
 	if err != nil {
@@ -114,10 +175,19 @@ func runGatherBootstrapCmd(directory string) error {
 	}
 	gatherBootstrapOpts.sshKeys = append(gatherBootstrapOpts.sshKeys, tmpfile.Name())
 
+	hostKey, err := bootstrapHostKey(assetStore, directory)
+	if err != nil {
+		return err
+	}
+	jumpHosts, err := bastionJumpHosts(directory)
+	if err != nil {
+		return err
+	}
+
 	tfStateFilePath := filepath.Join(directory, terraform.StateFileName)
 	_, err = os.Stat(tfStateFilePath)
 	if os.IsNotExist(err) {
-		return unSupportedPlatformGather(directory)
+		return unSupportedPlatformGather(directory, hostKey, jumpHosts)
 	}
 	if err != nil {
 		return err
@@ -136,17 +206,75 @@ func runGatherBootstrapCmd(directory string) error {
 	if err != nil {
 		if err2, ok := err.(errUnSupportedGatherPlatform); ok {
 			logrus.Error(err2)
-			return unSupportedPlatformGather(directory)
+			return unSupportedPlatformGather(directory, hostKey, jumpHosts)
 		}
 		return errors.Wrapf(err, "failed to get bootstrap and control plane host addresses from %q", tfStateFilePath)
 	}
 
-	return logGatherBootstrap(bootstrap, port, masters, directory)
+	return logGatherBootstrap(bootstrap, port, masters, directory, hostKey, jumpHosts)
+}
+
+// bootstrapHostKey returns the verifier of the bootstrap host's SSH host key.
+// The key generated by the installer is pinned. Asset directories created by
+// older installers do not have it, so the bootstrap host is trusted on first
+// use instead.
+func bootstrapHostKey(assetStore asset.Store, directory string) (ssh.HostKey, error) {
+	hostKeyPair, err := assetStore.Load(&tls.BootstrapSSHHostKeyPair{})
+	if err != nil {
+		return ssh.HostKey{}, errors.Wrap(err, "failed to load the bootstrap SSH host key")
+	}
+	if hostKeyPair == nil {
+		logrus.Warn("The bootstrap SSH host key was not found in the asset directory, trusting the bootstrap host on first use")
+		return ssh.TrustOnFirstUse(filepath.Join(directory, knownHostsFileName))
+	}
+	return ssh.PinnedHostKey(hostKeyPair.(*tls.BootstrapSSHHostKeyPair).Public())
+}
+
+// bastionJumpHosts returns the jump hosts given by --bastion. Their host keys
+// are trusted on first use.
+func bastionJumpHosts(directory string) ([]ssh.JumpHost, error) {
+	jumpHosts, err := ssh.ParseJumpHosts(gatherBootstrapOpts.bastion)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid --bastion")
+	}
+	if len(jumpHosts) == 0 {
+		return nil, nil
+	}
+	hostKey, err := ssh.TrustOnFirstUse(filepath.Join(directory, knownHostsFileName))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize the known hosts")
+	}
+	for i := range jumpHosts {
+		jumpHosts[i].HostKey = hostKey
+	}
+	return jumpHosts, nil
+}
+
+// controlPlaneKnownHosts verifies the SSH host keys of the control plane
+// hosts through the bootstrap host, trusting them on first use, and returns a
+// known_hosts file with the verified keys. Hosts that fail verification are
+// left out, so that the bootstrap host refuses to connect to them.
+func controlPlaneKnownHosts(client *gossh.Client, masters []string, directory string) ([]byte, error) {
+	hostKey, err := ssh.TrustOnFirstUse(filepath.Join(directory, knownHostsFileName))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize the known hosts")
+	}
+
+	var knownHosts bytes.Buffer
+	for _, master := range masters {
+		key, err := ssh.VerifyHostKey(client, net.JoinHostPort(master, "22"), hostKey)
+		if err != nil {
+			logrus.Errorf("Failed to verify the SSH host key of %s: %v", master, err)
+			continue
+		}
+		fmt.Fprintf(&knownHosts, "%s %s", master, gossh.MarshalAuthorizedKey(key))
+	}
+	return knownHosts.Bytes(), nil
 }
 
-func logGatherBootstrap(bootstrap string, port int, masters []string, directory string) error {
+func logGatherBootstrap(bootstrap string, port int, masters []string, directory string, hostKey ssh.HostKey, jumpHosts []ssh.JumpHost) error {
 	logrus.Info("Pulling debug logs from the bootstrap machine")
-	client, err := ssh.NewClient("core", net.JoinHostPort(bootstrap, strconv.Itoa(port)), gatherBootstrapOpts.sshKeys)
+	client, err := ssh.NewClient("core", net.JoinHostPort(bootstrap, strconv.Itoa(port)), gatherBootstrapOpts.sshKeys, hostKey, jumpHosts...)
 	if err != nil {
 		if errors.Is(err, syscall.ECONNREFUSED) {
 			return errors.Wrap(err, "failed to connect to the bootstrap machine")
@@ -155,7 +283,20 @@ func logGatherBootstrap(bootstrap string, port int, masters []string, directory
 	}
 
 	gatherID := time.Now().Format("20060102150405")
-	if err := ssh.Run(client, fmt.Sprintf("/usr/local/bin/installer-gather.sh --id %s %s", gatherID, strings.Join(masters, " "))); err != nil {
+	args := []string{"--id", gatherID}
+	if len(masters) > 0 {
+		knownHosts, err := controlPlaneKnownHosts(client, masters, directory)
+		if err != nil {
+			return err
+		}
+		knownHostsPath := fmt.Sprintf("/home/core/known_hosts-%s", gatherID)
+		if err := ssh.WriteFileTo(client, knownHosts, knownHostsPath); err != nil {
+			return errors.Wrap(err, "failed to push the control plane known hosts")
+		}
+		args = append(args, "--known-hosts", knownHostsPath)
+	}
+	args = append(args, masters...)
+	if err := ssh.Run(client, fmt.Sprintf("/usr/local/bin/installer-gather.sh %s", strings.Join(args, " "))); err != nil {
 		return errors.Wrap(err, "failed to run remote command")
 	}
 	file := filepath.Join(directory, fmt.Sprintf("log-bundle-%s.tar.gz", gatherID))
@@ -259,12 +400,12 @@ func (e errUnSupportedGatherPlatform) Error() string {
 	return e.Message
 }
 
-func unSupportedPlatformGather(directory string) error {
+func unSupportedPlatformGather(directory string, hostKey ssh.HostKey, jumpHosts []ssh.JumpHost) error {
 	if gatherBootstrapOpts.bootstrap == "" || len(gatherBootstrapOpts.masters) == 0 {
 		return errors.New("bootstrap host address and at least one control plane host address must be provided")
 	}
 
-	return logGatherBootstrap(gatherBootstrapOpts.bootstrap, 22, gatherBootstrapOpts.masters, directory)
+	return logGatherBootstrap(gatherBootstrapOpts.bootstrap, 22, gatherBootstrapOpts.masters, directory, hostKey, jumpHosts)
 }
 
 func logClusterOperatorConditions(ctx context.Context, config *rest.Config) error {
@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -14,13 +15,15 @@ import (
 	"k8s.io/klog"
 	klogv2 "k8s.io/klog/v2"
 
+	"github.com/openshift/installer/pkg/metrics/report"
 	"github.com/openshift/installer/pkg/terraform/exec/plugins"
 )
 
 var (
 	rootOpts struct {
-		dir      string
-		logLevel string
+		dir                string
+		logLevel           string
+		metricsPushgateway string
 	}
 )
 
@@ -63,6 +66,9 @@ func installerMain() {
 		newCompletionCmd(),
 		newMigrateCmd(),
 		newExplainCmd(),
+		newDiffAssetsCmd(),
+		newValidateCmd(),
+		newStatusCmd(),
 	} {
 		rootCmd.AddCommand(subCmd)
 	}
@@ -83,6 +89,7 @@ func newRootCmd() *cobra.Command {
 	}
 	cmd.PersistentFlags().StringVar(&rootOpts.dir, "dir", ".", "assets directory")
 	cmd.PersistentFlags().StringVar(&rootOpts.logLevel, "log-level", "info", "log level (e.g. \"debug | info | warn | error\")")
+	cmd.PersistentFlags().StringVar(&rootOpts.metricsPushgateway, "metrics-pushgateway", "", fmt.Sprintf("URL of a Prometheus Pushgateway to push install metrics to (defaults to $%s)", report.PushgatewayEnv))
 	return cmd
 }
 
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	"github.com/openshift/installer/pkg/metrics/report"
+	timer "github.com/openshift/installer/pkg/metrics/timer"
+)
+
+// metricsPushgateway returns the URL of the Pushgateway to push metrics to,
+// or an empty string if metrics are not pushed.
+func metricsPushgateway() string {
+	if rootOpts.metricsPushgateway != "" {
+		return rootOpts.metricsPushgateway
+	}
+	return os.Getenv(report.PushgatewayEnv)
+}
+
+// metricsReport returns a metrics report for command, labelled from the
+// cluster metadata and install config in directory as far as they exist.
+func metricsReport(command string, directory string) *report.Report {
+	r := &report.Report{Command: command}
+	if metricsPushgateway() == "" {
+		return r
+	}
+
+	if metadata, err := cluster.LoadMetadata(directory); err == nil {
+		r.Platform = metadata.Platform()
+		r.InfraID = metadata.InfraID
+	}
+	assetStore, err := assetstore.NewStore(directory)
+	if err != nil {
+		logrus.Debugf("Unable to label metrics from the install config: %v", err)
+		return r
+	}
+	if a, err := assetStore.Load(&installconfig.InstallConfig{}); err == nil && a != nil {
+		config := a.(*installconfig.InstallConfig).Config
+		r.Options = report.InstallConfigOptions(config)
+		if r.Platform == "" {
+			r.Platform = config.Platform.Name()
+		}
+	}
+	return r
+}
+
+// pushMetrics pushes r with the timer's stages and the command's error to
+// the configured Pushgateway, if any. Failing to push metrics is logged but
+// does not fail the command.
+func pushMetrics(r *report.Report, err error) {
+	url := metricsPushgateway()
+	if url == "" {
+		return
+	}
+	r.Stages = timer.StageTimes()
+	r.Err = err
+	if err := r.Push(url); err != nil {
+		logrus.Warnf("Failed to push metrics to %s: %v", url, err)
+		return
+	}
+	logrus.Debugf("Pushed metrics to %s", url)
+}
@@ -1,9 +1,15 @@
 package main
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	azure "github.com/openshift/installer/cmd/openshift-install/migrate/azure"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	"github.com/openshift/installer/pkg/asset/store/encryption"
 )
 
 func newMigrateCmd() *cobra.Command {
@@ -18,6 +24,58 @@ func newMigrateCmd() *cobra.Command {
 
 	migrateCmd.AddCommand(azure.NewMigrateAzurePrivateDNSEligibleCmd())
 	migrateCmd.AddCommand(azure.NewMigrateAzurePrivateDNSMigrateCmd())
+	migrateCmd.AddCommand(newMigrateEncryptStateCmd())
+	migrateCmd.AddCommand(newMigrateDecryptStateCmd())
 
 	return migrateCmd
 }
+
+func newMigrateEncryptStateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "encrypt-state",
+		Short: "Encrypt the state file and the generated secrets of an asset directory",
+		Long: fmt.Sprintf(`Encrypt the state file and the generated secrets of an asset directory.
+
+The state file and the secrets that the installer generated in the asset
+directory, auth/kubeconfig, auth/kubeadmin-password and the private keys in
+tls/, are encrypted with the key provider configured by %s.
+Once they are encrypted, the installer can only read them with the same
+configuration, and encrypts them again whenever it writes them. Files that
+are already encrypted are encrypted again under a new data key, so they can
+be moved to another passphrase or key by decrypting them with the old one
+and encrypting them with the new one.
+
+The Ignition configs are not encrypted, since the machines read them.`, strings.Join(encryption.Envs(), " or ")),
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			if err := assetstore.EncryptState(rootOpts.dir); err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+}
+
+func newMigrateDecryptStateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "decrypt-state",
+		Short: "Decrypt the state file and the generated secrets of an asset directory",
+		Long: `Decrypt the state file and the generated secrets of an asset directory.
+
+The state file and the secrets that the installer generated in the asset
+directory are decrypted with the key provider they were encrypted with, which
+must be configured as it was when they were encrypted. Unset its
+configuration afterwards to keep them decrypted.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			if err := assetstore.DecryptState(rootOpts.dir); err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+}
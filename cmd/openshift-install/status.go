@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+)
+
+var (
+	statusOpts struct {
+		output string
+	}
+)
+
+func newStatusCmd() *cobra.Command {
+	var names []string
+	for _, t := range targets {
+		names = append(names, t.command.Use)
+	}
+	cmd := &cobra.Command{
+		Use:   "status [TARGET]",
+		Short: "Show where each asset of a target would come from",
+		Long: fmt.Sprintf(`Show where each asset of a target would come from.
+
+TARGET is one of %s, and defaults to cluster. Its assets are
+listed in the order they would be fetched, with their source:
+
+  onDisk     the copy in the asset directory would be used
+  stateFile  the copy in the state file would be used
+  generated  the asset would be generated again
+
+An asset is regenerated when any of its dependencies was changed in the asset
+directory, and those dependencies are listed as its dirty parents. Its own
+copy in the asset directory, if any, is then discarded. Assets that are
+consumed by later assets are purged from the asset directory when the target
+is created.
+
+Nothing is generated or written.`, strings.Join(names, ", ")),
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			name := "cluster"
+			if len(args) > 0 {
+				name = args[0]
+			}
+			if err := runStatusCmd(rootOpts.dir, name, statusOpts.output); err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&statusOpts.output, "output", "table", "output format (e.g. \"table | json\")")
+	return cmd
+}
+
+func runStatusCmd(directory string, name string, output string) error {
+	switch output {
+	case "":
+		output = "table"
+	case "table", "json":
+	default:
+		return errors.Errorf("invalid output format %q for status", output)
+	}
+
+	var t *target
+	for i := range targets {
+		if targets[i].command.Use == name {
+			t = &targets[i]
+		}
+	}
+	if t == nil {
+		return errors.Errorf("unknown target %q", name)
+	}
+
+	statuses, err := assetstore.Status(directory, t.assets)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the status of %s", name)
+	}
+
+	switch output {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(statuses)
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "ASSET\tSOURCE\tON DISK\tDIRTY PARENTS\tFILES")
+		for _, status := range statuses {
+			onDisk := "-"
+			if status.Writable {
+				onDisk = "no"
+				if status.Purged {
+					onDisk = "purged"
+				} else if status.PresentOnDisk {
+					onDisk = "yes"
+				}
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", status.Name, status.Source, onDisk, listOrDash(status.DirtyParents), listOrDash(status.Files))
+		}
+		return w.Flush()
+	}
+}
+
+func listOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
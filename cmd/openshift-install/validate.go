@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/explain"
+)
+
+var (
+	validateInstallConfigOpts struct {
+		offline bool
+		output  string
+	}
+)
+
+func newValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate install assets",
+		Long:  "",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newValidateInstallConfigCmd())
+	return cmd
+}
+
+func newValidateInstallConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install-config [FILE]",
+		Short: "Validate an install-config without creating any assets",
+		Long: `Validate an install-config without creating any assets.
+
+The install-config is validated against the InstallConfig schema, then
+upconverted, defaulted and validated as it is when assets are created. Unless
+--offline is given, it is then validated against the platform's APIs, which
+needs credentials for the platform.
+
+FILE defaults to install-config.yaml in the asset directory. Each error is
+printed with its field path, type and detail; use --output=json for
+machine-readable output. The command fails if the install-config is invalid.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			path := filepath.Join(rootOpts.dir, "install-config.yaml")
+			if len(args) == 1 {
+				path = args[0]
+			}
+			valid, err := runValidateInstallConfigCmd(path, validateInstallConfigOpts.offline, validateInstallConfigOpts.output)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			if !valid {
+				logrus.Fatalf("%s is invalid", path)
+			}
+		},
+	}
+	cmd.PersistentFlags().BoolVar(&validateInstallConfigOpts.offline, "offline", false, "Skip the validation that calls the platform's APIs")
+	cmd.PersistentFlags().StringVar(&validateInstallConfigOpts.output, "output", "", "output format (e.g. \"json\" for machine-readable output)")
+	return cmd
+}
+
+// validationError is the JSON form of a field.Error.
+type validationError struct {
+	Field  string      `json:"field"`
+	Type   string      `json:"type"`
+	Value  interface{} `json:"value,omitempty"`
+	Detail string      `json:"detail,omitempty"`
+}
+
+// validationResult is the JSON output of validate install-config.
+type validationResult struct {
+	Valid  bool              `json:"valid"`
+	Errors []validationError `json:"errors"`
+}
+
+// runValidateInstallConfigCmd validates the install-config at path and
+// prints the errors. The first return is false if the install-config is
+// invalid.
+func runValidateInstallConfigCmd(path string, offline bool, output string) (bool, error) {
+	switch output {
+	case "", "json":
+	default:
+		return false, errors.Errorf("invalid output format %q for validate install-config", output)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read install-config")
+	}
+	schema, err := explain.InstallConfigSchema()
+	if err != nil {
+		return false, err
+	}
+
+	config, allErrs, err := installconfig.ValidateOffline(data, schema)
+	if err != nil {
+		return false, err
+	}
+	if len(allErrs) == 0 && !offline {
+		if err := installconfig.ValidatePlatform(config); err != nil {
+			allErrs = platformErrors(err)
+		}
+	}
+
+	if output == "json" {
+		result := validationResult{Valid: len(allErrs) == 0, Errors: []validationError{}}
+		for _, err := range allErrs {
+			result.Errors = append(result.Errors, newValidationError(err))
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return result.Valid, encoder.Encode(result)
+	}
+	for _, err := range allErrs {
+		fmt.Println(err.Error())
+	}
+	if len(allErrs) == 0 {
+		logrus.Infof("%s is valid", path)
+	}
+	return len(allErrs) == 0, nil
+}
+
+// platformErrors returns the field errors of an error returned by the
+// platform validation, which are usually aggregated field errors.
+func platformErrors(err error) field.ErrorList {
+	var errs []error
+	if agg, ok := err.(interface{ Errors() []error }); ok {
+		errs = agg.Errors()
+	} else {
+		errs = []error{err}
+	}
+
+	allErrs := field.ErrorList{}
+	for _, err := range errs {
+		if fieldErr, ok := errors.Cause(err).(*field.Error); ok {
+			allErrs = append(allErrs, fieldErr)
+			continue
+		}
+		allErrs = append(allErrs, field.InternalError(field.NewPath("platform"), err))
+	}
+	return allErrs
+}
+
+func newValidationError(err *field.Error) validationError {
+	e := validationError{
+		Field:  err.Field,
+		Type:   string(err.Type),
+		Detail: err.Detail,
+	}
+	switch err.Type {
+	case field.ErrorTypeRequired, field.ErrorTypeForbidden, field.ErrorTypeInternal:
+	default:
+		e.Value = err.BadValue
+	}
+	return e
+}
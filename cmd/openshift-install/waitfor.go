@@ -2,13 +2,11 @@ package main
 
 import (
 	"context"
-	"path/filepath"
 
 	timer "github.com/openshift/installer/pkg/metrics/timer"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"k8s.io/client-go/tools/clientcmd"
 )
 
 func newWaitForCmd() *cobra.Command {
@@ -30,7 +28,7 @@ them directly.`,
 }
 
 func newWaitForBootstrapCompleteCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "bootstrap-complete",
 		Short: "Wait until cluster bootstrapping has completed",
 		Args:  cobra.ExactArgs(0),
@@ -41,12 +39,14 @@ func newWaitForBootstrapCompleteCmd() *cobra.Command {
 			cleanup := setupFileHook(rootOpts.dir)
 			defer cleanup()
 
-			config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(rootOpts.dir, "auth", "kubeconfig"))
+			config, err := loadAdminKubeconfig(rootOpts.dir)
 			if err != nil {
-				logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
+				err = errors.Wrap(err, "loading kubeconfig")
+				pushMetrics(metricsReport("wait_for_bootstrap_complete", rootOpts.dir), err)
+				logrus.Fatal(err)
 			}
 			timer.StartTimer("Bootstrap Complete")
-			err = waitForBootstrapComplete(ctx, config)
+			err = waitForBootstrapComplete(ctx, config, rootOpts.dir)
 			if err != nil {
 				if err2 := logClusterOperatorConditions(ctx, config); err2 != nil {
 					logrus.Error("Attempted to gather ClusterOperator status after wait failure: ", err2)
@@ -54,6 +54,7 @@ func newWaitForBootstrapCompleteCmd() *cobra.Command {
 
 				logrus.Info("Use the following commands to gather logs from the cluster")
 				logrus.Info("openshift-install gather bootstrap --help")
+				pushMetrics(metricsReport("wait_for_bootstrap_complete", rootOpts.dir), err)
 				logrus.Fatal(err)
 			}
 
@@ -61,12 +62,15 @@ func newWaitForBootstrapCompleteCmd() *cobra.Command {
 			timer.StopTimer("Bootstrap Complete")
 			timer.StopTimer(timer.TotalTimeElapsed)
 			timer.LogSummary()
+			pushMetrics(metricsReport("wait_for_bootstrap_complete", rootOpts.dir), nil)
 		},
 	}
+	addEventsFlags(cmd)
+	return cmd
 }
 
 func newWaitForInstallCompleteCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "install-complete",
 		Short: "Wait until the cluster is ready",
 		Args:  cobra.ExactArgs(0),
@@ -77,9 +81,11 @@ func newWaitForInstallCompleteCmd() *cobra.Command {
 			cleanup := setupFileHook(rootOpts.dir)
 			defer cleanup()
 
-			config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(rootOpts.dir, "auth", "kubeconfig"))
+			config, err := loadAdminKubeconfig(rootOpts.dir)
 			if err != nil {
-				logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
+				err = errors.Wrap(err, "loading kubeconfig")
+				pushMetrics(metricsReport("wait_for_install_complete", rootOpts.dir), err)
+				logrus.Fatal(err)
 			}
 
 			err = waitForInstallComplete(ctx, config, rootOpts.dir)
@@ -88,10 +94,14 @@ func newWaitForInstallCompleteCmd() *cobra.Command {
 					logrus.Error("Attempted to gather ClusterOperator status after wait failure: ", err2)
 				}
 				logTroubleshootingLink()
+				pushMetrics(metricsReport("wait_for_install_complete", rootOpts.dir), err)
 				logrus.Fatal(err)
 			}
 			timer.StopTimer(timer.TotalTimeElapsed)
 			timer.LogSummary()
+			pushMetrics(metricsReport("wait_for_install_complete", rootOpts.dir), nil)
 		},
 	}
+	addEventsFlags(cmd)
+	return cmd
 }
@@ -35,4 +35,5 @@ module "masters" {
   master_ignition_url         = var.master_ignition_url
   master_ignition_url_ca_cert = var.master_ignition_url_ca_cert
   master_ignition_url_headers = var.master_ignition_url_headers
+  user_data                   = var.user_data
 }
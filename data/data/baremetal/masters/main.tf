@@ -32,7 +32,9 @@ resource "ironic_allocation_v1" "openshift-master-allocation" {
   count          = var.master_count
   resource_class = "baremetal"
 
-  candidate_nodes = ironic_node_v1.openshift-master-host.*.id
+  # Each allocation is pinned to its host, so that the deployment of a
+  # host gets the per-host ignition of that host.
+  candidate_nodes = [ironic_node_v1.openshift-master-host[count.index].id]
 }
 
 resource "ironic_deployment" "openshift-master-deployment" {
@@ -42,10 +44,14 @@ resource "ironic_deployment" "openshift-master-deployment" {
     count.index,
   )
 
-  instance_info         = var.instance_infos[count.index]
-  user_data_url         = var.master_ignition_url
-  user_data_url_ca_cert = var.master_ignition_url_ca_cert
-  user_data_url_headers = var.master_ignition_url_headers
+  instance_info = var.instance_infos[count.index]
+
+  # The provider prefers user_data_url over user_data, so the URL is only
+  # set for hosts without a per-host ignition.
+  user_data             = var.user_data[count.index]
+  user_data_url         = var.user_data[count.index] == "" ? var.master_ignition_url : null
+  user_data_url_ca_cert = var.user_data[count.index] == "" ? var.master_ignition_url_ca_cert : null
+  user_data_url_headers = var.user_data[count.index] == "" ? var.master_ignition_url_headers : null
 }
 
 data "ironic_introspection" "openshift-master-introspection" {
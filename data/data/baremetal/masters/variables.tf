@@ -43,3 +43,8 @@ variable "master_ignition_url_headers" {
   type        = map(string)
   description = "Headers to use when retrieving master_ignition_url"
 }
+
+variable "user_data" {
+  type        = list(string)
+  description = "Per-host ignition for hosts with a static network configuration, empty for hosts that use master_ignition_url"
+}
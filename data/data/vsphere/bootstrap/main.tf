@@ -26,10 +26,15 @@ resource "vsphere_virtual_machine" "vm" {
     template_uuid = var.template
   }
 
+  // Afterburn passes the network kernel arguments to the initramfs; they
+  // are left out for DHCP.
   extra_config = {
-    "guestinfo.ignition.config.data"          = base64encode(var.ignition)
-    "guestinfo.ignition.config.data.encoding" = "base64"
-    "guestinfo.hostname"                      = "${var.cluster_id}-bootstrap"
+    for k, v in {
+      "guestinfo.ignition.config.data"           = base64encode(var.ignition)
+      "guestinfo.ignition.config.data.encoding"  = "base64"
+      "guestinfo.hostname"                       = "${var.cluster_id}-bootstrap"
+      "guestinfo.afterburn.initrd.network-kargs" = var.network_kargs
+    } : k => v if v != ""
   }
   tags = var.tags
 }
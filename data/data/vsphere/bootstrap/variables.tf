@@ -45,4 +45,9 @@ variable "thin_disk" {
 
 variable "scrub_disk" {
   type = bool
-}
\ No newline at end of file
+}
+
+variable "network_kargs" {
+  type    = string
+  default = ""
+}
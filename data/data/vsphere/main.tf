@@ -35,6 +35,60 @@ data "vsphere_network" "network" {
   datacenter_id = data.vsphere_datacenter.datacenter.id
 }
 
+data "vsphere_datacenter" "control_plane" {
+  count = var.master_count
+
+  name = var.vsphere_control_plane_failure_domains[count.index].datacenter
+}
+
+data "vsphere_compute_cluster" "control_plane" {
+  count = var.master_count
+
+  name          = var.vsphere_control_plane_failure_domains[count.index].cluster
+  datacenter_id = data.vsphere_datacenter.control_plane[count.index].id
+}
+
+data "vsphere_datastore" "control_plane" {
+  count = var.master_count
+
+  name          = var.vsphere_control_plane_failure_domains[count.index].datastore
+  datacenter_id = data.vsphere_datacenter.control_plane[count.index].id
+}
+
+data "vsphere_network" "control_plane" {
+  count = var.master_count
+
+  name          = var.vsphere_control_plane_failure_domains[count.index].network
+  datacenter_id = data.vsphere_datacenter.control_plane[count.index].id
+}
+
+data "vsphere_datacenter" "compute" {
+  count = length(var.vsphere_compute_failure_domains)
+
+  name = var.vsphere_compute_failure_domains[count.index].datacenter
+}
+
+data "vsphere_compute_cluster" "compute" {
+  count = length(var.vsphere_compute_failure_domains)
+
+  name          = var.vsphere_compute_failure_domains[count.index].cluster
+  datacenter_id = data.vsphere_datacenter.compute[count.index].id
+}
+
+data "vsphere_datastore" "compute" {
+  count = length(var.vsphere_compute_failure_domains)
+
+  name          = var.vsphere_compute_failure_domains[count.index].datastore
+  datacenter_id = data.vsphere_datacenter.compute[count.index].id
+}
+
+data "vsphere_network" "compute" {
+  count = length(var.vsphere_compute_failure_domains)
+
+  name          = var.vsphere_compute_failure_domains[count.index].network
+  datacenter_id = data.vsphere_datacenter.compute[count.index].id
+}
+
 data "vsphere_virtual_machine" "template" {
   name          = vsphereprivate_import_ova.import.name
   datacenter_id = data.vsphere_datacenter.datacenter.id
@@ -92,6 +146,7 @@ module "bootstrap" {
   guest_id      = data.vsphere_virtual_machine.template.guest_id
   thin_disk     = data.vsphere_virtual_machine.template.disks.0.thin_provisioned
   scrub_disk    = data.vsphere_virtual_machine.template.disks.0.eagerly_scrub
+  network_kargs = var.vsphere_bootstrap_network_kargs
 
   cluster_id = var.cluster_id
   tags       = [vsphere_tag.tag.id]
@@ -105,16 +160,16 @@ module "master" {
   instance_count = var.master_count
   ignition       = var.ignition_master
 
-  resource_pool = data.vsphere_compute_cluster.cluster.resource_pool_id
-  datastore     = data.vsphere_datastore.datastore.id
-  folder        = local.folder
-  network       = data.vsphere_network.network.id
-  datacenter    = data.vsphere_datacenter.datacenter.id
-  template      = data.vsphere_virtual_machine.template.id
-  guest_id      = data.vsphere_virtual_machine.template.guest_id
-  thin_disk     = data.vsphere_virtual_machine.template.disks.0.thin_provisioned
-  scrub_disk    = data.vsphere_virtual_machine.template.disks.0.eagerly_scrub
-  tags          = [vsphere_tag.tag.id]
+  resource_pools = data.vsphere_compute_cluster.control_plane.*.resource_pool_id
+  datastores     = data.vsphere_datastore.control_plane.*.id
+  folders        = [for fd in var.vsphere_control_plane_failure_domains : fd.folder != "" ? fd.folder : local.folder]
+  networks       = data.vsphere_network.control_plane.*.id
+  template       = data.vsphere_virtual_machine.template.id
+  guest_id       = data.vsphere_virtual_machine.template.guest_id
+  thin_disk      = data.vsphere_virtual_machine.template.disks.0.thin_provisioned
+  scrub_disk     = data.vsphere_virtual_machine.template.disks.0.eagerly_scrub
+  tags           = [vsphere_tag.tag.id]
+  network_kargs  = var.vsphere_control_plane_network_kargs
 
   cluster_domain   = var.cluster_domain
   cluster_id       = var.cluster_id
@@ -124,3 +179,31 @@ module "master" {
   disk_size        = var.vsphere_control_plane_disk_gib
 }
 
+// The compute machines are only created here when they have static IPs,
+// which the machine API cannot give them. The machine API adopts them
+// through their Machine manifests, like the control plane machines.
+module "compute" {
+  source = "./master"
+
+  name           = "worker"
+  instance_count = length(var.vsphere_compute_failure_domains)
+  ignition       = var.vsphere_compute_ignition
+
+  resource_pools = data.vsphere_compute_cluster.compute.*.resource_pool_id
+  datastores     = data.vsphere_datastore.compute.*.id
+  folders        = [for fd in var.vsphere_compute_failure_domains : fd.folder != "" ? fd.folder : local.folder]
+  networks       = data.vsphere_network.compute.*.id
+  template       = data.vsphere_virtual_machine.template.id
+  guest_id       = data.vsphere_virtual_machine.template.guest_id
+  thin_disk      = data.vsphere_virtual_machine.template.disks.0.thin_provisioned
+  scrub_disk     = data.vsphere_virtual_machine.template.disks.0.eagerly_scrub
+  tags           = [vsphere_tag.tag.id]
+  network_kargs  = var.vsphere_compute_network_kargs
+
+  cluster_domain   = var.cluster_domain
+  cluster_id       = var.cluster_id
+  memory           = var.vsphere_compute_memory_mib
+  num_cpus         = var.vsphere_compute_num_cpus
+  cores_per_socket = var.vsphere_compute_cores_per_socket
+  disk_size        = var.vsphere_compute_disk_gib
+}
@@ -2,20 +2,20 @@ resource "vsphere_virtual_machine" "vm" {
   count = var.instance_count
 
   name                 = "${var.cluster_id}-${var.name}-${count.index}"
-  resource_pool_id     = var.resource_pool
-  datastore_id         = var.datastore
+  resource_pool_id     = var.resource_pools[count.index]
+  datastore_id         = var.datastores[count.index]
   num_cpus             = var.num_cpus
   num_cores_per_socket = var.cores_per_socket
   memory               = var.memory
   guest_id             = var.guest_id
-  folder               = var.folder
+  folder               = var.folders[count.index]
   enable_disk_uuid     = "true"
 
   wait_for_guest_net_timeout  = "0"
   wait_for_guest_net_routable = "false"
 
   network_interface {
-    network_id = var.network
+    network_id = var.networks[count.index]
   }
 
   disk {
@@ -29,10 +29,15 @@ resource "vsphere_virtual_machine" "vm" {
     template_uuid = var.template
   }
 
+  // Afterburn passes the network kernel arguments to the initramfs; they
+  // are left out for DHCP.
   extra_config = {
-    "guestinfo.ignition.config.data"          = base64encode(var.ignition)
-    "guestinfo.ignition.config.data.encoding" = "base64"
-    "guestinfo.hostname"                      = "${var.cluster_id}-${var.name}-${count.index}"
+    for k, v in {
+      "guestinfo.ignition.config.data"           = base64encode(var.ignition)
+      "guestinfo.ignition.config.data.encoding"  = "base64"
+      "guestinfo.hostname"                       = "${var.cluster_id}-${var.name}-${count.index}"
+      "guestinfo.afterburn.initrd.network-kargs" = length(var.network_kargs) > count.index ? var.network_kargs[count.index] : ""
+    } : k => v if v != ""
   }
 
   tags = var.tags
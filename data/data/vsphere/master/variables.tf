@@ -11,30 +11,26 @@ variable "ignition" {
   default = ""
 }
 
-variable "resource_pool" {
-  type = string
+variable "resource_pools" {
+  type = list(string)
 }
 
-variable "folder" {
-  type = string
+variable "folders" {
+  type = list(string)
 }
 
-variable "datastore" {
-  type = string
+variable "datastores" {
+  type = list(string)
 }
 
-variable "network" {
-  type = string
+variable "networks" {
+  type = list(string)
 }
 
 variable "cluster_domain" {
   type = string
 }
 
-variable "datacenter" {
-  type = string
-}
-
 variable "template" {
   type = string
 }
@@ -74,3 +70,8 @@ variable "thin_disk" {
 variable "scrub_disk" {
   type = bool
 }
+
+variable "network_kargs" {
+  type    = list(string)
+  default = []
+}
@@ -57,10 +57,33 @@ variable "vsphere_preexisting_folder" {
   description = "If false, creates a top-level folder with the name from vsphere_folder_rel_path."
 }
 
+variable "vsphere_bootstrap_network_kargs" {
+  type        = string
+  default     = ""
+  description = "The kernel arguments that configure the network of the bootstrap machine. Empty for DHCP."
+}
+
 ///////////
 // Control Plane machine variables
 ///////////
 
+variable "vsphere_control_plane_network_kargs" {
+  type        = list(string)
+  default     = []
+  description = "The kernel arguments that configure the network of each control plane machine. Empty for DHCP."
+}
+
+variable "vsphere_control_plane_failure_domains" {
+  type = list(object({
+    datacenter = string
+    cluster    = string
+    datastore  = string
+    network    = string
+    folder     = string
+  }))
+  description = "The location of each control plane machine. An empty folder places the machine in vsphere_folder."
+}
+
 variable "vsphere_control_plane_memory_mib" {
   type = number
 }
@@ -77,3 +100,50 @@ variable "vsphere_control_plane_cores_per_socket" {
   type = number
 }
 
+///////////
+// Compute machine variables
+///////////
+
+variable "vsphere_compute_failure_domains" {
+  type = list(object({
+    datacenter = string
+    cluster    = string
+    datastore  = string
+    network    = string
+    folder     = string
+  }))
+  default     = []
+  description = "The location of each compute machine that the installer creates, which it only does with static IPs. An empty folder places the machine in vsphere_folder."
+}
+
+variable "vsphere_compute_network_kargs" {
+  type        = list(string)
+  default     = []
+  description = "The kernel arguments that configure the network of each compute machine."
+}
+
+variable "vsphere_compute_ignition" {
+  type        = string
+  default     = ""
+  description = "The Ignition config of the compute machines."
+}
+
+variable "vsphere_compute_memory_mib" {
+  type    = number
+  default = 0
+}
+
+variable "vsphere_compute_disk_gib" {
+  type    = number
+  default = 0
+}
+
+variable "vsphere_compute_num_cpus" {
+  type    = number
+  default = 0
+}
+
+variable "vsphere_compute_cores_per_socket" {
+  type    = number
+  default = 0
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset/store/encryption"
 )
 
 // Asset used to install OpenShift.
@@ -45,14 +47,18 @@ type File struct {
 }
 
 // PersistToFile writes all of the files of the specified asset into the specified
-// directory.
+// directory. Secret files are encrypted if a key provider is configured.
 func PersistToFile(asset WritableAsset, directory string) error {
 	for _, f := range asset.Files() {
 		path := filepath.Join(directory, f.Filename)
 		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
 			return errors.Wrap(err, "failed to create dir")
 		}
-		if err := ioutil.WriteFile(path, f.Data, 0640); err != nil {
+		writeFile := ioutil.WriteFile
+		if IsSecretFile(f.Filename) {
+			writeFile = encryption.WriteFile
+		}
+		if err := writeFile(path, f.Data, 0640); err != nil {
 			return errors.Wrap(err, "failed to write file")
 		}
 	}
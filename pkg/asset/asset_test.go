@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/installer/pkg/asset/store/encryption"
 )
 
 type persistAsset struct{}
@@ -86,6 +89,39 @@ func TestPersistToFile(t *testing.T) {
 	}
 }
 
+func TestPersistToFileEncryptsSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestPersistToFileEncryptsSecrets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	os.Setenv(encryption.PassphraseEnv, "correct horse")
+	defer os.Unsetenv(encryption.PassphraseEnv)
+
+	asset := &writablePersistAsset{
+		FileList: []*File{
+			{Filename: "auth/kubeadmin-password", Data: []byte("password")},
+			{Filename: "tls/journal-gatewayd.key", Data: []byte("key")},
+			{Filename: "tls/journal-gatewayd.crt", Data: []byte("cert")},
+		},
+	}
+	require.NoError(t, PersistToFile(asset, dir))
+	for _, f := range asset.FileList {
+		path := filepath.Join(dir, f.Filename)
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, IsSecretFile(f.Filename), encryption.IsEncrypted(data), f.Filename)
+		data, err = encryption.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, f.Data, data)
+	}
+
+	secretFiles, err := SecretFiles(dir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "auth", "kubeadmin-password"),
+		filepath.Join(dir, "tls", "journal-gatewayd.key"),
+	}, secretFiles)
+}
+
 func verifyFilesCreated(t *testing.T, dir string, expectedFiles map[string][]byte) {
 	dirContents, err := ioutil.ReadDir(dir)
 	assert.NoError(t, err, "could not read contents of directory %q", dir)
@@ -115,6 +115,26 @@ func (m *Metadata) Files() []*asset.File {
 	return []*asset.File{}
 }
 
+// MarshalJSON leaves out the secrets read from secret references, such as the
+// vSphere password, so that they are not saved in the state file.
+func (m *Metadata) MarshalJSON() ([]byte, error) {
+	type metadata Metadata
+	saved := &metadata{}
+	if m.File != nil {
+		saved.File = asset.RedactSecrets([]*asset.File{m.File})[0]
+	}
+	return json.Marshal(saved)
+}
+
+// UnmarshalJSON reads the secrets left out by MarshalJSON again.
+func (m *Metadata) UnmarshalJSON(data []byte) error {
+	type metadata Metadata
+	if err := json.Unmarshal(data, (*metadata)(m)); err != nil {
+		return err
+	}
+	return asset.RestoreSecrets([]*asset.File{m.File})
+}
+
 // Load is a no-op, because we never want to load broken metadata from
 // the disk.
 func (m *Metadata) Load(f asset.FileFetcher) (found bool, err error) {
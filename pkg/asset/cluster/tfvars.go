@@ -93,6 +93,7 @@ func (t *TerraformVariables) Dependencies() []asset.Asset {
 		new(rhcos.BootstrapImage),
 		&bootstrap.Bootstrap{},
 		&machine.Master{},
+		&machine.Worker{},
 		&machines.Master{},
 		&machines.Worker{},
 		&baremetalbootstrap.IronicCreds{},
@@ -106,12 +107,13 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	bootstrapIgnAsset := &bootstrap.Bootstrap{}
 	masterIgnAsset := &machine.Master{}
+	workerIgnAsset := &machine.Worker{}
 	mastersAsset := &machines.Master{}
 	workersAsset := &machines.Worker{}
 	rhcosImage := new(rhcos.Image)
 	rhcosBootstrapImage := new(rhcos.BootstrapImage)
 	ironicCreds := &baremetalbootstrap.IronicCreds{}
-	parents.Get(clusterID, installConfig, bootstrapIgnAsset, masterIgnAsset, mastersAsset, workersAsset, rhcosImage, rhcosBootstrapImage, ironicCreds)
+	parents.Get(clusterID, installConfig, bootstrapIgnAsset, masterIgnAsset, workerIgnAsset, mastersAsset, workersAsset, rhcosImage, rhcosBootstrapImage, ironicCreds)
 
 	platform := installConfig.Config.Platform.Name()
 	switch platform {
@@ -517,18 +519,32 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		for i, c := range controlPlanes {
 			controlPlaneConfigs[i] = c.Spec.ProviderSpec.Value.Object.(*vsphereprovider.VSphereMachineProviderSpec)
 		}
+		computes, err := workersAsset.Machines()
+		if err != nil {
+			return err
+		}
+		computeConfigs := make([]*vsphereprovider.VSphereMachineProviderSpec, len(computes))
+		for i, c := range computes {
+			computeConfigs[i] = c.Spec.ProviderSpec.Value.Object.(*vsphereprovider.VSphereMachineProviderSpec)
+		}
 
 		// Set this flag to use an existing folder specified in the install-config. Otherwise, create one.
-		preexistingFolder := installConfig.Config.Platform.VSphere.Folder != ""
+		// The first control plane machine goes in the default folder unless the install-config
+		// specifies a folder for the platform or for its failure domain.
+		firstWorkspace := controlPlaneConfigs[0].Workspace
+		preexistingFolder := firstWorkspace.Folder != fmt.Sprintf("/%s/vm/%s", firstWorkspace.Datacenter, clusterID.InfraID)
 
 		data, err = vspheretfvars.TFVars(
 			vspheretfvars.TFVarsSources{
 				ControlPlaneConfigs: controlPlaneConfigs,
 				Username:            installConfig.Config.VSphere.Username,
 				Password:            installConfig.Config.VSphere.Password,
-				Cluster:             installConfig.Config.VSphere.Cluster,
 				ImageURL:            string(*rhcosImage),
 				PreexistingFolder:   preexistingFolder,
+				InfraID:             clusterID.InfraID,
+				StaticIPs:           installConfig.Config.VSphere.StaticIPs,
+				ComputeConfigs:      computeConfigs,
+				ComputeIgnition:     string(workerIgnAsset.Files()[0].Data),
 			},
 		)
 		if err != nil {
@@ -577,6 +593,23 @@ func (t *TerraformVariables) Files() []*asset.File {
 	return t.FileList
 }
 
+// MarshalJSON leaves out the secrets read from secret references, such as the
+// vSphere password and the BMC passwords, so that they are not saved in the
+// state file.
+func (t *TerraformVariables) MarshalJSON() ([]byte, error) {
+	type terraformVariables TerraformVariables
+	return json.Marshal(&terraformVariables{FileList: asset.RedactSecrets(t.FileList)})
+}
+
+// UnmarshalJSON reads the secrets left out by MarshalJSON again.
+func (t *TerraformVariables) UnmarshalJSON(data []byte) error {
+	type terraformVariables TerraformVariables
+	if err := json.Unmarshal(data, (*terraformVariables)(t)); err != nil {
+		return err
+	}
+	return asset.RestoreSecrets(t.FileList)
+}
+
 // Load reads the terraform.tfvars from disk.
 func (t *TerraformVariables) Load(f asset.FileFetcher) (found bool, err error) {
 	file, err := f.FetchByName(TfVarsFileName)
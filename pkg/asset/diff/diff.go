@@ -0,0 +1,295 @@
+// Package diff computes semantic diffs between the files of two sets of
+// assets.
+package diff
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_1/types"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/vincent-petithory/dataurl"
+)
+
+var (
+	// secretKeyRegexp matches the YAML and JSON keys whose values are
+	// redacted.
+	secretKeyRegexp = regexp.MustCompile(`(?i)(password|secret|token|key-data|private)`)
+
+	// secretFileRegexp matches the names of files whose contents are
+	// redacted.
+	secretFileRegexp = regexp.MustCompile(`(?i)(\.key$|password|kubeconfig)`)
+
+	// dockerConfigRegexp matches the paths of the Docker configs holding the
+	// pull secret, which are redacted as a whole.
+	dockerConfigRegexp = regexp.MustCompile(`(^|[/:])\.docker/config\.json$`)
+
+	documentSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+)
+
+// entry is a normalized file, or part of a file, to be diffed.
+type entry struct {
+	name string
+	text string
+}
+
+// Files returns a unified diff between two sets of files, keyed by their file
+// names. The files are normalized before they are compared, so the diff is
+// semantic rather than textual:
+//
+// * YAML and JSON files have their keys sorted and are compared document by
+//   document.
+// * Ignition configs (.ign) are compared as YAML, with the contents of the
+//   files they write decoded and compared as files of their own.
+// * Secrets are redacted: Secret data, values with secret-looking keys, the
+//   auths of pull secrets, private keys, kubeconfigs and passwords are replaced
+//   with a short hash of their contents, which shows whether they changed
+//   without revealing them.
+func Files(from, to map[string][]byte) (string, error) {
+	fromEntries, err := normalizeAll(from)
+	if err != nil {
+		return "", err
+	}
+	toEntries, err := normalizeAll(to)
+	if err != nil {
+		return "", err
+	}
+
+	names := map[string]bool{}
+	for name := range fromEntries {
+		names[name] = true
+	}
+	for name := range toEntries {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var out strings.Builder
+	for _, name := range sorted {
+		a, inFrom := fromEntries[name]
+		b, inTo := toEntries[name]
+		if a == b && inFrom == inTo {
+			continue
+		}
+		fromFile, toFile := "a/"+name, "b/"+name
+		if !inFrom {
+			fromFile = "/dev/null"
+		}
+		if !inTo {
+			toFile = "/dev/null"
+		}
+		d, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(a),
+			B:        splitLines(b),
+			FromFile: fromFile,
+			ToFile:   toFile,
+			Context:  3,
+		})
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to diff %s", name)
+		}
+		out.WriteString(d)
+	}
+	return out.String(), nil
+}
+
+// splitLines splits text into lines, keeping their line endings.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// normalizeAll normalizes files, returning the text of each entry keyed by
+// its name.
+func normalizeAll(files map[string][]byte) (map[string]string, error) {
+	entries := map[string]string{}
+	for name, data := range files {
+		normalized, err := normalize(name, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to normalize %s", name)
+		}
+		for _, e := range normalized {
+			entries[e.name] = e.text
+		}
+	}
+	return entries, nil
+}
+
+// normalize returns the entries to diff for the file name.
+func normalize(name string, data []byte) ([]entry, error) {
+	if secretFileRegexp.MatchString(path.Base(name)) || dockerConfigRegexp.MatchString(name) || bytes.Contains(data, []byte("PRIVATE KEY-----")) {
+		return []entry{{name: name, text: redact(data) + "\n"}}, nil
+	}
+
+	switch strings.ToLower(path.Ext(name)) {
+	case ".ign":
+		return normalizeIgnition(name, data)
+	case ".yaml", ".yml", ".json":
+		text, err := normalizeYAML(data)
+		if err != nil {
+			return nil, err
+		}
+		return []entry{{name: name, text: text}}, nil
+	}
+
+	if !utf8.Valid(data) {
+		return []entry{{name: name, text: fmt.Sprintf("binary file %s\n", hash(data))}}, nil
+	}
+	return []entry{{name: name, text: string(data)}}, nil
+}
+
+// normalizeYAML returns the YAML or JSON documents in data with their keys
+// sorted and their secrets redacted.
+func normalizeYAML(data []byte) (string, error) {
+	var docs []string
+	for _, doc := range documentSeparator.Split(string(data), -1) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		var obj interface{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			// Not valid YAML, e.g. a template, so compare it as text.
+			return string(data), nil
+		}
+		redactObject(obj, false)
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", err
+		}
+		docs = append(docs, string(out))
+	}
+	return strings.Join(docs, "---\n"), nil
+}
+
+// redactObject redacts the secrets in obj in place. All of the values in obj
+// are redacted if all is true.
+func redactObject(obj interface{}, all bool) {
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		isSecret := o["kind"] == "Secret"
+		for k, v := range o {
+			if k == "auths" && v != nil {
+				// The credentials of a pull secret are redacted as a whole,
+				// whatever their keys.
+				data, _ := json.Marshal(v)
+				o[k] = redact(data)
+				continue
+			}
+			redactValue := all || secretKeyRegexp.MatchString(k) || (isSecret && (k == "data" || k == "stringData"))
+			switch v.(type) {
+			case map[string]interface{}, []interface{}:
+				redactObject(v, redactValue)
+			default:
+				if redactValue && v != nil {
+					o[k] = redact([]byte(fmt.Sprint(v)))
+				}
+			}
+		}
+	case []interface{}:
+		for i, v := range o {
+			switch v.(type) {
+			case map[string]interface{}, []interface{}:
+				redactObject(v, all)
+			default:
+				if all && v != nil {
+					o[i] = redact([]byte(fmt.Sprint(v)))
+				}
+			}
+		}
+	}
+}
+
+// normalizeIgnition returns the entries for an Ignition config: the config
+// itself without the contents of its files, and an entry for each of its
+// files named "<name>:<path>".
+func normalizeIgnition(name string, data []byte) ([]entry, error) {
+	config := &igntypes.Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, errors.Wrap(err, "failed to parse Ignition config")
+	}
+
+	var entries []entry
+	for i, file := range config.Storage.Files {
+		var contents []byte
+		for _, resource := range append([]igntypes.Resource{file.Contents}, file.Append...) {
+			decoded, err := decodeResource(resource)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode %s", file.Path)
+			}
+			contents = append(contents, decoded...)
+		}
+		fileEntries, err := normalize(fmt.Sprintf("%s:%s", name, file.Path), contents)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+
+		config.Storage.Files[i].Contents = igntypes.Resource{}
+		config.Storage.Files[i].Append = nil
+	}
+
+	stripped, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	text, err := normalizeYAML(stripped)
+	if err != nil {
+		return nil, err
+	}
+	return append(entries, entry{name: name, text: text}), nil
+}
+
+// decodeResource returns the contents of an Ignition resource with a data URL
+// source, or a description of the source for other URLs.
+func decodeResource(resource igntypes.Resource) ([]byte, error) {
+	if resource.Source == nil {
+		return nil, nil
+	}
+	if !strings.HasPrefix(*resource.Source, "data:") {
+		return []byte(fmt.Sprintf("source: %s\n", *resource.Source)), nil
+	}
+	u, err := dataurl.DecodeString(*resource.Source)
+	if err != nil {
+		return nil, err
+	}
+	if resource.Compression != nil && *resource.Compression == "gzip" {
+		r, err := gzip.NewReader(bytes.NewReader(u.Data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	}
+	return u.Data, nil
+}
+
+// redact returns a placeholder for a secret that only changes when the secret
+// does.
+func redact(data []byte) string {
+	return fmt.Sprintf("<redacted %s>", hash(data))
+}
+
+func hash(data []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data))[:15]
+}
@@ -0,0 +1,32 @@
+package baremetal
+
+import (
+	"path"
+	"sort"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_1/types"
+
+	"github.com/openshift/installer/pkg/asset/ignition"
+	"github.com/openshift/installer/pkg/types/baremetal"
+)
+
+// NetworkFiles returns the ignition files holding the NetworkManager
+// keyfiles of a static network configuration.
+func NetworkFiles(config *baremetal.NetworkConfig) ([]igntypes.File, error) {
+	keyfiles, err := config.MakeKeyfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(keyfiles))
+	for name := range keyfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	files := make([]igntypes.File, 0, len(names))
+	for _, name := range names {
+		files = append(files, ignition.FileFromString(path.Join("/etc/NetworkManager/system-connections", name+".nmconnection"), "root", 0600, keyfiles[name]))
+	}
+	return files, nil
+}
@@ -97,6 +97,7 @@ func (a *Bootstrap) Dependencies() []asset.Asset {
 		&tls.AggregatorClientCertKey{},
 		&tls.AggregatorSignerCertKey{},
 		&tls.APIServerProxyCertKey{},
+		&tls.BootstrapSSHHostKeyPair{},
 		&tls.BootstrapSSHKeyPair{},
 		&tls.BoundSASigningKey{},
 		&tls.CloudProviderCABundle{},
@@ -192,6 +193,16 @@ func (a *Bootstrap) Generate(dependencies asset.Parents) error {
 		}
 	}
 
+	if platform == baremetaltypes.Name {
+		files, err := baremetal.NetworkFiles(installConfig.Config.Platform.BareMetal.BootstrapNetworkConfig)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate bootstrap network configuration")
+		}
+		for _, file := range files {
+			a.Config.Storage.Files = replaceOrAppend(a.Config.Storage.Files, file)
+		}
+	}
+
 	a.addParentFiles(dependencies)
 
 	a.Config.Passwd.Users = append(
@@ -227,6 +238,69 @@ func (a *Bootstrap) Files() []*asset.File {
 	return []*asset.File{}
 }
 
+// MarshalJSON leaves out the secrets read from secret references, such as the
+// passwords in the cloud credentials and in the BMC secrets which are among
+// the files of the config, so that they are not saved in the state file.
+func (a *Bootstrap) MarshalJSON() ([]byte, error) {
+	type bootstrap Bootstrap
+	config, err := mapConfigSecrets(a.Config, ignition.RedactSecrets)
+	if err != nil {
+		return nil, err
+	}
+	saved := &bootstrap{Config: config}
+	if a.File != nil {
+		data, err := ignition.RedactSecrets(a.File.Data)
+		if err != nil {
+			return nil, err
+		}
+		saved.File = &asset.File{Filename: a.File.Filename, Data: data}
+	}
+	return json.Marshal(saved)
+}
+
+// UnmarshalJSON reads the secrets left out by MarshalJSON again.
+func (a *Bootstrap) UnmarshalJSON(data []byte) error {
+	type bootstrap Bootstrap
+	if err := json.Unmarshal(data, (*bootstrap)(a)); err != nil {
+		return err
+	}
+	config, err := mapConfigSecrets(a.Config, ignition.RestoreSecrets)
+	if err != nil {
+		return err
+	}
+	a.Config = config
+	if a.File != nil {
+		if a.File.Data, err = ignition.RestoreSecrets(a.File.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapConfigSecrets applies fn, which redacts or restores the secrets in the
+// data of an Ignition config, to config.
+func mapConfigSecrets(config *igntypes.Config, fn func([]byte) ([]byte, error)) (*igntypes.Config, error) {
+	if config == nil {
+		return nil, nil
+	}
+	data, err := ignition.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	mapped, err := fn(data)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(mapped, data) {
+		return config, nil
+	}
+	mappedConfig := &igntypes.Config{}
+	if err := json.Unmarshal(mapped, mappedConfig); err != nil {
+		return nil, err
+	}
+	return mappedConfig, nil
+}
+
 // getTemplateData returns the data to use to execute bootstrap templates.
 func (a *Bootstrap) getTemplateData(installConfig *types.InstallConfig, releaseImage string, imageSources []types.ImageContentSource, proxy *configv1.Proxy, rhcosImage *rhcos.Image, ironicCreds *baremetal.IronicCreds) (*bootstrapTemplateData, error) {
 	etcdEndpoints := make([]string, *installConfig.ControlPlane.Replicas)
@@ -542,6 +616,13 @@ func (a *Bootstrap) addParentFiles(dependencies asset.Parents) {
 	rootCA := &tls.RootCA{}
 	dependencies.Get(rootCA)
 	a.Config.Storage.Files = replaceOrAppend(a.Config.Storage.Files, ignition.FileFromBytes(filepath.Join(rootDir, rootCA.CertFile().Filename), "root", 0644, rootCA.Cert()))
+
+	// Install the pre-generated host key, so that the installer can verify the
+	// bootstrap-host when gathering logs from it.
+	hostKeyPair := &tls.BootstrapSSHHostKeyPair{}
+	dependencies.Get(hostKeyPair)
+	a.Config.Storage.Files = replaceOrAppend(a.Config.Storage.Files, ignition.FileFromBytes("/etc/ssh/ssh_host_rsa_key", "root", 0600, hostKeyPair.Private()))
+	a.Config.Storage.Files = replaceOrAppend(a.Config.Storage.Files, ignition.FileFromBytes("/etc/ssh/ssh_host_rsa_key.pub", "root", 0644, hostKeyPair.Public()))
 }
 
 func replaceOrAppend(files []igntypes.File, file igntypes.File) []igntypes.File {
@@ -0,0 +1,70 @@
+package ignition
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	ignutil "github.com/coreos/ignition/v2/config/util"
+	igntypes "github.com/coreos/ignition/v2/config/v3_1/types"
+	"github.com/pkg/errors"
+	"github.com/vincent-petithory/dataurl"
+
+	"github.com/openshift/installer/pkg/types/secret"
+)
+
+// RedactSecrets returns a copy of the Ignition config data in which the
+// secrets read from secret references are replaced by placeholders, so that
+// the config can be saved in the state file without them. Unlike
+// asset.RedactSecrets, it also finds the secrets in the contents of the files
+// of the config, which are encoded in data URLs.
+func RedactSecrets(data []byte) ([]byte, error) {
+	return mapSecrets(data, func(data []byte) ([]byte, error) {
+		return secret.Redact(data), nil
+	})
+}
+
+// RestoreSecrets replaces the placeholders that RedactSecrets left in the
+// Ignition config data with the secrets, reading their references again.
+func RestoreSecrets(data []byte) ([]byte, error) {
+	return mapSecrets(data, secret.Restore)
+}
+
+// mapSecrets applies fn to the contents of each of the files in the Ignition
+// config data and then to the whole config. The data is returned as it is if
+// fn does not change the contents of any of the files.
+func mapSecrets(data []byte, fn func([]byte) ([]byte, error)) ([]byte, error) {
+	config := &igntypes.Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, errors.Wrap(err, "failed to parse Ignition config")
+	}
+
+	changed := false
+	for i, file := range config.Storage.Files {
+		source := file.Contents.Source
+		if source == nil || !strings.HasPrefix(*source, "data:") {
+			continue
+		}
+		contents, err := dataurl.DecodeString(*source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode %s", file.Path)
+		}
+		mapped, err := fn(contents.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to process %s", file.Path)
+		}
+		if !bytes.Equal(mapped, contents.Data) {
+			config.Storage.Files[i].Contents.Source = ignutil.StrToPtr(dataurl.EncodeBytes(mapped))
+			changed = true
+		}
+	}
+
+	if changed {
+		var err error
+		if data, err = Marshal(config); err != nil {
+			return nil, err
+		}
+	}
+	// The secrets may also be written as text elsewhere in the config.
+	return fn(data)
+}
@@ -0,0 +1,333 @@
+// Package answers answers the install-config prompts from a file and from
+// environment variables, so that install-configs can be created without a
+// terminal.
+//
+// Each prompt has a key, such as "platform" or "aws.region". The answers file
+// is YAML whose nested fields and lists are joined into keys, so that
+//
+//	aws:
+//	  region: us-east-1
+//
+// answers the "aws.region" prompt, and the first item of a list "hosts" under
+// "baremetal" holds the "baremetal.hosts[0]" keys. An environment variable
+// OPENSHIFT_INSTALL_ANSWER_<KEY>, where <KEY> is the key in upper snake case
+// (AWS_REGION, BAREMETAL_HOSTS_0_NAME), overrides the file.
+//
+// Answers are validated as the interactive answers are, but are never asked
+// again. Prompts with a default use the default when they have no answer, and
+// the other prompts without answers are collected so that all of them can be
+// reported at once.
+package answers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+	"gopkg.in/AlecAivazis/survey.v1/core"
+)
+
+const (
+	// FileEnv is the environment variable naming the answers file.
+	FileEnv = "OPENSHIFT_INSTALL_ANSWERS"
+
+	// EnvPrefix is the prefix of the environment variables holding single
+	// answers.
+	EnvPrefix = "OPENSHIFT_INSTALL_ANSWER_"
+)
+
+// current holds the loaded answers. The prompts are interactive while it is
+// nil.
+var current *answers
+
+type answers struct {
+	// file holds the answers from the file by key, and env those from the
+	// environment by variable name without EnvPrefix.
+	file    map[string]string
+	env     map[string]string
+	missing []string
+}
+
+// MissingError is returned when prompts without defaults had no answers.
+type MissingError struct {
+	// Keys are the keys of the prompts, in the order they were asked.
+	Keys []string
+}
+
+func (e *MissingError) Error() string {
+	return fmt.Sprintf("missing answers for %s; add them to the answers file or set %s<KEY>", strings.Join(e.Keys, ", "), EnvPrefix)
+}
+
+// Load loads the answers from the file at path, or from the file named by
+// FileEnv if path is empty, and from the environment. The prompts stay
+// interactive if there is neither a file nor an answer in the environment.
+func Load(path string) error {
+	if path == "" {
+		path = os.Getenv(FileEnv)
+	}
+
+	file := map[string]string{}
+	if path != "" {
+		raw, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrap(err, "failed to read answers")
+		}
+		var data interface{}
+		if err := yaml.Unmarshal(raw, &data); err != nil {
+			return errors.Wrapf(err, "failed to parse answers from %s", path)
+		}
+		if data != nil {
+			if _, ok := data.(map[string]interface{}); !ok {
+				return errors.Errorf("answers in %s must be a map", path)
+			}
+			flatten("", data, file)
+		}
+	}
+
+	env := environment()
+	if path == "" && len(env) == 0 {
+		current = nil
+		return nil
+	}
+	logrus.Debugf("Answering prompts from %d answers in %q and %d %s* environment variables", len(file), path, len(env), EnvPrefix)
+	current = &answers{file: file, env: env}
+	return nil
+}
+
+// Reset makes the prompts interactive again.
+func Reset() {
+	current = nil
+}
+
+// Enabled returns true if the prompts are answered from the loaded answers.
+func Enabled() bool {
+	return current != nil
+}
+
+// Has returns true if there is an answer for key, or for a key nested under
+// key.
+func Has(key string) bool {
+	if current == nil {
+		return false
+	}
+	if _, ok := current.lookup(key); ok {
+		return true
+	}
+	for k := range current.file {
+		if strings.HasPrefix(k, key+".") || strings.HasPrefix(k, key+"[") {
+			return true
+		}
+	}
+	name := EnvName(key)
+	for k := range current.env {
+		if strings.HasPrefix(k, name+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the answer for key.
+func Get(key string) (string, bool) {
+	if current == nil {
+		return "", false
+	}
+	return current.lookup(key)
+}
+
+// Missing returns a *MissingError if any prompts have had no answers so far,
+// and nil otherwise.
+func Missing() error {
+	if current == nil || len(current.missing) == 0 {
+		return nil
+	}
+	return &MissingError{Keys: append([]string(nil), current.missing...)}
+}
+
+// Ask is survey.Ask for a single question identified by key. The question is
+// answered from the loaded answers if there are any, and asked otherwise.
+func Ask(key string, qs []*survey.Question, response interface{}) error {
+	if current == nil {
+		return survey.Ask(qs, response)
+	}
+	if len(qs) != 1 {
+		return errors.Errorf("installer bug: %s must be a single question", key)
+	}
+	return current.answer(key, qs[0], response)
+}
+
+// AskOne is survey.AskOne for a prompt identified by key.
+func AskOne(key string, p survey.Prompt, response interface{}, v survey.Validator) error {
+	return Ask(key, []*survey.Question{{Prompt: p, Validate: v}}, response)
+}
+
+// answer writes the answer to q into response after validating and
+// transforming it as survey does.
+func (a *answers) answer(key string, q *survey.Question, response interface{}) error {
+	value, ok := a.lookup(key)
+	var ans interface{}
+	var err error
+	if ok {
+		ans, err = convert(q.Prompt, value)
+		if err != nil {
+			return errors.Wrapf(err, "invalid answer for %s", key)
+		}
+	} else {
+		ans, ok = defaultAnswer(q.Prompt)
+		if !ok {
+			logrus.Debugf("No answer for %s", key)
+			a.addMissing(key)
+			return nil
+		}
+	}
+
+	if q.Validate != nil {
+		if err := q.Validate(ans); err != nil {
+			return errors.Wrapf(err, "invalid answer for %s", key)
+		}
+	}
+	if q.Transform != nil {
+		if transformed := q.Transform(ans); transformed != nil {
+			ans = transformed
+		}
+	}
+	return core.WriteAnswer(response, q.Name, ans)
+}
+
+func (a *answers) lookup(key string) (string, bool) {
+	if value, ok := a.env[EnvName(key)]; ok {
+		return value, true
+	}
+	value, ok := a.file[key]
+	return value, ok
+}
+
+func (a *answers) addMissing(key string) {
+	for _, k := range a.missing {
+		if k == key {
+			return
+		}
+	}
+	a.missing = append(a.missing, key)
+}
+
+// convert returns the answer to p for value, as p would have returned it.
+func convert(p survey.Prompt, value string) (interface{}, error) {
+	switch p := p.(type) {
+	case *survey.Select:
+		return selectOption(p.Options, value)
+	case *survey.Confirm:
+		return strconv.ParseBool(value)
+	default:
+		return value, nil
+	}
+}
+
+// selectOption returns the option matching value. Options often pair an
+// identifier with a description, as in "us-east-1 (US East (N. Virginia))"
+// and "My Project (my-project)", so value may also be the identifier alone.
+func selectOption(options []string, value string) (string, error) {
+	var matches []string
+	for _, option := range options {
+		if option == value {
+			return option, nil
+		}
+		if strings.HasPrefix(option, value+" ") || strings.HasSuffix(option, " ("+value+")") {
+			matches = append(matches, option)
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+	sorted := append([]string(nil), options...)
+	sort.Strings(sorted)
+	return "", errors.Errorf("%q is not one of %s", value, strings.Join(sorted, ", "))
+}
+
+// defaultAnswer returns the answer to p when its answer is missing. Confirm
+// prompts always have a default, but it is usually too consequential to
+// assume, so they are never defaulted.
+func defaultAnswer(p survey.Prompt) (interface{}, bool) {
+	switch p := p.(type) {
+	case *survey.Input:
+		return p.Default, p.Default != ""
+	case *survey.Select:
+		return p.Default, p.Default != ""
+	case *survey.Multiline:
+		return p.Default, p.Default != ""
+	default:
+		return nil, false
+	}
+}
+
+// flatten adds the scalars in data to values, keyed by their paths under
+// prefix.
+func flatten(prefix string, data interface{}, values map[string]string) {
+	switch data := data.(type) {
+	case map[string]interface{}:
+		for name, value := range data {
+			key := name
+			if prefix != "" {
+				key = prefix + "." + name
+			}
+			flatten(key, value, values)
+		}
+	case []interface{}:
+		for i, value := range data {
+			flatten(fmt.Sprintf("%s[%d]", prefix, i), value, values)
+		}
+	case nil:
+	case string:
+		values[prefix] = data
+	default:
+		values[prefix] = fmt.Sprint(data)
+	}
+}
+
+// environment returns the answers in the environment, keyed by the name of
+// their variables without EnvPrefix.
+func environment() map[string]string {
+	values := map[string]string{}
+	for _, variable := range os.Environ() {
+		parts := strings.SplitN(variable, "=", 2)
+		if len(parts) == 2 && strings.HasPrefix(parts[0], EnvPrefix) && len(parts[0]) > len(EnvPrefix) {
+			values[strings.TrimPrefix(parts[0], EnvPrefix)] = parts[1]
+		}
+	}
+	return values
+}
+
+var nonAlphanumeric = regexp.MustCompile("[^A-Z0-9]+")
+
+// EnvName returns the name, without EnvPrefix, of the environment variable
+// answering key. Words are split at the changes from lower to upper case,
+// except after a single letter, so "aws.accessKeyID" is answered by
+// AWS_ACCESS_KEY_ID and "vsphere.vCenter" by VSPHERE_VCENTER.
+func EnvName(key string) string {
+	var name strings.Builder
+	var previous rune
+	length := 0
+	for _, r := range key {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			length = 0
+		case unicode.IsUpper(r) && (unicode.IsLower(previous) || unicode.IsDigit(previous)) && length > 1:
+			name.WriteRune('_')
+			length = 1
+		default:
+			length++
+		}
+		name.WriteRune(unicode.ToUpper(r))
+		previous = r
+	}
+	return strings.Trim(nonAlphanumeric.ReplaceAllString(name.String(), "_"), "_")
+}
@@ -0,0 +1,158 @@
+package answers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+)
+
+// load loads answers from a file with content, and returns a function that
+// makes the prompts interactive again.
+func load(t *testing.T, content string) func() {
+	dir, err := ioutil.TempDir("", "answers")
+	require.NoError(t, err)
+	path := filepath.Join(dir, "answers.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	require.NoError(t, Load(path))
+	return func() {
+		Reset()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	cases := map[string]string{
+		"platform":                       "PLATFORM",
+		"aws.accessKeyID":                "AWS_ACCESS_KEY_ID",
+		"vsphere.vCenter":                "VSPHERE_VCENTER",
+		"ovirt.apiVIP":                   "OVIRT_API_VIP",
+		"baremetal.hosts[0].bmc.address": "BAREMETAL_HOSTS_0_BMC_ADDRESS",
+		"machineNetworkCIDR":             "MACHINE_NETWORK_CIDR",
+	}
+	for key, expected := range cases {
+		assert.Equal(t, expected, EnvName(key), key)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	assert.NoError(t, Load(""))
+	assert.False(t, Enabled())
+
+	os.Setenv(EnvPrefix+"AWS_REGION", "us-west-2")
+	defer os.Unsetenv(EnvPrefix + "AWS_REGION")
+	defer load(t, `
+platform: aws
+aws:
+  region: us-east-1
+baremetal:
+  hosts:
+  - name: master-0
+    bmc:
+      port: 623
+`)()
+	assert.True(t, Enabled())
+
+	value, ok := Get("platform")
+	assert.True(t, ok)
+	assert.Equal(t, "aws", value)
+	value, _ = Get("aws.region")
+	assert.Equal(t, "us-west-2", value, "the environment overrides the file")
+	value, _ = Get("baremetal.hosts[0].bmc.port")
+	assert.Equal(t, "623", value)
+
+	assert.True(t, Has("baremetal.hosts[0]"))
+	assert.False(t, Has("baremetal.hosts[1]"))
+	assert.True(t, Has("aws"))
+	assert.False(t, Has("gcp"))
+
+	os.Setenv(EnvPrefix+"BAREMETAL_HOSTS_1_NAME", "master-1")
+	defer os.Unsetenv(EnvPrefix + "BAREMETAL_HOSTS_1_NAME")
+	require.NoError(t, Load(""))
+	assert.True(t, Has("baremetal.hosts[1]"), "hosts may be answered from the environment alone")
+}
+
+func TestLoadInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "answers")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "answers.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte("- platform"), 0600))
+	assert.EqualError(t, Load(path), "answers in "+path+" must be a map")
+	assert.Error(t, Load(filepath.Join(dir, "missing.yaml")))
+}
+
+func TestAsk(t *testing.T) {
+	defer load(t, `
+clusterName: test-cluster
+region: us-east-1
+confirm: "true"
+invalid: "-invalid-"
+unknown: unknown
+`)()
+
+	var name string
+	err := Ask("clusterName", []*survey.Question{{
+		Prompt:    &survey.Input{Message: "Cluster Name"},
+		Validate:  survey.Required,
+		Transform: survey.ToLower,
+	}}, &name)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-cluster", name)
+
+	var region string
+	err = Ask("region", []*survey.Question{{
+		Prompt: &survey.Select{
+			Message: "Region",
+			Options: []string{"us-east-1 (US East (N. Virginia))", "us-west-2 (US West (Oregon))"},
+		},
+		Transform: survey.TransformString(func(s string) string {
+			return strings.SplitN(s, " ", 2)[0]
+		}),
+	}}, &region)
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", region)
+
+	var confirm bool
+	assert.NoError(t, AskOne("confirm", &survey.Confirm{Message: "Confirm"}, &confirm, nil))
+	assert.True(t, confirm)
+
+	var defaulted string
+	assert.NoError(t, AskOne("defaulted", &survey.Input{Message: "Defaulted", Default: "default"}, &defaulted, nil))
+	assert.Equal(t, "default", defaulted)
+
+	var invalid string
+	err = AskOne("invalid", &survey.Input{Message: "Invalid"}, &invalid, func(ans interface{}) error {
+		return errors.Errorf("%q is invalid", ans)
+	})
+	assert.EqualError(t, err, `invalid answer for invalid: "-invalid-" is invalid`)
+
+	var unknown string
+	err = AskOne("unknown", &survey.Select{Message: "Unknown", Options: []string{"b", "a"}}, &unknown, nil)
+	assert.EqualError(t, err, `invalid answer for unknown: "unknown" is not one of a, b`)
+
+	assert.NoError(t, Missing())
+}
+
+func TestAskMissing(t *testing.T) {
+	defer load(t, "")()
+
+	var value string
+	assert.NoError(t, AskOne("pullSecret", &survey.Password{Message: "Pull Secret"}, &value, survey.Required))
+	assert.NoError(t, AskOne("sshKey", &survey.Select{Message: "SSH Public Key", Options: []string{"<none>"}, Default: "<none>"}, &value, nil))
+	assert.NoError(t, AskOne("aws.region", &survey.Select{Message: "Region", Options: []string{"us-east-1"}}, &value, nil))
+	assert.NoError(t, AskOne("pullSecret", &survey.Password{Message: "Pull Secret"}, &value, survey.Required))
+	var confirm bool
+	assert.NoError(t, AskOne("confirm", &survey.Confirm{Message: "Confirm"}, &confirm, nil))
+
+	err := Missing()
+	require.IsType(t, &MissingError{}, err)
+	assert.Equal(t, []string{"pullSecret", "aws.region", "confirm"}, err.(*MissingError).Keys)
+	assert.EqualError(t, err, "missing answers for pullSecret, aws.region, confirm; add them to the answers file or set OPENSHIFT_INSTALL_ANSWER_<KEY>")
+}
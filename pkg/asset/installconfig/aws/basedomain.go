@@ -12,6 +12,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	survey "gopkg.in/AlecAivazis/survey.v1"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 )
 
 // IsForbidden returns true if and only if the input error is an HTTP
@@ -57,7 +59,7 @@ func GetBaseDomain() (string, error) {
 	}
 
 	var domain string
-	if err := survey.AskOne(&survey.Select{
+	if err := answers.AskOne("baseDomain", &survey.Select{
 		Message: "Base Domain",
 		Help:    "The base domain of the cluster. All DNS records will be sub-domains of this base and will also include the cluster name.\n\nIf you don't see you intended base-domain listed, create a new public Route53 hosted zone and rerun the installer.",
 		Options: publicZones,
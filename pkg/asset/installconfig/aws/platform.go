@@ -9,6 +9,7 @@ import (
 	"github.com/sirupsen/logrus"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/types/aws"
 )
 
@@ -48,7 +49,7 @@ func Platform() (*aws.Platform, error) {
 	sort.Strings(shortRegions)
 
 	var region string
-	err = survey.Ask([]*survey.Question{
+	err = answers.Ask("aws.region", []*survey.Question{
 		{
 			Prompt: &survey.Select{
 				Message: "Region",
@@ -16,6 +16,7 @@ import (
 	survey "gopkg.in/AlecAivazis/survey.v1"
 	ini "gopkg.in/ini.v1"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	typesaws "github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/version"
 )
@@ -103,7 +104,7 @@ func GetSessionWithOptions(optFuncs ...SessionOptions) (*session.Session, error)
 
 func getCredentials() error {
 	var keyID string
-	err := survey.Ask([]*survey.Question{
+	err := answers.Ask("aws.accessKeyID", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "AWS Access Key ID",
@@ -116,7 +117,7 @@ func getCredentials() error {
 	}
 
 	var secretKey string
-	err = survey.Ask([]*survey.Question{
+	err = answers.Ask("aws.secretAccessKey", []*survey.Question{
 		{
 			Prompt: &survey.Password{
 				Message: "AWS Secret Access Key",
@@ -127,6 +128,9 @@ func getCredentials() error {
 	if err != nil {
 		return err
 	}
+	if err := answers.Missing(); err != nil {
+		return err
+	}
 
 	path := defaults.SharedCredentialsFilename()
 	logrus.Infof("Writing AWS credentials to %q (https://docs.aws.amazon.com/cli/latest/userguide/cli-configure-files.html)", path)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Azure/go-autorest/autorest/to"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/types/azure"
 
 	"github.com/pkg/errors"
@@ -64,7 +65,7 @@ func Platform() (*azure.Platform, error) {
 	sort.Strings(shortRegions)
 
 	var region string
-	err = survey.Ask([]*survey.Question{
+	err = answers.Ask("azure.region", []*survey.Question{
 		{
 			Prompt: &survey.Select{
 				Message: "Region",
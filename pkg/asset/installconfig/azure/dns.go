@@ -9,6 +9,8 @@ import (
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	survey "gopkg.in/AlecAivazis/survey.v1"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 )
 
 //DNSConfig exposes functions to choose the DNS settings
@@ -75,7 +77,7 @@ func (config DNSConfig) GetDNSZone() (*Zone, error) {
 	}
 
 	var zoneName string
-	err := survey.Ask([]*survey.Question{
+	err := answers.Ask("baseDomain", []*survey.Question{
 		{
 			Prompt: &survey.Select{
 				Message: "Base Domain",
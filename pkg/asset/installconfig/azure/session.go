@@ -14,6 +14,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/types/azure"
 )
 
@@ -138,7 +139,7 @@ func getCredentials(fs auth.FileSettings) (*Credentials, error) {
 func askForCredentials() (*Credentials, error) {
 	var subscriptionID, tenantID, clientID, clientSecret string
 
-	err := survey.Ask([]*survey.Question{
+	err := answers.Ask("azure.subscriptionID", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "azure subscription id",
@@ -150,7 +151,7 @@ func askForCredentials() (*Credentials, error) {
 		return nil, err
 	}
 
-	err = survey.Ask([]*survey.Question{
+	err = answers.Ask("azure.tenantID", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "azure tenant id",
@@ -162,7 +163,7 @@ func askForCredentials() (*Credentials, error) {
 		return nil, err
 	}
 
-	err = survey.Ask([]*survey.Question{
+	err = answers.Ask("azure.clientID", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "azure service principal client id",
@@ -174,7 +175,7 @@ func askForCredentials() (*Credentials, error) {
 		return nil, err
 	}
 
-	err = survey.Ask([]*survey.Question{
+	err = answers.Ask("azure.clientSecret", []*survey.Question{
 		{
 			Prompt: &survey.Password{
 				Message: "azure service principal client secret",
@@ -185,6 +186,9 @@ func askForCredentials() (*Credentials, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := answers.Missing(); err != nil {
+		return nil, err
+	}
 
 	return &Credentials{
 		SubscriptionID: subscriptionID,
@@ -3,10 +3,12 @@ package baremetal
 
 import (
 	"fmt"
+
 	"github.com/pkg/errors"
 	"gopkg.in/AlecAivazis/survey.v1"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/ipnet"
 	"github.com/openshift/installer/pkg/types/baremetal"
 	baremetaldefaults "github.com/openshift/installer/pkg/types/baremetal/defaults"
@@ -18,15 +20,17 @@ func Platform() (*baremetal.Platform, error) {
 	var parsedCIDR *ipnet.IPNet
 	var hosts []*baremetal.Host
 
-	survey.AskOne(&survey.Select{
+	if err := answers.AskOne("baremetal.provisioningNetwork", &survey.Select{
 		Message: "Provisioning Network",
 		Help:    "Select whether the provisioning network will be managed, unmanaged, or disabled. In managed mode, the cluster deploys DHCP and TFTP services for PXE provisioning.",
 		Options: []string{"Managed", "Unmanaged", "Disabled"},
 		Default: "Managed",
-	}, &provisioningNetwork, nil)
+	}, &provisioningNetwork, nil); err != nil {
+		return nil, err
+	}
 
 	if provisioningNetwork != string(baremetal.DisabledProvisioningNetwork) {
-		if err := survey.Ask([]*survey.Question{
+		if err := answers.Ask("baremetal.provisioningNetworkCIDR", []*survey.Question{
 			{
 				Prompt: &survey.Input{
 					Message: "Provisioning Network CIDR",
@@ -44,7 +48,7 @@ func Platform() (*baremetal.Platform, error) {
 		}
 		parsedCIDR = provNetCIDR
 
-		if err := survey.Ask([]*survey.Question{
+		if err := answers.Ask("baremetal.provisioningBridge", []*survey.Question{
 			{
 				Prompt: &survey.Input{
 					Message: "Provisioning bridge",
@@ -56,7 +60,7 @@ func Platform() (*baremetal.Platform, error) {
 			return nil, err
 		}
 
-		if err := survey.Ask([]*survey.Question{
+		if err := answers.Ask("baremetal.provisioningNetworkInterface", []*survey.Question{
 			{
 				Prompt: &survey.Input{
 					Message: "Provisioning Network Interface",
@@ -69,7 +73,7 @@ func Platform() (*baremetal.Platform, error) {
 		}
 	}
 
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask("baremetal.externalBridge", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "External bridge",
@@ -82,20 +86,26 @@ func Platform() (*baremetal.Platform, error) {
 	}
 
 	// Keep prompting for hosts
-	for {
+	for i := 0; ; i++ {
+		key := fmt.Sprintf("baremetal.hosts[%d]", i)
 		var hostRole string
-		survey.AskOne(&survey.Select{
+		if err := answers.AskOne(key+".role", &survey.Select{
 			Message: "Add a Host:",
 			Options: []string{"control plane", "worker"},
-		}, &hostRole, nil)
+		}, &hostRole, nil); err != nil && answers.Enabled() {
+			return nil, err
+		}
 
 		var host *baremetal.Host
 		var err error
-		host, err = Host()
+		host, err = Host(key)
 		// Check for kebyoard interrupt or else we'll loop forever
 		if errors.Is(err, terminal.InterruptErr) {
 			fmt.Println("interrupted - hosts were not added")
 			break
+		} else if err != nil && answers.Enabled() {
+			// Answers are never asked again.
+			return nil, err
 		} else if err != nil {
 			fmt.Printf("invalid host - please try again")
 			continue
@@ -108,9 +118,13 @@ func Platform() (*baremetal.Platform, error) {
 		hosts = append(hosts, host)
 
 		more := false
-		survey.AskOne(&survey.Confirm{
-			Message: "Add another host?",
-		}, &more, nil)
+		if answers.Enabled() {
+			more = answers.Has(fmt.Sprintf("baremetal.hosts[%d]", i+1))
+		} else {
+			survey.AskOne(&survey.Confirm{
+				Message: "Add another host?",
+			}, &more, nil)
+		}
 		if !more {
 			break
 		}
@@ -0,0 +1,95 @@
+package baremetal
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/metal3-io/baremetal-operator/pkg/bmc"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types/baremetal"
+)
+
+// bmcCheckTimeout bounds the checks of each BMC.
+const bmcCheckTimeout = time.Minute
+
+// ipmitool is the command that checks IPMI BMCs. IPMI BMCs are not checked
+// when it is not installed.
+var ipmitool = "ipmitool"
+
+// validateBMCs logs into the BMC of each host to check that it accepts the
+// credentials and can control the host, so that mistakes are reported before
+// the cluster is created rather than when provisioning the hosts times out.
+func validateBMCs(hosts []*baremetal.Host, fldPath *field.Path) field.ErrorList {
+	hostErrs := make([]field.ErrorList, len(hosts))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host *baremetal.Host) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.TODO(), bmcCheckTimeout)
+			defer cancel()
+			hostErrs[i] = validateBMC(ctx, host, fldPath.Index(i))
+		}(i, host)
+	}
+	wg.Wait()
+
+	allErrs := field.ErrorList{}
+	for _, errs := range hostErrs {
+		allErrs = append(allErrs, errs...)
+	}
+	return allErrs
+}
+
+func validateBMC(ctx context.Context, host *baremetal.Host, fldPath *field.Path) field.ErrorList {
+	accessDetails, err := bmc.NewAccessDetails(host.BMC.Address, host.BMC.DisableCertificateVerification)
+	if err != nil {
+		// Invalid addresses are reported by the install-config validation.
+		return nil
+	}
+	info := accessDetails.DriverInfo(bmc.Credentials{Username: host.BMC.Username, Password: host.BMC.Password})
+	switch {
+	case info["redfish_address"] != nil:
+		return validateRedfish(ctx, host, info, fldPath)
+	case info["ipmi_address"] != nil:
+		return validateIPMI(ctx, host, info, fldPath)
+	default:
+		logrus.Debugf("Skipping the check of the %s BMC of host %s", accessDetails.Type(), host.Name)
+		return nil
+	}
+}
+
+// validateIPMI checks that ipmitool can get the power status of the host with
+// the operator privileges needed to control its power.
+func validateIPMI(ctx context.Context, host *baremetal.Host, info map[string]interface{}, fldPath *field.Path) field.ErrorList {
+	path, err := exec.LookPath(ipmitool)
+	if err != nil {
+		logrus.Debugf("Skipping the check of the IPMI BMC of host %s: %s is not installed", host.Name, ipmitool)
+		return nil
+	}
+
+	cmd := exec.CommandContext(ctx, path,
+		"-I", "lanplus",
+		"-H", info["ipmi_address"].(string),
+		"-p", info["ipmi_port"].(string),
+		"-U", host.BMC.Username,
+		"-E",
+		"-L", "OPERATOR",
+		"power", "status")
+	// The password is passed in the environment so that it is not visible in
+	// the process list.
+	cmd.Env = append(os.Environ(), "IPMI_PASSWORD="+host.BMC.Password)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		message := strings.TrimSpace(string(output))
+		if message == "" {
+			message = err.Error()
+		}
+		return field.ErrorList{field.Invalid(fldPath.Child("bmc", "address"), host.BMC.Address, "failed to get the power status with the operator privilege over IPMI; check the address, username and password: "+message)}
+	}
+	return nil
+}
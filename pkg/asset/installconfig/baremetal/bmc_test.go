@@ -0,0 +1,194 @@
+package baremetal
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types/baremetal"
+)
+
+// fakeRedfish serves a BMC managing a single system.
+type fakeRedfish struct {
+	resetTypes []string
+	bootModes  []string
+	macs       []string
+}
+
+func (f *fakeRedfish) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "password" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var resource interface{}
+	switch {
+	case r.URL.Path == "/redfish/v1/Systems":
+		resource = map[string]interface{}{
+			"Members": []interface{}{map[string]string{"@odata.id": "/redfish/v1/Systems/1"}},
+		}
+	case r.URL.Path == "/redfish/v1/Systems/1":
+		resource = map[string]interface{}{
+			"Actions": map[string]interface{}{
+				"#ComputerSystem.Reset": map[string]interface{}{
+					"target":                            "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset",
+					"ResetType@Redfish.AllowableValues": f.resetTypes,
+				},
+			},
+			"Boot": map[string]interface{}{
+				"BootSourceOverrideMode@Redfish.AllowableValues": f.bootModes,
+			},
+			"EthernetInterfaces": map[string]string{"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces"},
+		}
+	case r.URL.Path == "/redfish/v1/Systems/1/EthernetInterfaces":
+		var members []interface{}
+		for i := range f.macs {
+			members = append(members, map[string]string{"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/" + string(rune('a'+i))})
+		}
+		resource = map[string]interface{}{"Members": members}
+	case strings.HasPrefix(r.URL.Path, "/redfish/v1/Systems/1/EthernetInterfaces/"):
+		i := int(strings.TrimPrefix(r.URL.Path, "/redfish/v1/Systems/1/EthernetInterfaces/")[0] - 'a')
+		resource = map[string]string{"MACAddress": f.macs[i]}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(resource)
+}
+
+func TestValidateBMCsRedfish(t *testing.T) {
+	server := httptest.NewServer(&fakeRedfish{
+		resetTypes: []string{"On", "ForceOff", "ForceRestart"},
+		bootModes:  []string{"UEFI"},
+		macs:       []string{"52:54:00:00:00:01", "52:54:00:00:00:0a"},
+	})
+	defer server.Close()
+	address := "redfish+" + server.URL + "/redfish/v1/Systems/1"
+
+	cases := []struct {
+		name     string
+		host     func(*baremetal.Host)
+		expected string
+	}{
+		{
+			name: "valid",
+			host: func(h *baremetal.Host) {},
+		},
+		{
+			name: "valid without system path",
+			host: func(h *baremetal.Host) { h.BMC.Address = "redfish+" + server.URL },
+		},
+		{
+			name: "valid with upper-case MAC address",
+			host: func(h *baremetal.Host) { h.BootMACAddress = "52:54:00:00:00:0A" },
+		},
+		{
+			name:     "wrong password",
+			host:     func(h *baremetal.Host) { h.BMC.Password = "wrong" },
+			expected: `^hosts\[0\]\.bmc\.username: Invalid value: "admin": the BMC rejected the username or password$`,
+		},
+		{
+			name:     "unknown system",
+			host:     func(h *baremetal.Host) { h.BMC.Address = "redfish+" + server.URL + "/redfish/v1/Systems/2" },
+			expected: `^hosts\[0\]\.bmc\.address: Invalid value: ".*": failed to get the system from the BMC: GET /redfish/v1/Systems/2 returned 404 Not Found$`,
+		},
+		{
+			name:     "unreachable",
+			host:     func(h *baremetal.Host) { h.BMC.Address = "redfish+http://127.0.0.1:1/redfish/v1/Systems/1" },
+			expected: `^hosts\[0\]\.bmc\.address: Invalid value: "redfish\+http://127\.0\.0\.1:1/redfish/v1/Systems/1": failed to get the system from the BMC: .*connection refused$`,
+		},
+		{
+			name:     "unsupported boot mode",
+			host:     func(h *baremetal.Host) { h.BootMode = baremetal.Legacy },
+			expected: `^hosts\[0\]\.bootMode: Invalid value: "legacy": the system only supports the boot modes UEFI$`,
+		},
+		{
+			name:     "unknown MAC address",
+			host:     func(h *baremetal.Host) { h.BootMACAddress = "52:54:00:00:00:03" },
+			expected: `^hosts\[0\]\.bootMACAddress: Invalid value: "52:54:00:00:00:03": the system has no network interface with this MAC address; it has 52:54:00:00:00:01, 52:54:00:00:00:0a$`,
+		},
+		{
+			name: "unchecked BMC type",
+			host: func(h *baremetal.Host) { h.BMC.Address = "idrac://127.0.0.1:1" },
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			host := &baremetal.Host{
+				Name:           "host",
+				BootMACAddress: "52:54:00:00:00:0a",
+				BMC: baremetal.BMC{
+					Address:  address,
+					Username: "admin",
+					Password: "password",
+				},
+			}
+			tc.host(host)
+			errs := validateBMCs([]*baremetal.Host{host}, field.NewPath("hosts"))
+			if tc.expected == "" {
+				assert.Empty(t, errs)
+			} else {
+				assert.Regexp(t, tc.expected, errs.ToAggregate())
+			}
+		})
+	}
+}
+
+func TestValidateBMCsPowerControl(t *testing.T) {
+	server := httptest.NewServer(&fakeRedfish{resetTypes: []string{"ForceRestart"}})
+	defer server.Close()
+
+	hosts := []*baremetal.Host{{
+		Name: "host",
+		BMC: baremetal.BMC{
+			Address:  "redfish+" + server.URL + "/redfish/v1/Systems/1",
+			Username: "admin",
+			Password: "password",
+		},
+	}}
+	errs := validateBMCs(hosts, field.NewPath("hosts"))
+	assert.Regexp(t, `^hosts\[0\]\.bmc\.address: Invalid value: ".*": the BMC cannot power the system on and off; it only supports the reset types ForceRestart$`, errs.ToAggregate())
+}
+
+func TestValidateBMCsIPMI(t *testing.T) {
+	defer func(command string) { ipmitool = command }(ipmitool)
+
+	dir, err := ioutil.TempDir("", "ipmitool")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ipmitool = filepath.Join(dir, "ipmitool")
+	require.NoError(t, ioutil.WriteFile(ipmitool, []byte(`#!/bin/sh
+if [ "$IPMI_PASSWORD" = password ]; then
+	echo "Chassis Power is on"
+else
+	echo "Error: Unable to establish IPMI v2 / RMCP+ session" >&2
+	exit 1
+fi
+`), 0700))
+
+	host := &baremetal.Host{
+		Name: "host",
+		BMC: baremetal.BMC{
+			Address:  "ipmi://192.168.111.1:6230",
+			Username: "admin",
+			Password: "password",
+		},
+	}
+	assert.Empty(t, validateBMCs([]*baremetal.Host{host}, field.NewPath("hosts")))
+
+	host.BMC.Password = "wrong"
+	errs := validateBMCs([]*baremetal.Host{host}, field.NewPath("hosts"))
+	assert.EqualError(t, errs.ToAggregate(), `hosts[0].bmc.address: Invalid value: "ipmi://192.168.111.1:6230": failed to get the power status with the operator privilege over IPMI; check the address, username and password: Error: Unable to establish IPMI v2 / RMCP+ session`)
+
+	ipmitool = filepath.Join(dir, "missing")
+	assert.Empty(t, validateBMCs([]*baremetal.Host{host}, field.NewPath("hosts")), "IPMI BMCs are not checked without ipmitool")
+}
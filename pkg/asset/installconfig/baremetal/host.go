@@ -3,15 +3,17 @@ package baremetal
 import (
 	"gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/types/baremetal"
 	"github.com/openshift/installer/pkg/validate"
 )
 
-// Host prompts the user for hardware details about a baremetal host.
-func Host() (*baremetal.Host, error) {
+// Host prompts the user for hardware details about a baremetal host. key is
+// the answers key of the host.
+func Host(key string) (*baremetal.Host, error) {
 	var host baremetal.Host
 
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask(key+".name", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Name",
@@ -22,7 +24,7 @@ func Host() (*baremetal.Host, error) {
 		return nil, err
 	}
 
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask(key+".bmc.address", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "BMC Address",
@@ -34,7 +36,7 @@ func Host() (*baremetal.Host, error) {
 		return nil, err
 	}
 
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask(key+".bmc.username", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "BMC Username",
@@ -45,7 +47,7 @@ func Host() (*baremetal.Host, error) {
 		return nil, err
 	}
 
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask(key+".bmc.password", []*survey.Question{
 		{
 			Prompt: &survey.Password{
 				Message: "BMC Password",
@@ -56,7 +58,7 @@ func Host() (*baremetal.Host, error) {
 		return nil, err
 	}
 
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask(key+".bootMACAddress", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Boot MAC Address",
@@ -0,0 +1,207 @@
+package baremetal
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types/baremetal"
+)
+
+// errRedfishUnauthorized is returned when the BMC rejects the credentials.
+var errRedfishUnauthorized = errors.New("the BMC rejected the username or password")
+
+// redfishLink is a link to another Redfish resource.
+type redfishLink struct {
+	ID string `json:"@odata.id"`
+}
+
+type redfishCollection struct {
+	Members []redfishLink `json:"Members"`
+}
+
+// redfishSystem holds the fields of a Redfish ComputerSystem that are checked.
+type redfishSystem struct {
+	Actions map[string]struct {
+		Target     string   `json:"target"`
+		ResetTypes []string `json:"ResetType@Redfish.AllowableValues"`
+	} `json:"Actions"`
+	Boot struct {
+		BootSourceOverrideModes []string `json:"BootSourceOverrideMode@Redfish.AllowableValues"`
+	} `json:"Boot"`
+	EthernetInterfaces *redfishLink `json:"EthernetInterfaces"`
+}
+
+type redfishEthernetInterface struct {
+	MACAddress          string `json:"MACAddress"`
+	PermanentMACAddress string `json:"PermanentMACAddress"`
+}
+
+type redfishClient struct {
+	client   *http.Client
+	address  string
+	username string
+	password string
+}
+
+func newRedfishClient(info map[string]interface{}) *redfishClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if verify, ok := info["redfish_verify_ca"].(bool); ok && !verify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	return &redfishClient{
+		client:   &http.Client{Transport: transport},
+		address:  info["redfish_address"].(string),
+		username: info["redfish_username"].(string),
+		password: info["redfish_password"].(string),
+	}
+}
+
+// get decodes the resource at path into v.
+func (c *redfishClient) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.address+path, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return errRedfishUnauthorized
+	default:
+		return errors.Errorf("GET %s returned %s", path, resp.Status)
+	}
+	return errors.Wrapf(json.NewDecoder(resp.Body).Decode(v), "failed to decode %s", path)
+}
+
+// systemID returns the path of the host's system, which is the only system of
+// the BMC when the address does not include it.
+func (c *redfishClient) systemID(ctx context.Context, info map[string]interface{}) (string, error) {
+	systemID := info["redfish_system_id"].(string)
+	if strings.Trim(systemID, "/") != "" {
+		return systemID, nil
+	}
+	var systems redfishCollection
+	if err := c.get(ctx, "/redfish/v1/Systems", &systems); err != nil {
+		return "", err
+	}
+	if len(systems.Members) != 1 {
+		return "", errors.Errorf("the BMC manages %d systems; add the path of the host's system to the address", len(systems.Members))
+	}
+	return systems.Members[0].ID, nil
+}
+
+// validateRedfish checks that the BMC accepts the credentials and can reset
+// the host's system, that the system has the boot MAC address, and that it
+// supports the boot mode.
+func validateRedfish(ctx context.Context, host *baremetal.Host, info map[string]interface{}, fldPath *field.Path) field.ErrorList {
+	client := newRedfishClient(info)
+	systemID, err := client.systemID(ctx, info)
+	if err != nil {
+		return redfishErrors(host, fldPath, err)
+	}
+	var system redfishSystem
+	if err := client.get(ctx, systemID, &system); err != nil {
+		return redfishErrors(host, fldPath, err)
+	}
+
+	allErrs := field.ErrorList{}
+	if err := validateRedfishReset(&system); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("bmc", "address"), host.BMC.Address, err.Error()))
+	}
+
+	bootMode := host.BootMode
+	if bootMode == "" {
+		bootMode = baremetal.UEFI
+	}
+	if modes := system.Boot.BootSourceOverrideModes; len(modes) > 0 && !containsFold(modes, string(bootMode)) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("bootMode"), bootMode, fmt.Sprintf("the system only supports the boot modes %s", strings.Join(modes, ", "))))
+	}
+
+	if system.EthernetInterfaces == nil {
+		logrus.Debugf("Skipping the check of the boot MAC address of host %s: the BMC does not list the network interfaces", host.Name)
+		return allErrs
+	}
+	macs, err := client.macAddresses(ctx, system.EthernetInterfaces.ID)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath.Child("bmc", "address"), host.BMC.Address, err.Error()))
+	}
+	if len(macs) == 0 {
+		logrus.Debugf("Skipping the check of the boot MAC address of host %s: the BMC lists no MAC addresses", host.Name)
+		return allErrs
+	}
+	if !containsFold(macs, host.BootMACAddress) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("bootMACAddress"), host.BootMACAddress, fmt.Sprintf("the system has no network interface with this MAC address; it has %s", strings.Join(macs, ", "))))
+	}
+	return allErrs
+}
+
+// validateRedfishReset returns an error if the system cannot be powered on and
+// off.
+func validateRedfishReset(system *redfishSystem) error {
+	reset, ok := system.Actions["#ComputerSystem.Reset"]
+	if !ok || reset.Target == "" {
+		return errors.New("the BMC does not support power control of the system")
+	}
+	if len(reset.ResetTypes) == 0 {
+		return nil
+	}
+	if !containsFold(reset.ResetTypes, "On") || !(containsFold(reset.ResetTypes, "ForceOff") || containsFold(reset.ResetTypes, "GracefulShutdown")) {
+		return errors.Errorf("the BMC cannot power the system on and off; it only supports the reset types %s", strings.Join(reset.ResetTypes, ", "))
+	}
+	return nil
+}
+
+// macAddresses returns the MAC addresses of the ethernet interfaces in the
+// collection at path.
+func (c *redfishClient) macAddresses(ctx context.Context, path string) ([]string, error) {
+	var interfaces redfishCollection
+	if err := c.get(ctx, path, &interfaces); err != nil {
+		return nil, err
+	}
+	var macs []string
+	for _, member := range interfaces.Members {
+		var iface redfishEthernetInterface
+		if err := c.get(ctx, member.ID, &iface); err != nil {
+			return nil, err
+		}
+		for _, mac := range []string{iface.MACAddress, iface.PermanentMACAddress} {
+			if mac != "" && !containsFold(macs, mac) {
+				macs = append(macs, mac)
+			}
+		}
+	}
+	return macs, nil
+}
+
+// redfishErrors reports the failure to get the host's system.
+func redfishErrors(host *baremetal.Host, fldPath *field.Path, err error) field.ErrorList {
+	if err == errRedfishUnauthorized {
+		return field.ErrorList{field.Invalid(fldPath.Child("bmc", "username"), host.BMC.Username, err.Error())}
+	}
+	return field.ErrorList{field.Invalid(fldPath.Child("bmc", "address"), host.BMC.Address, fmt.Sprintf("failed to get the system from the BMC: %v", err))}
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
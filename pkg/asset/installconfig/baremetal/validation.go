@@ -17,6 +17,7 @@ func ValidateProvisioning(ic *types.InstallConfig) error {
 	}
 
 	allErrs = append(allErrs, validation.ValidateProvisioning(ic.Platform.BareMetal, ic.Networking, field.NewPath("platform").Child("baremetal"))...)
+	allErrs = append(allErrs, validateBMCs(ic.Platform.BareMetal.Hosts, field.NewPath("platform").Child("baremetal").Child("hosts"))...)
 
 	return allErrs.ToAggregate()
 }
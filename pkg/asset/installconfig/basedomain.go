@@ -6,6 +6,7 @@ import (
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	azureconfig "github.com/openshift/installer/pkg/asset/installconfig/azure"
 	gcpconfig "github.com/openshift/installer/pkg/asset/installconfig/gcp"
@@ -65,7 +66,7 @@ func (a *baseDomain) Generate(parents asset.Parents) error {
 		//Do nothing
 	}
 
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask("baseDomain", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Base Domain",
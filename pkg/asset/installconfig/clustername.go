@@ -5,6 +5,7 @@ import (
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/validate"
 )
@@ -42,7 +43,7 @@ func (a *clusterName) Generate(parents asset.Parents) error {
 		return validate.DomainName(installConfig.ClusterDomain(), false)
 	})
 
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask("clusterName", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Cluster Name",
@@ -9,6 +9,8 @@ import (
 	dns "google.golang.org/api/dns/v1"
 	"google.golang.org/api/googleapi"
 	survey "gopkg.in/AlecAivazis/survey.v1"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 )
 
 // GetPublicZone returns a DNS managed zone from the provided project which matches the baseDomain
@@ -47,7 +49,7 @@ func GetBaseDomain(project string) (string, error) {
 	sort.Strings(publicZones)
 
 	var domain string
-	if err := survey.AskOne(&survey.Select{
+	if err := answers.AskOne("baseDomain", &survey.Select{
 		Message: "Base Domain",
 		Help:    "The base domain of the cluster. All DNS records will be sub-domains of this base and will also include the cluster name.\n\nIf you don't see you intended base-domain listed, create a new public hosted zone and rerun the installer.",
 		Options: publicZones,
@@ -9,6 +9,7 @@ import (
 
 	"gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/types/gcp"
 	"github.com/openshift/installer/pkg/types/gcp/validation"
 	"github.com/pkg/errors"
@@ -66,7 +67,7 @@ func selectProject(ctx context.Context) (string, error) {
 	sort.Strings(options)
 
 	var selectedProject string
-	err = survey.Ask([]*survey.Question{
+	err = answers.Ask("gcp.projectID", []*survey.Question{
 		{
 			Prompt: &survey.Select{
 				Message: "Project ID",
@@ -97,7 +98,7 @@ func selectRegion(project string) (string, error) {
 
 	defaultRegion := "us-central1"
 	var selectedRegion string
-	err := survey.Ask([]*survey.Question{
+	err := answers.Ask("gcp.region", []*survey.Question{
 		{
 			Prompt: &survey.Select{
 				Message: "Region",
@@ -14,6 +14,8 @@ import (
 	googleoauth "golang.org/x/oauth2/google"
 	compute "google.golang.org/api/compute/v1"
 	"gopkg.in/AlecAivazis/survey.v1"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 )
 
 var (
@@ -181,7 +183,7 @@ type userLoader struct{}
 
 func (u *userLoader) Load(ctx context.Context) (*googleoauth.Credentials, error) {
 	var content string
-	err := survey.Ask([]*survey.Question{
+	err := answers.Ask("gcp.serviceAccount", []*survey.Question{
 		{
 			Prompt: &survey.Multiline{
 				Message: "Service Account (absolute path to file or JSON content)",
@@ -193,6 +195,9 @@ func (u *userLoader) Load(ctx context.Context) (*googleoauth.Credentials, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := answers.Missing(); err != nil {
+		return nil, err
+	}
 	content = strings.TrimSpace(content)
 	return (&fileOrContentLoader{pathOrContent: content}).Load(ctx)
 }
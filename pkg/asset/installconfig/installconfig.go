@@ -2,6 +2,7 @@ package installconfig
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 
 	"github.com/ghodss/yaml"
@@ -10,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/asset/installconfig/aws"
 	icazure "github.com/openshift/installer/pkg/asset/installconfig/azure"
 	icgcp "github.com/openshift/installer/pkg/asset/installconfig/gcp"
@@ -93,6 +95,12 @@ func (a *InstallConfig) Generate(parents asset.Parents) error {
 	a.Config.Ovirt = platform.Ovirt
 	a.Config.Kubevirt = platform.Kubevirt
 
+	// Every missing answer is reported together, rather than as whatever
+	// validation error the first of them causes.
+	if err := answers.Missing(); err != nil {
+		return err
+	}
+
 	return a.finish("")
 }
 
@@ -130,6 +138,10 @@ func (a *InstallConfig) Load(f asset.FileFetcher) (found bool, err error) {
 		return false, errors.Wrap(err, "failed to upconvert install config")
 	}
 
+	if err := resolveSecrets(a.Config).ToAggregate(); err != nil {
+		return false, errors.Wrapf(err, "invalid %q file", installConfigFilename)
+	}
+
 	err = a.finish(installConfigFilename)
 	if err != nil {
 		return false, err
@@ -140,12 +152,7 @@ func (a *InstallConfig) Load(f asset.FileFetcher) (found bool, err error) {
 func (a *InstallConfig) finish(filename string) error {
 	defaults.SetInstallConfigDefaults(a.Config)
 
-	if a.Config.AWS != nil {
-		a.AWS = aws.NewMetadata(a.Config.Platform.AWS.Region, a.Config.Platform.AWS.Subnets, a.Config.AWS.ServiceEndpoints)
-	}
-	if a.Config.Azure != nil {
-		a.Azure = icazure.NewMetadata(a.Config.Azure.CloudName)
-	}
+	a.setMetadata()
 	if err := validation.ValidateInstallConfig(a.Config).ToAggregate(); err != nil {
 		if filename == "" {
 			return errors.Wrap(err, "invalid install config")
@@ -157,7 +164,7 @@ func (a *InstallConfig) finish(filename string) error {
 		return err
 	}
 
-	data, err := yaml.Marshal(a.Config)
+	data, err := yaml.Marshal(RedactSecretReferences(a.Config))
 	if err != nil {
 		return errors.Wrap(err, "failed to Marshal InstallConfig")
 	}
@@ -168,6 +175,50 @@ func (a *InstallConfig) finish(filename string) error {
 	return nil
 }
 
+// MarshalJSON leaves out the secrets that the install-config refers to, so
+// that they are not saved in the state file.
+func (a *InstallConfig) MarshalJSON() ([]byte, error) {
+	type installConfig InstallConfig
+	c := installConfig(*a)
+	if c.Config != nil {
+		c.Config = RedactSecretReferences(c.Config)
+	}
+	return json.Marshal(&c)
+}
+
+// UnmarshalJSON reads the secrets that the install-config refers to again,
+// since they are not saved in the state file.
+func (a *InstallConfig) UnmarshalJSON(data []byte) error {
+	type installConfig InstallConfig
+	if err := json.Unmarshal(data, (*installConfig)(a)); err != nil {
+		return err
+	}
+	if a.Config != nil {
+		if err := resolveSecrets(a.Config).ToAggregate(); err != nil {
+			return errors.Wrap(err, "failed to resolve secret references")
+		}
+	}
+	return nil
+}
+
+// ValidatePlatform runs the validation of config that calls the platform's
+// APIs, and so needs credentials for the platform. The defaults must already
+// be set on config.
+func ValidatePlatform(config *types.InstallConfig) error {
+	a := &InstallConfig{Config: config}
+	a.setMetadata()
+	return a.platformValidation()
+}
+
+func (a *InstallConfig) setMetadata() {
+	if a.Config.AWS != nil {
+		a.AWS = aws.NewMetadata(a.Config.Platform.AWS.Region, a.Config.Platform.AWS.Subnets, a.Config.AWS.ServiceEndpoints)
+	}
+	if a.Config.Azure != nil {
+		a.Azure = icazure.NewMetadata(a.Config.Azure.CloudName)
+	}
+}
+
 func (a *InstallConfig) platformValidation() error {
 	if a.Config.Platform.Azure != nil {
 		client, err := a.Azure.Client()
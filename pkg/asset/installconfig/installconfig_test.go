@@ -2,15 +2,19 @@ package installconfig
 
 import (
 	"errors"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/asset/mock"
 	"github.com/openshift/installer/pkg/ipnet"
 	"github.com/openshift/installer/pkg/types"
@@ -84,6 +88,76 @@ func TestInstallConfigGenerate_FillsInDefaults(t *testing.T) {
 	assert.Equal(t, expected, installConfig.Config, "unexpected config generated")
 }
 
+func TestInstallConfigGenerate_FromAnswers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "answers")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+	os.Setenv("HOME", dir)
+	defer answers.Reset()
+
+	cases := []struct {
+		name          string
+		answers       string
+		expectedError string
+	}{
+		{
+			name: "complete",
+			answers: `
+baseDomain: test-domain
+clusterName: test-cluster
+pullSecret: '{"auths":{"example.com":{"auth":"authorization value"}}}'
+`,
+		},
+		{
+			name: "missing",
+			answers: `
+baseDomain: test-domain
+`,
+			expectedError: `^missing answers for clusterName, pullSecret;`,
+		},
+		{
+			name: "invalid",
+			answers: `
+baseDomain: test-domain
+clusterName: test_cluster
+`,
+			expectedError: `^failed UserInput: invalid answer for clusterName: a DNS-1123 subdomain`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, "answers.yaml")
+			require.NoError(t, ioutil.WriteFile(path, []byte(tc.answers), 0600))
+			require.NoError(t, answers.Load(path))
+
+			parents := asset.Parents{}
+			platform := &platform{
+				Platform: types.Platform{None: &none.Platform{}},
+			}
+			parents.Add(platform, &networking{})
+			installConfig := &InstallConfig{}
+			err := func() error {
+				for _, a := range []asset.Asset{&sshPublicKey{}, &baseDomain{}, &clusterName{}, &pullSecret{}} {
+					if err := a.Generate(parents); err != nil {
+						return err
+					}
+					parents.Add(a)
+				}
+				return installConfig.Generate(parents)
+			}()
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				assert.Equal(t, "test-cluster", installConfig.Config.ObjectMeta.Name)
+				assert.Equal(t, types.ExternalPublishingStrategy, installConfig.Config.Publish, "defaults should be filled in")
+			} else {
+				assert.Regexp(t, tc.expectedError, err)
+			}
+		})
+	}
+}
+
 func TestInstallConfigLoad(t *testing.T) {
 	cases := []struct {
 		name           string
@@ -3,6 +3,7 @@ package kubevirt
 import (
 	"gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/types/kubevirt"
 )
 
@@ -40,7 +41,7 @@ func Platform() (*kubevirt.Platform, error) {
 func selectNamespace() (string, error) {
 	var selectedNamespace string
 
-	err := survey.Ask([]*survey.Question{
+	err := answers.Ask("kubevirt.namespace", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Namespace",
@@ -55,7 +56,7 @@ func selectNamespace() (string, error) {
 func selectAPIVIP() (string, error) {
 	var selectedAPIVIP string
 
-	err := survey.Ask([]*survey.Question{
+	err := answers.Ask("kubevirt.apiVIP", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "API VIP",
@@ -70,7 +71,7 @@ func selectAPIVIP() (string, error) {
 func selectIngressVIP() (string, error) {
 	var selectedIngressVIP string
 
-	err := survey.Ask([]*survey.Question{
+	err := answers.Ask("kubevirt.ingressVIP", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Ingress VIP",
@@ -85,7 +86,7 @@ func selectIngressVIP() (string, error) {
 func selectNetworkName() (string, error) {
 	var selectedNetworkName string
 
-	err := survey.Ask([]*survey.Question{
+	err := answers.Ask("kubevirt.networkName", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Network Name",
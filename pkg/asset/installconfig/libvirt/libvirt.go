@@ -5,6 +5,7 @@ import (
 	"github.com/pkg/errors"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	libvirtdefaults "github.com/openshift/installer/pkg/types/libvirt/defaults"
 	"github.com/openshift/installer/pkg/validate"
@@ -13,7 +14,7 @@ import (
 // Platform collects libvirt-specific configuration.
 func Platform() (*libvirt.Platform, error) {
 	var uri string
-	err := survey.Ask([]*survey.Question{
+	err := answers.Ask("libvirt.uri", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Libvirt Connection URI",
@@ -4,6 +4,7 @@ import (
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/ipnet"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/kubevirt"
@@ -47,7 +48,7 @@ func (a *networking) Generate(parents asset.Parents) error {
 func selectMachineNetworkCIDR() (string, error) {
 	var selectedCIDR string
 
-	err := survey.Ask([]*survey.Question{
+	err := answers.Ask("machineNetworkCIDR", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Machine Network CIDR",
@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/types/openstack"
 )
 
@@ -25,7 +26,7 @@ func Platform() (*openstack.Platform, error) {
 	// Sort cloudNames so we can use sort.SearchStrings
 	sort.Strings(cloudNames)
 	var cloud string
-	err = survey.Ask([]*survey.Question{
+	err = answers.Ask("openstack.cloud", []*survey.Question{
 		{
 			Prompt: &survey.Select{
 				Message: "Cloud",
@@ -45,6 +46,9 @@ func Platform() (*openstack.Platform, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed UserInput")
 	}
+	if err := answers.Missing(); err != nil {
+		return nil, err
+	}
 
 	// We should unset OS_CLOUD env variable here, because the real cloud name was defined
 	// on the previous step. OS_CLOUD has more priority, so the value from "cloud" variable
@@ -58,7 +62,7 @@ func Platform() (*openstack.Platform, error) {
 	networkNames = append(networkNames, noExtNet)
 	sort.Strings(networkNames)
 	var extNet string
-	err = survey.Ask([]*survey.Question{
+	err = answers.Ask("openstack.externalNetwork", []*survey.Question{
 		{
 			Prompt: &survey.Select{
 				Message: "ExternalNetwork",
@@ -90,7 +94,7 @@ func Platform() (*openstack.Platform, error) {
 			return nil, err
 		}
 		sort.Strings(floatingIPNames)
-		err = survey.Ask([]*survey.Question{
+		err = answers.Ask("openstack.apiFloatingIP", []*survey.Question{
 			{
 				Prompt: &survey.Select{
 					Message: "APIFloatingIPAddress",
@@ -118,7 +122,7 @@ func Platform() (*openstack.Platform, error) {
 	}
 	sort.Strings(flavorNames)
 	var flavor string
-	err = survey.Ask([]*survey.Question{
+	err = answers.Ask("openstack.flavorName", []*survey.Question{
 		{
 			Prompt: &survey.Select{
 				Message: "FlavorName",
@@ -9,6 +9,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/types/ovirt"
 )
 
@@ -38,7 +39,7 @@ func askCluster(c *ovirtsdk4.Connection, p *ovirt.Platform) (string, error) {
 			clusterNames = append(clusterNames, cluster.MustName())
 		}
 	}
-	if err := survey.AskOne(&survey.Select{
+	if err := answers.AskOne("ovirt.cluster", &survey.Select{
 		Message: "Cluster",
 		Help:    "The Cluster where the VMs will be created.",
 		Options: clusterNames,
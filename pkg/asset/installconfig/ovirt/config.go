@@ -9,6 +9,8 @@ import (
 	ovirtsdk "github.com/ovirt/go-ovirt"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
+
+	"github.com/openshift/installer/pkg/types/secret"
 )
 
 var defaultOvirtConfigEnvVar = "OVIRT_CONFIG"
@@ -20,10 +22,14 @@ type Config struct {
 	FQDN     string `yaml:"ovirt_fqdn"`
 	PemURL   string `yaml:"ovirt_pem_url"`
 	Username string `yaml:"ovirt_username"`
-	Password string `yaml:"ovirt_password"`
+	Password string `yaml:"ovirt_password,omitempty"`
 	CAFile   string `yaml:"ovirt_cafile,omitempty"`
 	Insecure bool   `yaml:"ovirt_insecure,omitempty"`
 	CABundle string `yaml:"ovirt_ca_bundle,omitempty"`
+
+	// PasswordRef refers to the password when it is kept outside of the
+	// file. It replaces Password.
+	PasswordRef *secret.Reference `yaml:"ovirt_password_ref,omitempty"`
 }
 
 // clientHTTP struct - Hold info about http calls
@@ -60,6 +66,16 @@ func NewConfig() (Config, error) {
 		return c, err
 	}
 
+	if c.PasswordRef != nil {
+		if c.Password != "" {
+			return c, errors.New("ovirt_password and ovirt_password_ref must not both be set")
+		}
+		c.Password, err = secret.Resolve(c.PasswordRef)
+		if err != nil {
+			return c, errors.Wrap(err, "failed to resolve ovirt_password_ref")
+		}
+	}
+
 	return c, nil
 }
 
@@ -74,8 +90,13 @@ func discoverPath() string {
 
 // Save will serialize the config back into the locations
 // specified in @LoadOvirtConfig, first location with a file, wins.
+// A password that the config refers to is not saved.
 func (c *Config) Save() error {
-	out, err := yaml.Marshal(c)
+	saved := *c
+	if saved.PasswordRef != nil {
+		saved.Password = ""
+	}
+	out, err := yaml.Marshal(&saved)
 	if err != nil {
 		return err
 	}
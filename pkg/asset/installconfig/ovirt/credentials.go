@@ -15,6 +15,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/AlecAivazis/survey.v1"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 )
 
 var errHTTPNotFound = errors.New("http response 404")
@@ -119,7 +121,7 @@ func askPassword(c *Config) error {
 {{- color "default+hb"}}{{ .Message }} {{color "reset"}}
 {{- if and .Help (not .ShowHelp)}}{{color "cyan"}}[Press Ctrl+C to switch username, {{ HelpInputRune }} for help]{{color "reset"}} {{end}}`
 
-	err := survey.Ask([]*survey.Question{
+	err := answers.Ask("ovirt.password", []*survey.Question{
 		{
 			Prompt: &survey.Password{
 				Message: "Engine password",
@@ -142,7 +144,7 @@ func askPassword(c *Config) error {
 // The username provided will be added in the Config struct.
 // Returns Config and error if failure.
 func askUsername(c *Config) error {
-	err := survey.Ask([]*survey.Question{
+	err := answers.Ask("ovirt.username", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Engine username",
@@ -161,9 +163,9 @@ func askUsername(c *Config) error {
 
 // askQuestionTrueOrFalse generic function to ask question to users which
 // requires true (Yes) or false (No) as answer
-func askQuestionTrueOrFalse(question string, helpMessage string) (bool, error) {
+func askQuestionTrueOrFalse(key string, question string, helpMessage string) (bool, error) {
 	value := false
-	err := survey.AskOne(
+	err := answers.AskOne(key,
 		&survey.Confirm{
 			Message: question,
 			Help:    helpMessage,
@@ -187,7 +189,10 @@ func askCredentials(c Config) (Config, error) {
 		}
 
 		err = askPassword(&c)
-		if err != nil {
+		if err != nil && answers.Enabled() {
+			// Answers are never asked again.
+			return c, err
+		} else if err != nil {
 			loginAttempts = loginAttempts - 1
 			logrus.Debugf("login attempts now: %d", loginAttempts)
 			if loginAttempts == 0 {
@@ -197,7 +202,7 @@ func askCredentials(c Config) (Config, error) {
 			break
 		}
 	}
-	return c, nil
+	return c, answers.Missing()
 }
 
 // showPEM will print information about PEM file provided in param or error
@@ -241,7 +246,7 @@ func showPEM(pemFilePath string) error {
 // or in case of failure returns error
 func askPEMFile() (string, error) {
 	bundlePEM := ""
-	err := survey.AskOne(&survey.Multiline{
+	err := answers.AskOne("ovirt.caBundle", &survey.Multiline{
 		Message: "Certificate bundle",
 		Help:    "The certificate bundle to installer be able to communicate with oVirt API",
 	},
@@ -260,7 +265,7 @@ func engineSetup() (Config, error) {
 	engineConfig := Config{}
 	httpResource := clientHTTP{}
 
-	err := survey.Ask([]*survey.Question{
+	err := answers.Ask("ovirt.engineFQDN", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Engine FQDN[:PORT]",
@@ -272,6 +277,9 @@ func engineSetup() (Config, error) {
 	if err != nil {
 		return engineConfig, err
 	}
+	if err := answers.Missing(); err != nil {
+		return engineConfig, err
+	}
 	logrus.Debug("engine FQDN: ", engineConfig.FQDN)
 
 	// By default, we set Insecure true
@@ -314,6 +322,7 @@ func engineSetup() (Config, error) {
 	if err != nil {
 		logrus.Warning("cannot download PEM file from Engine!", err)
 		answer, err := askQuestionTrueOrFalse(
+			"ovirt.insecure",
 			"Would you like to continue?",
 			"By not using a trusted CA, insecure connections can "+
 				"cause man-in-the-middle attacks among many others.")
@@ -326,6 +335,7 @@ func engineSetup() (Config, error) {
 			engineConfig.Insecure = true
 		} else {
 			answer, err := askQuestionTrueOrFalse(
+				"ovirt.useEngineCertificate",
 				"Would you like to use the above certificate to connect to Engine? ",
 				"Certificate to connecto with Engine. Make sure this cert CA is trusted locally.")
 			if err != nil {
@@ -342,6 +352,7 @@ func engineSetup() (Config, error) {
 				}
 			} else {
 				answer, err = askQuestionTrueOrFalse(
+					"ovirt.importCABundle",
 					"Would you like to import another PEM bundle?",
 					"Users are able to use it's own PEM bundle to connect to Engine API")
 				if err != nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/types/ovirt"
 )
 
@@ -29,7 +30,7 @@ func askNetwork(c *ovirtsdk4.Connection, p *ovirt.Platform) error {
 		networkByNames[network.MustName()] = network
 		networkNames = append(networkNames, network.MustName())
 	}
-	if err := survey.AskOne(&survey.Select{
+	if err := answers.AskOne("ovirt.network", &survey.Select{
 		Message: "Network",
 		Help:    "The Engine network of the deployed VMs. 'ovirtmgmt' is the default network. It is recommended to use a dedicated network for each OpenShift cluster.",
 		Options: networkNames,
@@ -74,7 +75,7 @@ func askVNICProfileID(c *ovirtsdk4.Connection, p *ovirt.Platform) error {
 	}
 
 	// we have multiple vnic profile for the selected network
-	if err := survey.AskOne(&survey.Select{
+	if err := answers.AskOne("ovirt.vnicProfile", &survey.Select{
 		Message: "VNIC Profile",
 		Help:    "The Engine VNIC profile of the VMs.",
 		Options: profileNames,
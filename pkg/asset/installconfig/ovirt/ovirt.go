@@ -6,6 +6,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/types/ovirt"
 )
 
@@ -21,6 +22,9 @@ func Platform() (*ovirt.Platform, error) {
 	for tries := 0; tries < platformValidationMaxTries; tries++ {
 		if err != nil {
 			ovirtConfig, err = engineSetup()
+			if _, ok := errors.Cause(err).(*answers.MissingError); ok {
+				return nil, err
+			}
 			if err != nil {
 				logrus.Error(errors.Wrap(err, "oVirt configuration failed"))
 			}
@@ -64,7 +68,7 @@ func Platform() (*ovirt.Platform, error) {
 		return &p, err
 	}
 
-	err = survey.Ask([]*survey.Question{
+	err = answers.Ask("ovirt.apiVIP", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Internal API virtual IP",
@@ -78,7 +82,7 @@ func Platform() (*ovirt.Platform, error) {
 		return nil, errors.Wrap(err, "failed UserInput")
 	}
 
-	err = survey.Ask([]*survey.Question{
+	err = answers.Ask("ovirt.ingressVIP", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Ingress virtual IP",
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/types/ovirt"
 )
 
@@ -29,7 +30,7 @@ func askStorage(c *ovirtsdk4.Connection, p *ovirt.Platform, clusterName string)
 		domainsForCluster[domain.MustName()] = domain
 		domainNames = append(domainNames, domain.MustName())
 	}
-	if err := survey.AskOne(&survey.Select{
+	if err := answers.AskOne("ovirt.storageDomain", &survey.Select{
 		Message: "Storage domain",
 		Help:    "The storage domain will be used to create the disks of all the cluster nodes.",
 		Options: domainNames,
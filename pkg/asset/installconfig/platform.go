@@ -8,6 +8,7 @@ import (
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	azureconfig "github.com/openshift/installer/pkg/asset/installconfig/azure"
 	baremetalconfig "github.com/openshift/installer/pkg/asset/installconfig/baremetal"
@@ -111,7 +112,7 @@ func (a *platform) Name() string {
 }
 
 func (a *platform) queryUserForPlatform() (platform string, err error) {
-	err = survey.Ask([]*survey.Question{
+	err = answers.Ask("platform", []*survey.Question{
 		{
 			Prompt: &survey.Select{
 				Message: "Platform",
@@ -5,6 +5,7 @@ import (
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/validate"
 )
 
@@ -21,7 +22,7 @@ func (a *pullSecret) Dependencies() []asset.Asset {
 
 // Generate queries for the pull secret from the user.
 func (a *pullSecret) Generate(asset.Parents) error {
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask("pullSecret", []*survey.Question{
 		{
 			Prompt: &survey.Password{
 				Message: "Pull Secret",
@@ -0,0 +1,149 @@
+package installconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"regexp"
+	"sort"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// validateSchema validates a value decoded from JSON against the subset of
+// OpenAPI v3 used by the InstallConfig CRD: types, unknown properties, enums,
+// and the string, number and array constraints.
+func validateSchema(schema *apiextv1.JSONSchemaProps, value interface{}, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if schema == nil || value == nil {
+		return allErrs
+	}
+
+	if schema.XIntOrString {
+		switch value.(type) {
+		case string, float64:
+		default:
+			allErrs = append(allErrs, field.Invalid(fldPath, value, "must be an integer or a string"))
+		}
+		return allErrs
+	}
+
+	switch schema.Type {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return append(allErrs, field.Invalid(fldPath, value, "must be an object"))
+		}
+		allErrs = append(allErrs, validateObject(schema, obj, fldPath)...)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return append(allErrs, field.Invalid(fldPath, value, "must be an array"))
+		}
+		if schema.MaxItems != nil && int64(len(items)) > *schema.MaxItems {
+			allErrs = append(allErrs, field.TooMany(fldPath, len(items), int(*schema.MaxItems)))
+		}
+		if schema.MinItems != nil && int64(len(items)) < *schema.MinItems {
+			allErrs = append(allErrs, field.Invalid(fldPath, len(items), fmt.Sprintf("must have at least %d items", *schema.MinItems)))
+		}
+		if schema.Items != nil && schema.Items.Schema != nil {
+			for i, item := range items {
+				allErrs = append(allErrs, validateSchema(schema.Items.Schema, item, fldPath.Index(i))...)
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return append(allErrs, field.Invalid(fldPath, value, "must be a string"))
+		}
+		if schema.MaxLength != nil && int64(len(s)) > *schema.MaxLength {
+			allErrs = append(allErrs, field.TooLong(fldPath, s, int(*schema.MaxLength)))
+		}
+		if schema.MinLength != nil && int64(len(s)) < *schema.MinLength {
+			allErrs = append(allErrs, field.Invalid(fldPath, s, fmt.Sprintf("must be at least %d characters long", *schema.MinLength)))
+		}
+		if schema.Pattern != "" {
+			if re, err := regexp.Compile(schema.Pattern); err == nil && !re.MatchString(s) {
+				allErrs = append(allErrs, field.Invalid(fldPath, s, fmt.Sprintf("must match %q", schema.Pattern)))
+			}
+		}
+		if schema.Format == "ip" && net.ParseIP(s) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, s, "must be an IP address"))
+		}
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok || (schema.Type == "integer" && n != math.Trunc(n)) {
+			return append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be an %s", schema.Type)))
+		}
+		if schema.Minimum != nil && (n < *schema.Minimum || (schema.ExclusiveMinimum && n == *schema.Minimum)) {
+			allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be greater than or equal to %v", *schema.Minimum)))
+		}
+		if schema.Maximum != nil && (n > *schema.Maximum || (schema.ExclusiveMaximum && n == *schema.Maximum)) {
+			allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be less than or equal to %v", *schema.Maximum)))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return append(allErrs, field.Invalid(fldPath, value, "must be a boolean"))
+		}
+	}
+
+	if len(schema.Enum) > 0 {
+		allErrs = append(allErrs, validateEnum(schema.Enum, value, fldPath)...)
+	}
+	return allErrs
+}
+
+// validateObject validates the properties of an object. Properties that are
+// not in the schema are forbidden, unless the schema allows additional
+// properties or does not list any properties at all. Required properties are
+// not checked, as the CRD requires some properties that the installer sets
+// defaults for; the install-config validation reports those that are missing.
+func validateObject(schema *apiextv1.JSONSchemaProps, obj map[string]interface{}, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if property, ok := schema.Properties[key]; ok {
+			allErrs = append(allErrs, validateSchema(&property, obj[key], fldPath.Child(key))...)
+			continue
+		}
+		if additional := schema.AdditionalProperties; additional != nil {
+			if additional.Schema != nil {
+				allErrs = append(allErrs, validateSchema(additional.Schema, obj[key], fldPath.Key(key))...)
+				continue
+			}
+			if additional.Allows {
+				continue
+			}
+		}
+		if len(schema.Properties) > 0 && (schema.XPreserveUnknownFields == nil || !*schema.XPreserveUnknownFields) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(key), "unknown field"))
+		}
+	}
+	return allErrs
+}
+
+func validateEnum(enum []apiextv1.JSON, value interface{}, fldPath *field.Path) field.ErrorList {
+	valid := make([]string, 0, len(enum))
+	for _, e := range enum {
+		var allowed interface{}
+		if err := json.Unmarshal(e.Raw, &allowed); err != nil {
+			continue
+		}
+		if reflect.DeepEqual(allowed, value) {
+			return nil
+		}
+		if s, ok := allowed.(string); ok {
+			valid = append(valid, s)
+		} else {
+			valid = append(valid, string(e.Raw))
+		}
+	}
+	return field.ErrorList{field.NotSupported(fldPath, value, valid)}
+}
@@ -0,0 +1,68 @@
+package installconfig
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/secret"
+)
+
+// resolveSecrets reads the secrets that the install-config refers to into
+// the fields that the references replace.
+func resolveSecrets(config *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	resolve := func(value *string, ref *secret.Reference, fldPath *field.Path) {
+		if ref == nil {
+			return
+		}
+		if *value != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "must not be set together with the value that it refers to"))
+			return
+		}
+		s, err := secret.Resolve(ref)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, *ref, err.Error()))
+			return
+		}
+		*value = s
+	}
+
+	platformPath := field.NewPath("platform")
+	if p := config.Platform.VSphere; p != nil {
+		resolve(&p.Password, p.PasswordRef, platformPath.Child("vsphere", "passwordRef"))
+	}
+	if p := config.Platform.BareMetal; p != nil {
+		for i, host := range p.Hosts {
+			resolve(&host.BMC.Password, host.BMC.PasswordRef, platformPath.Child("baremetal", "hosts").Index(i).Child("bmc", "passwordRef"))
+		}
+	}
+
+	return allErrs
+}
+
+// RedactSecretReferences returns a copy of config without the secrets that
+// it refers to, so that it can be saved the way the user wrote it.
+func RedactSecretReferences(config *types.InstallConfig) *types.InstallConfig {
+	c := *config
+	if p := c.Platform.VSphere; p != nil && p.PasswordRef != nil {
+		vsphere := *p
+		vsphere.Password = ""
+		c.Platform.VSphere = &vsphere
+	}
+	if p := c.Platform.BareMetal; p != nil {
+		bm := *p
+		bm.Hosts = make([]*baremetal.Host, len(p.Hosts))
+		for i, host := range p.Hosts {
+			if host.BMC.PasswordRef != nil {
+				h := *host
+				h.BMC.Password = ""
+				host = &h
+			}
+			bm.Hosts[i] = host
+		}
+		c.Platform.BareMetal = &bm
+	}
+	return &c
+}
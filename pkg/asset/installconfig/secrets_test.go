@@ -0,0 +1,88 @@
+package installconfig
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/types/secret"
+	"github.com/openshift/installer/pkg/types/vsphere"
+)
+
+func secretReferencesConfig() *types.InstallConfig {
+	return &types.InstallConfig{
+		Platform: types.Platform{
+			BareMetal: &baremetal.Platform{
+				Hosts: []*baremetal.Host{
+					{Name: "host0", BMC: baremetal.BMC{Username: "admin", PasswordRef: &secret.Reference{Env: "INSTALLCONFIG_TEST_BMC_PASSWORD"}}},
+					{Name: "host1", BMC: baremetal.BMC{Username: "admin", Password: "inline-password"}},
+				},
+			},
+		},
+	}
+}
+
+func TestResolveSecrets(t *testing.T) {
+	os.Setenv("INSTALLCONFIG_TEST_BMC_PASSWORD", "bmc-password")
+	defer os.Unsetenv("INSTALLCONFIG_TEST_BMC_PASSWORD")
+
+	config := secretReferencesConfig()
+	assert.Empty(t, resolveSecrets(config))
+	assert.Equal(t, "bmc-password", config.BareMetal.Hosts[0].BMC.Password)
+	assert.Equal(t, "inline-password", config.BareMetal.Hosts[1].BMC.Password)
+
+	// Resolving again finds both the reference and its value.
+	errs := resolveSecrets(config)
+	if assert.Len(t, errs, 1) {
+		assert.EqualError(t, errs[0], "platform.baremetal.hosts[0].bmc.passwordRef: Forbidden: must not be set together with the value that it refers to")
+	}
+
+	config = &types.InstallConfig{
+		Platform: types.Platform{
+			VSphere: &vsphere.Platform{PasswordRef: &secret.Reference{Env: "INSTALLCONFIG_TEST_UNSET"}},
+		},
+	}
+	errs = resolveSecrets(config)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "platform.vsphere.passwordRef", errs[0].Field)
+		assert.Equal(t, "environment variable INSTALLCONFIG_TEST_UNSET is not set", errs[0].Detail)
+	}
+}
+
+func TestRedactSecretReferences(t *testing.T) {
+	config := secretReferencesConfig()
+	config.BareMetal.Hosts[0].BMC.Password = "bmc-password"
+
+	redacted := RedactSecretReferences(config)
+	assert.Equal(t, "", redacted.BareMetal.Hosts[0].BMC.Password)
+	assert.Equal(t, "inline-password", redacted.BareMetal.Hosts[1].BMC.Password)
+	assert.Equal(t, "bmc-password", config.BareMetal.Hosts[0].BMC.Password, "install config was unexpectedly modified")
+}
+
+func TestInstallConfigStateSecretReferences(t *testing.T) {
+	os.Setenv("INSTALLCONFIG_TEST_BMC_PASSWORD", "bmc-password")
+	defer os.Unsetenv("INSTALLCONFIG_TEST_BMC_PASSWORD")
+
+	config := secretReferencesConfig()
+	if !assert.Empty(t, resolveSecrets(config)) {
+		return
+	}
+	ic := &InstallConfig{Config: config, File: &asset.File{Filename: installConfigFilename}}
+
+	data, err := json.Marshal(ic)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotContains(t, string(data), "bmc-password")
+	assert.Contains(t, string(data), "inline-password")
+
+	loaded := &InstallConfig{}
+	if assert.NoError(t, json.Unmarshal(data, loaded)) {
+		assert.Equal(t, ic, loaded)
+	}
+}
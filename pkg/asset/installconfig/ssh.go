@@ -11,6 +11,7 @@ import (
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/validate"
 )
 
@@ -65,6 +66,17 @@ func (a *sshPublicKey) Generate(asset.Parents) error {
 		}
 	}
 
+	// Answers may name keys outside of ~/.ssh.
+	if path, ok := answers.Get("sshKey"); ok && path != noSSHKey {
+		if _, ok := pubKeys[path]; !ok {
+			key, err := readSSHKey(path)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read the SSH key %s", path)
+			}
+			pubKeys[path] = key
+		}
+	}
+
 	if len(pubKeys) == 1 {
 		for _, value := range pubKeys {
 			a.Key = value
@@ -79,7 +91,7 @@ func (a *sshPublicKey) Generate(asset.Parents) error {
 	sort.Strings(paths)
 
 	var path string
-	if err := survey.AskOne(&survey.Select{
+	if err := answers.AskOne("sshKey", &survey.Select{
 		Message: "SSH Public Key",
 		Help:    "The SSH public key used to access all nodes within the cluster. This is optional.",
 		Options: paths,
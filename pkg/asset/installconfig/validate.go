@@ -0,0 +1,84 @@
+package installconfig
+
+import (
+	"encoding/json"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/conversion"
+	"github.com/openshift/installer/pkg/types/defaults"
+	"github.com/openshift/installer/pkg/types/validation"
+)
+
+// ValidateOffline validates the install-config in data against schema, the
+// schema of the InstallConfig CRD, and then upconverts it, sets its defaults
+// and validates it the way the InstallConfig asset does when it is loaded.
+// None of the platform's APIs are called; see ValidatePlatform.
+// The first return is the install-config with its defaults set, or nil if it
+// could not be loaded. The error is only set if data is not YAML.
+func ValidateOffline(data []byte, schema *apiextv1.JSONSchemaProps) (*types.InstallConfig, field.ErrorList, error) {
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse install-config")
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse install-config")
+	}
+	allErrs := validateSchema(schema, value, nil)
+
+	config := &types.InstallConfig{}
+	if err := json.Unmarshal(raw, config); err != nil {
+		// The schema errors explain why the install-config could not be
+		// unmarshaled, if it does not match the schema.
+		if len(allErrs) == 0 {
+			allErrs = append(allErrs, field.Invalid(nil, nil, err.Error()))
+		}
+		return nil, redactSecrets(allErrs), nil
+	}
+
+	if err := conversion.ConvertInstallConfig(config); err != nil {
+		if fieldErr, ok := err.(*field.Error); ok {
+			return nil, redactSecrets(append(allErrs, fieldErr)), nil
+		}
+		return nil, redactSecrets(append(allErrs, field.InternalError(nil, err))), nil
+	}
+
+	allErrs = append(allErrs, resolveSecrets(config)...)
+
+	defaults.SetInstallConfigDefaults(config)
+
+	// Only report the fields that did not match the schema once.
+	invalid := map[string]bool{}
+	for _, err := range allErrs {
+		invalid[err.Field] = true
+	}
+	for _, err := range validation.ValidateInstallConfig(config) {
+		if !invalid[err.Field] {
+			allErrs = append(allErrs, err)
+		}
+	}
+	return config, redactSecrets(allErrs), nil
+}
+
+// secretFields are the fields of the install-config whose values are secret.
+var secretFields = map[string]bool{
+	"pullSecret":                true,
+	"platform.vsphere.username": true,
+	"platform.vsphere.password": true,
+}
+
+// redactSecrets replaces the invalid values of secret fields, so that the
+// errors can be printed in logs.
+func redactSecrets(allErrs field.ErrorList) field.ErrorList {
+	for _, err := range allErrs {
+		if secretFields[err.Field] && err.BadValue != nil {
+			err.BadValue = "<redacted>"
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,166 @@
+package installconfig
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func loadSchema(t *testing.T) *apiextv1.JSONSchemaProps {
+	data, err := ioutil.ReadFile("../../../data/data/install.openshift.io_installconfigs.yaml")
+	require.NoError(t, err)
+	crd := &apiextv1.CustomResourceDefinition{}
+	require.NoError(t, yaml.Unmarshal(data, crd))
+	return crd.Spec.Versions[0].Schema.OpenAPIV3Schema
+}
+
+const validInstallConfig = `
+apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: test-cluster
+controlPlane:
+  name: master
+  replicas: 3
+  hyperthreading: Enabled
+platform:
+  aws:
+    region: us-east-1
+pullSecret: '{"auths":{"example.com":{"auth":"YXV0aA=="}}}'
+`
+
+func TestValidateOffline(t *testing.T) {
+	schema := loadSchema(t)
+
+	cases := []struct {
+		name     string
+		data     string
+		expected []string
+	}{{
+		name: "valid",
+		data: validInstallConfig,
+	}, {
+		name: "unknown field",
+		data: `
+apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: test-cluster
+platform:
+  aws:
+    regoin: us-east-1
+pullSecret: '{"auths":{"example.com":{"auth":"YXV0aA=="}}}'
+`,
+		expected: []string{
+			`platform.aws.regoin: Forbidden: unknown field`,
+			`platform.aws.region: Required value: region must be specified`,
+		},
+	}, {
+		name: "wrong type",
+		data: `
+apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: test-cluster
+controlPlane:
+  name: master
+  replicas: three
+platform:
+  aws:
+    region: us-east-1
+pullSecret: '{"auths":{"example.com":{"auth":"YXV0aA=="}}}'
+`,
+		expected: []string{
+			`controlPlane.replicas: Invalid value: "three": must be an integer`,
+		},
+	}, {
+		name: "enum",
+		data: `
+apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: test-cluster
+controlPlane:
+  name: master
+  hyperthreading: Sometimes
+platform:
+  aws:
+    region: us-east-1
+pullSecret: '{"auths":{"example.com":{"auth":"YXV0aA=="}}}'
+`,
+		expected: []string{
+			`controlPlane.hyperthreading: Unsupported value: "Sometimes": supported values: "", "Enabled", "Disabled"`,
+		},
+	}, {
+		name: "semantic",
+		data: `
+apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: test-cluster
+platform:
+  aws:
+    region: us-east-1
+pullSecret: not-json
+`,
+		expected: []string{
+			`pullSecret: Invalid value: "<redacted>": invalid character 'o' in literal null (expecting 'u')`,
+		},
+	}, {
+		name: "unresolved secret reference",
+		data: `
+apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: test-cluster
+platform:
+  vsphere:
+    vCenter: vcenter.example.com
+    username: admin
+    passwordRef:
+      env: INSTALLCONFIG_TEST_UNSET
+    datacenter: dc
+    defaultDatastore: ds
+pullSecret: '{"auths":{"example.com":{"auth":"YXV0aA=="}}}'
+`,
+		expected: []string{
+			`platform.vsphere.passwordRef: Invalid value: secret.Reference{File:"", Env:"INSTALLCONFIG_TEST_UNSET"}: environment variable INSTALLCONFIG_TEST_UNSET is not set`,
+			`platform.vsphere.password: Required value: must specify the password`,
+		},
+	}, {
+		name: "unsupported version",
+		data: `
+apiVersion: v1beta1
+metadata:
+  name: test-cluster
+`,
+		expected: []string{
+			`apiVersion: Invalid value: "v1beta1": cannot upconvert from version v1beta1`,
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, allErrs, err := ValidateOffline([]byte(tc.data), schema)
+			require.NoError(t, err)
+			var actual []string
+			for _, err := range allErrs {
+				actual = append(actual, err.Error())
+			}
+			assert.Equal(t, tc.expected, actual)
+			if tc.name == "valid" {
+				require.NotNil(t, config)
+				assert.Equal(t, "amd64", string(config.ControlPlane.Architecture), "defaults should be set")
+			}
+		})
+	}
+}
+
+func TestValidateOfflineNotYAML(t *testing.T) {
+	_, _, err := ValidateOffline([]byte("\t{"), loadSchema(t))
+	assert.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/vim25"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types"
@@ -20,7 +21,7 @@ func Validate(ic *types.InstallConfig) error {
 		return errors.New(field.Required(field.NewPath("platform", "vsphere"), "vSphere validation requires a vSphere platform configuration").Error())
 	}
 
-	allErrs = append(allErrs, validation.ValidatePlatform(ic.Platform.VSphere, field.NewPath("platform").Child("vsphere"))...)
+	allErrs = append(allErrs, validation.ValidatePlatform(ic.Platform.VSphere, ic.Networking, field.NewPath("platform").Child("vsphere"), ic)...)
 
 	return allErrs.ToAggregate()
 }
@@ -36,6 +37,7 @@ func ValidateForProvisioning(ic *types.InstallConfig) error {
 
 	allErrs = append(allErrs, validation.ValidateForProvisioning(ic.Platform.VSphere, field.NewPath("platform").Child("vsphere"))...)
 	allErrs = append(allErrs, folderExists(ic, field.NewPath("platform").Child("vsphere").Child("folder"))...)
+	allErrs = append(allErrs, failureDomainsExist(ic, field.NewPath("platform").Child("vsphere").Child("failureDomains"))...)
 
 	return allErrs.ToAggregate()
 }
@@ -66,3 +68,58 @@ func folderExists(ic *types.InstallConfig, fldPath *field.Path) field.ErrorList
 	}
 	return nil
 }
+
+// failureDomainsExist returns an error for each failure domain in the vSphere platform that refers to a
+// datacenter, cluster, datastore, network or folder that is not found in vCenter.
+func failureDomainsExist(ic *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	cfg := ic.VSphere
+
+	if len(cfg.FailureDomains) == 0 {
+		return allErrs
+	}
+
+	vim25Client, _, err := vspheretypes.CreateVSphereClients(context.TODO(), cfg.VCenter, cfg.Username, cfg.Password)
+	if err != nil {
+		err = errors.Wrap(err, "unable to connect to vCenter API")
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 60*time.Second)
+	defer cancel()
+
+	return validateFailureDomains(ctx, vim25Client, cfg.FailureDomains, fldPath)
+}
+
+func validateFailureDomains(ctx context.Context, client *vim25.Client, failureDomains []vspheretypes.FailureDomain, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for idx, fd := range failureDomains {
+		fdPath := fldPath.Index(idx)
+		finder := find.NewFinder(client)
+
+		dc, err := finder.Datacenter(ctx, fd.Datacenter)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fdPath.Child("datacenter"), fd.Datacenter, err.Error()))
+			continue
+		}
+		finder.SetDatacenter(dc)
+
+		if _, err := finder.ClusterComputeResource(ctx, fd.Cluster); err != nil {
+			allErrs = append(allErrs, field.Invalid(fdPath.Child("cluster"), fd.Cluster, err.Error()))
+		}
+		if _, err := finder.Datastore(ctx, fd.Datastore); err != nil {
+			allErrs = append(allErrs, field.Invalid(fdPath.Child("datastore"), fd.Datastore, err.Error()))
+		}
+		if _, err := finder.Network(ctx, fd.Network); err != nil {
+			allErrs = append(allErrs, field.Invalid(fdPath.Child("network"), fd.Network, err.Error()))
+		}
+		if fd.Folder != "" {
+			if _, err := finder.Folder(ctx, fd.Folder); err != nil {
+				allErrs = append(allErrs, field.Invalid(fdPath.Child("folder"), fd.Folder, err.Error()))
+			}
+		}
+	}
+
+	return allErrs
+}
@@ -1,9 +1,13 @@
 package vsphere
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/ipnet"
 	"github.com/openshift/installer/pkg/types"
@@ -103,3 +107,39 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFailureDomains(t *testing.T) {
+	simulator.Test(func(ctx context.Context, client *vim25.Client) {
+		valid := vsphere.FailureDomain{
+			Name:       "a",
+			Datacenter: "DC0",
+			Cluster:    "DC0_C0",
+			Datastore:  "LocalDS_0",
+			Network:    "VM Network",
+			Folder:     "/DC0/vm",
+		}
+		fldPath := field.NewPath("platform", "vsphere", "failureDomains")
+
+		errs := validateFailureDomains(ctx, client, []vsphere.FailureDomain{valid}, fldPath)
+		assert.Empty(t, errs)
+
+		missingDatacenter := valid
+		missingDatacenter.Datacenter = "missing"
+		errs = validateFailureDomains(ctx, client, []vsphere.FailureDomain{valid, missingDatacenter}, fldPath)
+		if assert.Len(t, errs, 1) {
+			assert.Equal(t, "platform.vsphere.failureDomains[1].datacenter", errs[0].Field)
+		}
+
+		missingResources := valid
+		missingResources.Cluster = "missing"
+		missingResources.Datastore = "missing"
+		missingResources.Network = "missing"
+		missingResources.Folder = "/DC0/vm/missing"
+		errs = validateFailureDomains(ctx, client, []vsphere.FailureDomain{missingResources}, fldPath)
+		if assert.Len(t, errs, 4) {
+			for i, name := range []string{"cluster", "datastore", "network", "folder"} {
+				assert.Equal(t, "platform.vsphere.failureDomains[0]."+name, errs[i].Field)
+			}
+		}
+	})
+}
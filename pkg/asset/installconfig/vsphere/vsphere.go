@@ -16,6 +16,7 @@ import (
 	"gopkg.in/AlecAivazis/survey.v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/answers"
 	"github.com/openshift/installer/pkg/types/vsphere"
 	vspheretypes "github.com/openshift/installer/pkg/types/vsphere"
 	"github.com/openshift/installer/pkg/validate"
@@ -98,7 +99,7 @@ func Platform() (*vsphere.Platform, error) {
 func getClients() (*vCenterClient, error) {
 	var vcenter, username, password string
 
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask("vsphere.vCenter", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "vCenter",
@@ -110,7 +111,7 @@ func getClients() (*vCenterClient, error) {
 		return nil, errors.Wrap(err, "failed UserInput")
 	}
 
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask("vsphere.username", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Username",
@@ -122,7 +123,7 @@ func getClients() (*vCenterClient, error) {
 		return nil, errors.Wrap(err, "failed UserInput")
 	}
 
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask("vsphere.password", []*survey.Question{
 		{
 			Prompt: &survey.Password{
 				Message: "Password",
@@ -133,6 +134,9 @@ func getClients() (*vCenterClient, error) {
 	}, &password); err != nil {
 		return nil, errors.Wrap(err, "failed UserInput")
 	}
+	if err := answers.Missing(); err != nil {
+		return nil, err
+	}
 
 	// There is a noticeable delay when creating the client, so let the user know what's going on.
 	logrus.Infof("Connecting to vCenter %s", vcenter)
@@ -188,7 +192,7 @@ func getDataCenter(ctx context.Context, finder *find.Finder, client *vim25.Clien
 	sort.Strings(dataCenterChoices)
 
 	var selectedDataCenter string
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask("vsphere.datacenter", []*survey.Question{
 		{
 			Prompt: &survey.Select{
 				Message: "Datacenter",
@@ -231,7 +235,7 @@ func getCluster(ctx context.Context, path string, finder *find.Finder, client *v
 	sort.Strings(clusterChoices)
 
 	var selectedcluster string
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask("vsphere.cluster", []*survey.Question{
 		{
 			Prompt: &survey.Select{
 				Message: "Cluster",
@@ -272,7 +276,7 @@ func getDataStore(ctx context.Context, path string, finder *find.Finder, client
 	sort.Strings(dataStoreChoices)
 
 	var selectedDataStore string
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask("vsphere.defaultDatastore", []*survey.Question{
 		{
 			Prompt: &survey.Select{
 				Message: "Default Datastore",
@@ -326,7 +330,7 @@ func getNetwork(ctx context.Context, path string, finder *find.Finder, client *v
 	sort.Strings(networkChoices)
 
 	var selectednetwork string
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask("vsphere.network", []*survey.Question{
 		{
 			Prompt: &survey.Select{
 				Message: "Network",
@@ -345,7 +349,7 @@ func getNetwork(ctx context.Context, path string, finder *find.Finder, client *v
 func getVIPs() (string, string, error) {
 	var apiVIP, ingressVIP string
 
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask("vsphere.apiVIP", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Virtual IP Address for API",
@@ -359,7 +363,7 @@ func getVIPs() (string, string, error) {
 		return "", "", errors.Wrap(err, "failed UserInput")
 	}
 
-	if err := survey.Ask([]*survey.Question{
+	if err := answers.Ask("vsphere.ingressVIP", []*survey.Question{
 		{
 			Prompt: &survey.Input{
 				Message: "Virtual IP Address for Ingress",
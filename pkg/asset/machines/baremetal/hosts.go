@@ -1,6 +1,7 @@
 package baremetal
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/metal3-io/baremetal-operator/pkg/hardware"
@@ -21,7 +22,8 @@ type HostSettings struct {
 	// the cluster.
 	Hosts []baremetalhost.BareMetalHost
 	// Secrets holds the credential information for communicating with
-	// the management controllers on the hosts.
+	// the management controllers on the hosts, and the static network
+	// configuration of the hosts.
 	Secrets []corev1.Secret
 }
 
@@ -83,6 +85,32 @@ func Hosts(config *types.InstallConfig, machines []machineapi.Machine) (*HostSet
 				RootDeviceHints: host.RootDeviceHints.MakeCRDHints(),
 			},
 		}
+		if host.NetworkConfig != nil {
+			// The static network configuration is written by the
+			// baremetal-operator to the config drive of the host.
+			networkData, err := json.Marshal(host.NetworkConfig.MakeNetworkData())
+			if err != nil {
+				return nil, err
+			}
+			secret := corev1.Secret{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "Secret",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-network-data-secret", host.Name),
+					Namespace: "openshift-machine-api",
+				},
+				Data: map[string][]byte{
+					"networkData": networkData,
+				},
+			}
+			newHost.Spec.NetworkData = &corev1.SecretReference{
+				Name:      secret.Name,
+				Namespace: secret.Namespace,
+			}
+			settings.Secrets = append(settings.Secrets, secret)
+		}
 		if i < len(machines) {
 			// Setting ExternallyProvisioned to true and adding a
 			// ConsumerRef without setting Image associates the host
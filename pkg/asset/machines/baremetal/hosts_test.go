@@ -251,3 +251,41 @@ func TestHosts(t *testing.T) {
 		})
 	}
 }
+
+func TestHostsNetworkConfig(t *testing.T) {
+	config := makeConfig(2)
+	config.Platform.BareMetal.Hosts[1].NetworkConfig = &baremetaltypes.NetworkConfig{
+		Interfaces: []baremetaltypes.NetworkInterface{{
+			Name: "eno1",
+			Type: baremetaltypes.EthernetInterface,
+			IPv4: &baremetaltypes.InterfaceIP{
+				Enabled: true,
+				Address: []baremetaltypes.InterfaceAddress{{IP: "192.168.111.21", PrefixLength: 24}},
+			},
+		}},
+	}
+
+	actual, err := Hosts(config, makeMachines(1))
+	if err != nil {
+		t.Fatalf("did not expect error but got %v", err)
+	}
+
+	if actual.Hosts[0].Spec.NetworkData != nil {
+		t.Errorf("Expected host0 to have no network data but has %v", actual.Hosts[0].Spec.NetworkData)
+	}
+	if actual.Hosts[1].Spec.NetworkData == nil || actual.Hosts[1].Spec.NetworkData.Name != "host1-network-data-secret" {
+		t.Fatalf("Expected host1 to reference host1-network-data-secret but got %v", actual.Hosts[1].Spec.NetworkData)
+	}
+
+	if len(actual.Secrets) != 3 {
+		t.Fatalf("Expected 3 secrets, got %d", len(actual.Secrets))
+	}
+	secret := actual.Secrets[2]
+	if secret.Name != "host1-network-data-secret" {
+		t.Errorf("Expected secret name to be %q but got %q", "host1-network-data-secret", secret.Name)
+	}
+	expected := `{"links":[{"id":"eno1","type":"phy"}],"networks":[{"id":"network0","ip_address":"192.168.111.21","link":"eno1","netmask":"255.255.255.0","routes":[],"type":"ipv4"}],"services":[]}`
+	if string(secret.Data["networkData"]) != expected {
+		t.Errorf("Expected network data %s but got %s", expected, secret.Data["networkData"])
+	}
+}
@@ -2,6 +2,7 @@ package machines
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -443,6 +444,25 @@ func (m *Master) Files() []*asset.File {
 	return files
 }
 
+// MarshalJSON leaves out the secrets read from secret references, such as the
+// BMC passwords in the host secrets, so that they are not saved in the state
+// file.
+func (m *Master) MarshalJSON() ([]byte, error) {
+	type master Master
+	c := master(*m)
+	c.SecretFiles = asset.RedactSecrets(m.SecretFiles)
+	return json.Marshal(&c)
+}
+
+// UnmarshalJSON reads the secrets left out by MarshalJSON again.
+func (m *Master) UnmarshalJSON(data []byte) error {
+	type master Master
+	if err := json.Unmarshal(data, (*master)(m)); err != nil {
+		return err
+	}
+	return asset.RestoreSecrets(m.SecretFiles)
+}
+
 // Load reads the asset files from disk.
 func (m *Master) Load(f asset.FileFetcher) (found bool, err error) {
 	file, err := f.FetchByName(filepath.Join(directory, masterUserDataFileName))
@@ -546,8 +566,13 @@ func IsMachineManifest(file *asset.File) bool {
 	} else if matched {
 		return true
 	}
-	if matched, err := filepath.Match(workerMachineSetFileNamePattern, filename); err != nil {
+	if matched, err := filepath.Match(workerMachineFileNamePattern, filename); err != nil {
 		panic("bad format for worker machine file name pattern")
+	} else if matched {
+		return true
+	}
+	if matched, err := filepath.Match(workerMachineSetFileNamePattern, filename); err != nil {
+		panic("bad format for worker machine set file name pattern")
 	} else {
 		return matched
 	}
@@ -1,6 +1,9 @@
 package machines
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -13,6 +16,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/rhcos"
 	"github.com/openshift/installer/pkg/types"
 	awstypes "github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/secret"
 )
 
 func TestMasterGenerateMachineConfigs(t *testing.T) {
@@ -191,3 +195,29 @@ spec:
 		})
 	}
 }
+
+func TestMasterSecretsNotSaved(t *testing.T) {
+	os.Setenv("MASTER_TEST_BMC_PASSWORD", "bmc-password")
+	defer os.Unsetenv("MASTER_TEST_BMC_PASSWORD")
+	password, err := secret.Resolve(&secret.Reference{Env: "MASTER_TEST_BMC_PASSWORD"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	data := "password: " + base64.StdEncoding.EncodeToString([]byte(password)) + "\n"
+	master := &Master{
+		UserDataFile: &asset.File{Filename: "user-data", Data: []byte("user-data")},
+		SecretFiles:  []*asset.File{{Filename: "secret-0", Data: []byte(data)}},
+	}
+	state, err := json.Marshal(master)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotContains(t, string(state), base64.StdEncoding.EncodeToString([]byte(data)))
+	assert.Equal(t, data, string(master.SecretFiles[0].Data), "the asset itself keeps the secrets")
+
+	loaded := &Master{}
+	if assert.NoError(t, json.Unmarshal(state, loaded)) {
+		assert.Equal(t, master, loaded)
+	}
+}
@@ -26,13 +26,19 @@ func Machines(clusterID string, config *types.InstallConfig, pool *types.Machine
 	platform := config.Platform.VSphere
 	mpool := pool.Platform.VSphere
 
+	domains, err := failureDomains(platform, mpool)
+	if err != nil {
+		return nil, err
+	}
+
 	total := int64(1)
 	if pool.Replicas != nil {
 		total = *pool.Replicas
 	}
 	var machines []machineapi.Machine
 	for idx := int64(0); idx < total; idx++ {
-		provider, err := provider(clusterID, platform, mpool, osImage, userDataSecret)
+		domain := domains[int(idx)%len(domains)]
+		provider, err := provider(clusterID, platform, domain, mpool, osImage, userDataSecret)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create provider")
 		}
@@ -63,10 +69,46 @@ func Machines(clusterID string, config *types.InstallConfig, pool *types.Machine
 	return machines, nil
 }
 
-func provider(clusterID string, platform *vsphere.Platform, mpool *vsphere.MachinePool, osImage string, userDataSecret string) (*vsphereapis.VSphereMachineProviderSpec, error) {
-	folder := fmt.Sprintf("/%s/vm/%s", platform.Datacenter, clusterID)
-	resourcePool := fmt.Sprintf("/%s/host/%s/Resources", platform.Datacenter, platform.Cluster)
-	if platform.Folder != "" {
+// failureDomains returns the failure domains that the machines of the pool
+// are spread across. Without failure domains in the platform, it returns a
+// single unnamed failure domain with the location of the platform.
+func failureDomains(platform *vsphere.Platform, mpool *vsphere.MachinePool) ([]vsphere.FailureDomain, error) {
+	if len(platform.FailureDomains) == 0 {
+		return []vsphere.FailureDomain{{
+			Datacenter: platform.Datacenter,
+			Cluster:    platform.Cluster,
+			Datastore:  platform.DefaultDatastore,
+			Network:    platform.Network,
+		}}, nil
+	}
+
+	if len(mpool.Zones) == 0 {
+		return platform.FailureDomains, nil
+	}
+
+	var domains []vsphere.FailureDomain
+	for _, zone := range mpool.Zones {
+		found := false
+		for _, fd := range platform.FailureDomains {
+			if fd.Name == zone {
+				domains = append(domains, fd)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no failure domain for zone %s", zone)
+		}
+	}
+	return domains, nil
+}
+
+func provider(clusterID string, platform *vsphere.Platform, domain vsphere.FailureDomain, mpool *vsphere.MachinePool, osImage string, userDataSecret string) (*vsphereapis.VSphereMachineProviderSpec, error) {
+	folder := fmt.Sprintf("/%s/vm/%s", domain.Datacenter, clusterID)
+	resourcePool := fmt.Sprintf("/%s/host/%s/Resources", domain.Datacenter, domain.Cluster)
+	if domain.Folder != "" {
+		folder = domain.Folder
+	} else if platform.Folder != "" {
 		folder = platform.Folder
 	}
 
@@ -81,14 +123,14 @@ func provider(clusterID string, platform *vsphere.Platform, mpool *vsphere.Machi
 		Network: vsphereapis.NetworkSpec{
 			Devices: []vsphereapis.NetworkDeviceSpec{
 				{
-					NetworkName: platform.Network,
+					NetworkName: domain.Network,
 				},
 			},
 		},
 		Workspace: &vsphereapis.Workspace{
 			Server:       platform.VCenter,
-			Datacenter:   platform.Datacenter,
-			Datastore:    platform.DefaultDatastore,
+			Datacenter:   domain.Datacenter,
+			Datastore:    domain.Datastore,
 			Folder:       folder,
 			ResourcePool: resourcePool,
 		},
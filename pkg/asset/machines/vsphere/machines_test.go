@@ -0,0 +1,93 @@
+package vsphere
+
+import (
+	"testing"
+
+	vsphereapis "github.com/openshift/machine-api-operator/pkg/apis/vsphereprovider/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/vsphere"
+)
+
+func testInstallConfig() *types.InstallConfig {
+	return &types.InstallConfig{
+		Platform: types.Platform{
+			VSphere: &vsphere.Platform{
+				VCenter:          "vcenter",
+				Datacenter:       "dc",
+				Cluster:          "cluster",
+				DefaultDatastore: "datastore",
+				Network:          "network",
+				FailureDomains: []vsphere.FailureDomain{
+					{Name: "a", Datacenter: "dc", Cluster: "cluster-a", Datastore: "datastore-a", Network: "network-a"},
+					{Name: "b", Datacenter: "dc-b", Cluster: "cluster-b", Datastore: "datastore-b", Network: "network-b", Folder: "/dc-b/vm/folder"},
+				},
+			},
+		},
+	}
+}
+
+func testMachinePool(replicas int64, zones ...string) *types.MachinePool {
+	return &types.MachinePool{
+		Name:     "worker",
+		Replicas: pointer.Int64Ptr(replicas),
+		Platform: types.MachinePoolPlatform{
+			VSphere: &vsphere.MachinePool{Zones: zones},
+		},
+	}
+}
+
+func TestMachinesFailureDomains(t *testing.T) {
+	machines, err := Machines("cluster-id", testInstallConfig(), testMachinePool(3), "template", "master", "master-user-data")
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, machines, 3) {
+		return
+	}
+
+	expected := []struct {
+		datacenter, datastore, folder, resourcePool, network string
+	}{
+		{"dc", "datastore-a", "/dc/vm/cluster-id", "/dc/host/cluster-a/Resources", "network-a"},
+		{"dc-b", "datastore-b", "/dc-b/vm/folder", "/dc-b/host/cluster-b/Resources", "network-b"},
+		{"dc", "datastore-a", "/dc/vm/cluster-id", "/dc/host/cluster-a/Resources", "network-a"},
+	}
+	for i, machine := range machines {
+		spec := machine.Spec.ProviderSpec.Value.Object.(*vsphereapis.VSphereMachineProviderSpec)
+		assert.Equal(t, expected[i].datacenter, spec.Workspace.Datacenter)
+		assert.Equal(t, expected[i].datastore, spec.Workspace.Datastore)
+		assert.Equal(t, expected[i].folder, spec.Workspace.Folder)
+		assert.Equal(t, expected[i].resourcePool, spec.Workspace.ResourcePool)
+		assert.Equal(t, expected[i].network, spec.Network.Devices[0].NetworkName)
+	}
+}
+
+func TestMachineSetsFailureDomains(t *testing.T) {
+	machinesets, err := MachineSets("cluster-id", testInstallConfig(), testMachinePool(3), "template", "worker", "worker-user-data")
+	if assert.NoError(t, err) && assert.Len(t, machinesets, 2) {
+		assert.Equal(t, "cluster-id-worker-a", machinesets[0].Name)
+		assert.Equal(t, int32(2), *machinesets[0].Spec.Replicas)
+		assert.Equal(t, "cluster-id-worker-b", machinesets[1].Name)
+		assert.Equal(t, int32(1), *machinesets[1].Spec.Replicas)
+	}
+
+	machinesets, err = MachineSets("cluster-id", testInstallConfig(), testMachinePool(3, "b"), "template", "worker", "worker-user-data")
+	if assert.NoError(t, err) && assert.Len(t, machinesets, 1) {
+		assert.Equal(t, "cluster-id-worker-b", machinesets[0].Name)
+		assert.Equal(t, int32(3), *machinesets[0].Spec.Replicas)
+	}
+
+	_, err = MachineSets("cluster-id", testInstallConfig(), testMachinePool(3, "c"), "template", "worker", "worker-user-data")
+	assert.EqualError(t, err, "no failure domain for zone c")
+
+	config := testInstallConfig()
+	config.Platform.VSphere.FailureDomains = nil
+	machinesets, err = MachineSets("cluster-id", config, testMachinePool(3), "template", "worker", "worker-user-data")
+	if assert.NoError(t, err) && assert.Len(t, machinesets, 1) {
+		assert.Equal(t, "cluster-id-worker", machinesets[0].Name)
+		assert.Equal(t, int32(3), *machinesets[0].Spec.Replicas)
+	}
+}
@@ -25,56 +25,72 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 	platform := config.Platform.VSphere
 	mpool := pool.Platform.VSphere
 
-	total := int32(0)
+	domains, err := failureDomains(platform, mpool)
+	if err != nil {
+		return nil, err
+	}
+
+	total := int64(0)
 	if pool.Replicas != nil {
-		total = int32(*pool.Replicas)
+		total = *pool.Replicas
 	}
+	numOfDomains := int64(len(domains))
 	var machinesets []*machineapi.MachineSet
-	provider, err := provider(clusterID, platform, mpool, osImage, userDataSecret)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create provider")
-	}
+	for idx, domain := range domains {
+		replicas := int32(total / numOfDomains)
+		if int64(idx) < total%numOfDomains {
+			replicas++
+		}
+
+		provider, err := provider(clusterID, platform, domain, mpool, osImage, userDataSecret)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create provider")
+		}
 
-	name := fmt.Sprintf("%s-%s", clusterID, pool.Name)
-	mset := &machineapi.MachineSet{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "machine.openshift.io/v1beta1",
-			Kind:       "MachineSet",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: "openshift-machine-api",
-			Name:      name,
-			Labels: map[string]string{
-				"machine.openshift.io/cluster-api-cluster": clusterID,
+		name := fmt.Sprintf("%s-%s", clusterID, pool.Name)
+		if domain.Name != "" {
+			name = fmt.Sprintf("%s-%s", name, domain.Name)
+		}
+		mset := &machineapi.MachineSet{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "machine.openshift.io/v1beta1",
+				Kind:       "MachineSet",
 			},
-		},
-		Spec: machineapi.MachineSetSpec{
-			Replicas: &total,
-			Selector: metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"machine.openshift.io/cluster-api-machineset": name,
-					"machine.openshift.io/cluster-api-cluster":    clusterID,
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "openshift-machine-api",
+				Name:      name,
+				Labels: map[string]string{
+					"machine.openshift.io/cluster-api-cluster": clusterID,
 				},
 			},
-			Template: machineapi.MachineTemplateSpec{
-				ObjectMeta: machineapi.ObjectMeta{
-					Labels: map[string]string{
-						"machine.openshift.io/cluster-api-machineset":   name,
-						"machine.openshift.io/cluster-api-cluster":      clusterID,
-						"machine.openshift.io/cluster-api-machine-role": role,
-						"machine.openshift.io/cluster-api-machine-type": role,
+			Spec: machineapi.MachineSetSpec{
+				Replicas: &replicas,
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"machine.openshift.io/cluster-api-machineset": name,
+						"machine.openshift.io/cluster-api-cluster":    clusterID,
 					},
 				},
-				Spec: machineapi.MachineSpec{
-					ProviderSpec: machineapi.ProviderSpec{
-						Value: &runtime.RawExtension{Object: provider},
+				Template: machineapi.MachineTemplateSpec{
+					ObjectMeta: machineapi.ObjectMeta{
+						Labels: map[string]string{
+							"machine.openshift.io/cluster-api-machineset":   name,
+							"machine.openshift.io/cluster-api-cluster":      clusterID,
+							"machine.openshift.io/cluster-api-machine-role": role,
+							"machine.openshift.io/cluster-api-machine-type": role,
+						},
+					},
+					Spec: machineapi.MachineSpec{
+						ProviderSpec: machineapi.ProviderSpec{
+							Value: &runtime.RawExtension{Object: provider},
+						},
+						// we don't need to set Versions, because we control those via cluster operators.
 					},
-					// we don't need to set Versions, because we control those via cluster operators.
 				},
 			},
-		},
+		}
+		machinesets = append(machinesets, mset)
 	}
-	machinesets = append(machinesets, mset)
 
 	return machinesets, nil
 }
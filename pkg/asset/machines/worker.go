@@ -68,12 +68,17 @@ const (
 	// workerMachineSetFileName is the format string for constructing the worker MachineSet filenames.
 	workerMachineSetFileName = "99_openshift-cluster-api_worker-machineset-%s.yaml"
 
+	// workerMachineFileName is the format string for constructing the
+	// filenames of the worker Machines that the installer creates itself.
+	workerMachineFileName = "99_openshift-cluster-api_worker-machines-%s.yaml"
+
 	// workerUserDataFileName is the filename used for the worker user-data secret.
 	workerUserDataFileName = "99_openshift-cluster-api_worker-user-data-secret.yaml"
 )
 
 var (
 	workerMachineSetFileNamePattern = fmt.Sprintf(workerMachineSetFileName, "*")
+	workerMachineFileNamePattern    = fmt.Sprintf(workerMachineFileName, "*")
 
 	_ asset.WritableAsset = (*Worker)(nil)
 )
@@ -167,6 +172,11 @@ type Worker struct {
 	UserDataFile       *asset.File
 	MachineConfigFiles []*asset.File
 	MachineSetFiles    []*asset.File
+
+	// MachineFiles are the worker Machines, for the platforms on which the
+	// installer creates the compute machines itself, like the control plane
+	// machines, instead of leaving them to MachineSets.
+	MachineFiles []*asset.File
 }
 
 // Name returns a human friendly name for the Worker Asset.
@@ -200,6 +210,7 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 
 	machineConfigs := []*mcfgv1.MachineConfig{}
 	machineSets := []runtime.Object{}
+	machines := []machineapi.Machine{}
 	var err error
 	ic := installConfig.Config
 	for _, pool := range ic.Compute {
@@ -378,6 +389,17 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			pool.Platform.VSphere = &mpool
 			templateName := clusterID.InfraID + "-rhcos"
 
+			// The machine API cannot give static IP addresses to the
+			// machines that it creates, so the installer creates them.
+			if ic.Platform.VSphere.StaticIPs != nil {
+				poolMachines, err := vsphere.Machines(clusterID.InfraID, ic, &pool, templateName, "worker", "worker-user-data")
+				if err != nil {
+					return errors.Wrap(err, "failed to create worker machine objects")
+				}
+				machines = append(machines, poolMachines...)
+				continue
+			}
+
 			sets, err := vsphere.MachineSets(clusterID.InfraID, ic, &pool, templateName, "worker", "worker-user-data")
 			if err != nil {
 				return errors.Wrap(err, "failed to create worker machine objects")
@@ -449,17 +471,33 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			Data:     data,
 		}
 	}
+
+	w.MachineFiles = make([]*asset.File, len(machines))
+	padFormat = fmt.Sprintf("%%0%dd", len(fmt.Sprintf("%d", len(machines))))
+	for i, machine := range machines {
+		data, err := yaml.Marshal(machine)
+		if err != nil {
+			return errors.Wrapf(err, "marshal worker machine %d", i)
+		}
+
+		padded := fmt.Sprintf(padFormat, i)
+		w.MachineFiles[i] = &asset.File{
+			Filename: filepath.Join(directory, fmt.Sprintf(workerMachineFileName, padded)),
+			Data:     data,
+		}
+	}
 	return nil
 }
 
 // Files returns the files generated by the asset.
 func (w *Worker) Files() []*asset.File {
-	files := make([]*asset.File, 0, 1+len(w.MachineConfigFiles)+len(w.MachineSetFiles))
+	files := make([]*asset.File, 0, 1+len(w.MachineConfigFiles)+len(w.MachineSetFiles)+len(w.MachineFiles))
 	if w.UserDataFile != nil {
 		files = append(files, w.UserDataFile)
 	}
 	files = append(files, w.MachineConfigFiles...)
 	files = append(files, w.MachineSetFiles...)
+	files = append(files, w.MachineFiles...)
 	return files
 }
 
@@ -485,6 +523,13 @@ func (w *Worker) Load(f asset.FileFetcher) (found bool, err error) {
 	}
 
 	w.MachineSetFiles = fileList
+
+	fileList, err = f.FetchByPattern(filepath.Join(directory, workerMachineFileNamePattern))
+	if err != nil {
+		return true, err
+	}
+
+	w.MachineFiles = fileList
 	return true, nil
 }
 
@@ -531,3 +576,31 @@ func (w *Worker) MachineSets() ([]machineapi.MachineSet, error) {
 
 	return machineSets, nil
 }
+
+// Machines returns the worker Machine manifest structures.
+func (w *Worker) Machines() ([]machineapi.Machine, error) {
+	scheme := runtime.NewScheme()
+	vsphereproviderapi.AddToScheme(scheme)
+	decoder := serializer.NewCodecFactory(scheme).UniversalDecoder(
+		vsphereprovider.SchemeGroupVersion,
+	)
+
+	machines := []machineapi.Machine{}
+	for i, file := range w.MachineFiles {
+		machine := &machineapi.Machine{}
+		err := yaml.Unmarshal(file.Data, &machine)
+		if err != nil {
+			return machines, errors.Wrapf(err, "unmarshal worker machine %d", i)
+		}
+
+		obj, _, err := decoder.Decode(machine.Spec.ProviderSpec.Value.Raw, nil, nil)
+		if err != nil {
+			return machines, errors.Wrapf(err, "unmarshal worker machine %d", i)
+		}
+
+		machine.Spec.ProviderSpec.Value = &runtime.RawExtension{Object: obj}
+		machines = append(machines, *machine)
+	}
+
+	return machines, nil
+}
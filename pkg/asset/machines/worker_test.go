@@ -13,6 +13,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/rhcos"
 	"github.com/openshift/installer/pkg/types"
 	awstypes "github.com/openshift/installer/pkg/types/aws"
+	vspheretypes "github.com/openshift/installer/pkg/types/vsphere"
 )
 
 func TestWorkerGenerate(t *testing.T) {
@@ -193,3 +194,63 @@ spec:
 		})
 	}
 }
+
+func TestWorkerGenerateVSphereStaticIPs(t *testing.T) {
+	parents := asset.Parents{}
+	parents.Add(
+		&installconfig.ClusterID{
+			UUID:    "test-uuid",
+			InfraID: "test-infra-id",
+		},
+		&installconfig.InstallConfig{
+			Config: &types.InstallConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster",
+				},
+				BaseDomain: "test-domain",
+				Platform: types.Platform{
+					VSphere: &vspheretypes.Platform{
+						VCenter:          "test-vcenter",
+						Datacenter:       "test-datacenter",
+						DefaultDatastore: "test-datastore",
+						Cluster:          "test-cluster",
+						Network:          "test-network",
+						StaticIPs: &vspheretypes.StaticIPConfig{
+							Gateway:      "192.168.111.1",
+							Netmask:      "255.255.255.0",
+							Bootstrap:    "192.168.111.10",
+							ControlPlane: vspheretypes.IPPool{Start: "192.168.111.20", End: "192.168.111.22"},
+							Compute:      vspheretypes.IPPool{Start: "192.168.111.30", End: "192.168.111.31"},
+						},
+					},
+				},
+				Compute: []types.MachinePool{
+					{
+						Name:     "worker",
+						Replicas: pointer.Int64Ptr(2),
+					},
+				},
+			},
+		},
+		(*rhcos.Image)(pointer.StringPtr("test-image")),
+		&machine.Worker{
+			File: &asset.File{
+				Filename: "worker-ignition",
+				Data:     []byte("test-ignition"),
+			},
+		},
+	)
+	worker := &Worker{}
+	if err := worker.Generate(parents); err != nil {
+		t.Fatalf("failed to generate worker machines: %v", err)
+	}
+	assert.Empty(t, worker.MachineSetFiles, "the installer creates the machines itself")
+	machines, err := worker.Machines()
+	if assert.NoError(t, err) && assert.Len(t, machines, 2) {
+		assert.Equal(t, "test-infra-id-worker-0", machines[0].Name)
+		assert.Equal(t, "test-infra-id-worker-1", machines[1].Name)
+	}
+	for _, file := range worker.MachineFiles {
+		assert.True(t, IsMachineManifest(file), "%s is not a machine manifest", file.Filename)
+	}
+}
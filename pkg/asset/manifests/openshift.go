@@ -3,6 +3,7 @@ package manifests
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"path/filepath"
 	"strconv"
 
@@ -263,6 +264,23 @@ func (o *Openshift) Files() []*asset.File {
 	return o.FileList
 }
 
+// MarshalJSON leaves out the secrets read from secret references, such as the
+// password in the cloud credentials, so that they are not saved in the state
+// file.
+func (o *Openshift) MarshalJSON() ([]byte, error) {
+	type openshift Openshift
+	return json.Marshal(&openshift{FileList: asset.RedactSecrets(o.FileList)})
+}
+
+// UnmarshalJSON reads the secrets left out by MarshalJSON again.
+func (o *Openshift) UnmarshalJSON(data []byte) error {
+	type openshift Openshift
+	if err := json.Unmarshal(data, (*openshift)(o)); err != nil {
+		return err
+	}
+	return asset.RestoreSecrets(o.FileList)
+}
+
 // Load returns the openshift asset from disk.
 func (o *Openshift) Load(f asset.FileFetcher) (bool, error) {
 	yamlFileList, err := f.FetchByPattern(filepath.Join(openshiftManifestDir, "*.yaml"))
@@ -86,7 +86,6 @@ platform:
   vsphere:
     datacenter: test-datacenter
     defaultDatastore: test-datastore
-    password: ""
     username: ""
     vCenter: test-server-1
 pullSecret: ""
@@ -3,6 +3,7 @@ package vsphere
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	vspheretypes "github.com/openshift/installer/pkg/types/vsphere"
 )
@@ -33,7 +34,20 @@ func CloudProviderConfig(folderPath string, p *vspheretypes.Platform) (string, e
 	fmt.Fprintln(buf, "")
 
 	fmt.Fprintf(buf, "[VirtualCenter %q]\n", p.VCenter)
-	printIfNotEmpty(buf, "datacenters", p.Datacenter)
+	datacenters := []string{p.Datacenter}
+	for _, fd := range p.FailureDomains {
+		found := false
+		for _, dc := range datacenters {
+			if dc == fd.Datacenter {
+				found = true
+				break
+			}
+		}
+		if !found {
+			datacenters = append(datacenters, fd.Datacenter)
+		}
+	}
+	printIfNotEmpty(buf, "datacenters", strings.Join(datacenters, ","))
 
 	return buf.String(), nil
 }
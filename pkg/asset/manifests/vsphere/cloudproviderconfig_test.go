@@ -36,3 +36,20 @@ datacenters = "test-datacenter"
 	assert.NoError(t, err, "failed to create cloud provider config")
 	assert.Equal(t, expectedConfig, actualConfig, "unexpected cloud provider config")
 }
+
+func TestCloudProviderConfigFailureDomains(t *testing.T) {
+	platform := &vspheretypes.Platform{
+		VCenter:          "test-name",
+		Datacenter:       "test-datacenter",
+		DefaultDatastore: "test-datastore",
+		FailureDomains: []vspheretypes.FailureDomain{
+			{Name: "a", Datacenter: "test-datacenter"},
+			{Name: "b", Datacenter: "other-datacenter"},
+			{Name: "c", Datacenter: "other-datacenter"},
+		},
+	}
+	folderPath := fmt.Sprintf("/%s/vm/%s", "test-datacenter", "clusterID")
+	actualConfig, err := CloudProviderConfig(folderPath, platform)
+	assert.NoError(t, err, "failed to create cloud provider config")
+	assert.Contains(t, actualConfig, `datacenters = "test-datacenter,other-datacenter"`)
+}
@@ -0,0 +1,163 @@
+package azure
+
+import (
+	"sort"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+	azureprovider "sigs.k8s.io/cluster-api-provider-azure/pkg/apis/azureprovider/v1beta1"
+
+	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+	typesazure "github.com/openshift/installer/pkg/types/azure"
+)
+
+// Constraints returns a list of quota constraints based on the InstallConfig.
+// These constraints can be used to check if there is enough quota for creating a cluster
+// for the install config.
+func Constraints(config *types.InstallConfig, controlPlanes []machineapi.Machine, computes []machineapi.MachineSet, instanceTypes map[string]InstanceTypeInfo) []quota.Constraint {
+	ctrplConfigs := make([]*azureprovider.AzureMachineProviderSpec, len(controlPlanes))
+	for i, m := range controlPlanes {
+		ctrplConfigs[i] = m.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
+	}
+	computeReplicas := make([]int64, len(computes))
+	computeConfigs := make([]*azureprovider.AzureMachineProviderSpec, len(computes))
+	for i, w := range computes {
+		computeReplicas[i] = int64(*w.Spec.Replicas)
+		computeConfigs[i] = w.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
+	}
+
+	var ret []quota.Constraint
+	for _, gen := range []constraintGenerator{
+		network(config),
+		controlPlane(config, ctrplConfigs, instanceTypes),
+		compute(config, computeReplicas, computeConfigs, instanceTypes),
+	} {
+		ret = append(ret, gen()...)
+	}
+	return aggregate(ret)
+}
+
+func aggregate(quotas []quota.Constraint) []quota.Constraint {
+	if len(quotas) == 0 {
+		return quotas
+	}
+
+	sort.SliceStable(quotas, func(i, j int) bool {
+		return quotas[i].Name < quotas[j].Name
+	})
+
+	i := 0
+	for j := 1; j < len(quotas); j++ {
+		if quotas[i].Name == quotas[j].Name && quotas[i].Region == quotas[j].Region {
+			quotas[i].Count += quotas[j].Count
+		} else {
+			i++
+			if i != j {
+				quotas[i] = quotas[j]
+			}
+		}
+	}
+	return quotas[:i+1]
+}
+
+// constraintGenerator generates a list of constraints.
+type constraintGenerator func() []quota.Constraint
+
+func network(config *types.InstallConfig) func() []quota.Constraint {
+	return func() []quota.Constraint {
+		var useIPv4, useIPv6 bool
+		for _, network := range config.Networking.ServiceNetwork {
+			if network.IP.To4() != nil {
+				useIPv4 = true
+			} else {
+				useIPv6 = true
+			}
+		}
+		private := config.Publish == types.InternalPublishingStrategy
+		udr := config.Platform.Azure.OutboundType == typesazure.UserDefinedRoutingOutboundType
+
+		// The public load balancer always gets an IPv4 address (and an
+		// IPv6 one when dual-stack) unless the cluster is private and
+		// egress uses user-defined routing. The bootstrap machine gets its
+		// own addresses when the cluster is public.
+		var publicIPs int64
+		if !private || !udr {
+			publicIPs++
+			if useIPv6 {
+				publicIPs++
+			}
+		}
+		if !private {
+			if useIPv4 {
+				publicIPs++
+			}
+			if useIPv6 {
+				publicIPs++
+			}
+		}
+
+		ret := []quota.Constraint{{
+			Name:   "network/LoadBalancers", // public and internal
+			Region: config.Platform.Azure.Region,
+			Count:  2,
+		}}
+		if publicIPs > 0 {
+			ret = append(ret, []quota.Constraint{{
+				Name:   "network/PublicIPAddresses",
+				Region: config.Platform.Azure.Region,
+				Count:  publicIPs,
+			}, {
+				Name:   "network/StandardSkuPublicIpAddresses",
+				Region: config.Platform.Azure.Region,
+				Count:  publicIPs,
+			}}...)
+		}
+		return ret
+	}
+}
+
+func controlPlane(config *types.InstallConfig, machines []*azureprovider.AzureMachineProviderSpec, instanceTypes map[string]InstanceTypeInfo) func() []quota.Constraint {
+	return func() []quota.Constraint {
+		var ret []quota.Constraint
+		for _, m := range machines {
+			ret = append(ret, machineTypeToQuota(config, m.VMSize, 1, instanceTypes)...)
+		}
+		// The bootstrap machine has the size of the control plane machines.
+		if len(machines) > 0 {
+			ret = append(ret, machineTypeToQuota(config, machines[0].VMSize, 1, instanceTypes)...)
+		}
+		return ret
+	}
+}
+
+func compute(config *types.InstallConfig, replicas []int64, machines []*azureprovider.AzureMachineProviderSpec, instanceTypes map[string]InstanceTypeInfo) func() []quota.Constraint {
+	return func() []quota.Constraint {
+		var ret []quota.Constraint
+		for idx, m := range machines {
+			ret = append(ret, machineTypeToQuota(config, m.VMSize, replicas[idx], instanceTypes)...)
+		}
+		return ret
+	}
+}
+
+// machineTypeToQuota returns the regional and VM family vCPU constraints for
+// count machines of type t.
+func machineTypeToQuota(config *types.InstallConfig, t string, count int64, instanceTypes map[string]InstanceTypeInfo) []quota.Constraint {
+	info, ok := instanceTypes[t]
+	if !ok {
+		return []quota.Constraint{{Name: "compute/cores", Region: config.Platform.Azure.Region, Count: 0}}
+	}
+	ret := []quota.Constraint{{
+		Name:   "compute/cores",
+		Region: config.Platform.Azure.Region,
+		Count:  info.vCPU * count,
+	}}
+	if info.Family != "" {
+		ret = append(ret, quota.Constraint{
+			Name:   "compute/" + info.Family,
+			Region: config.Platform.Azure.Region,
+			Count:  info.vCPU * count,
+		})
+	}
+	return ret
+}
@@ -0,0 +1,130 @@
+package azure
+
+import (
+	"testing"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	azureprovider "sigs.k8s.io/cluster-api-provider-azure/pkg/apis/azureprovider/v1beta1"
+
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+	typesazure "github.com/openshift/installer/pkg/types/azure"
+)
+
+func machine(vmSize string) machineapi.Machine {
+	return machineapi.Machine{
+		Spec: machineapi.MachineSpec{
+			ProviderSpec: machineapi.ProviderSpec{
+				Value: &runtime.RawExtension{Object: &azureprovider.AzureMachineProviderSpec{VMSize: vmSize}},
+			},
+		},
+	}
+}
+
+func machineSet(vmSize string, replicas int32) machineapi.MachineSet {
+	return machineapi.MachineSet{
+		Spec: machineapi.MachineSetSpec{
+			Replicas: &replicas,
+			Template: machineapi.MachineTemplateSpec{
+				Spec: machineapi.MachineSpec{
+					ProviderSpec: machineapi.ProviderSpec{
+						Value: &runtime.RawExtension{Object: &azureprovider.AzureMachineProviderSpec{VMSize: vmSize}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestConstraints(t *testing.T) {
+	instanceTypes := map[string]InstanceTypeInfo{
+		"Standard_D8s_v3": {Name: "Standard_D8s_v3", Family: "standardDSv3Family", vCPU: 8},
+		"Standard_D4s_v3": {Name: "Standard_D4s_v3", Family: "standardDSv3Family", vCPU: 4},
+		"Standard_F4s_v2": {Name: "Standard_F4s_v2", Family: "standardFSv2Family", vCPU: 4},
+	}
+	masters := []machineapi.Machine{machine("Standard_D8s_v3"), machine("Standard_D8s_v3"), machine("Standard_D8s_v3")}
+
+	cases := []struct {
+		name     string
+		publish  types.PublishingStrategy
+		outbound typesazure.OutboundType
+		ipv6     bool
+		workers  []machineapi.MachineSet
+		exp      []quota.Constraint
+	}{{
+		name:    "external",
+		workers: []machineapi.MachineSet{machineSet("Standard_D4s_v3", 2), machineSet("Standard_D4s_v3", 1)},
+		exp: []quota.Constraint{
+			{Name: "compute/cores", Region: "centralus", Count: 44},
+			{Name: "compute/standardDSv3Family", Region: "centralus", Count: 44},
+			{Name: "network/LoadBalancers", Region: "centralus", Count: 2},
+			{Name: "network/PublicIPAddresses", Region: "centralus", Count: 2},
+			{Name: "network/StandardSkuPublicIpAddresses", Region: "centralus", Count: 2},
+		},
+	}, {
+		name:    "mixed families",
+		workers: []machineapi.MachineSet{machineSet("Standard_F4s_v2", 3)},
+		exp: []quota.Constraint{
+			{Name: "compute/cores", Region: "centralus", Count: 44},
+			{Name: "compute/standardDSv3Family", Region: "centralus", Count: 32},
+			{Name: "compute/standardFSv2Family", Region: "centralus", Count: 12},
+			{Name: "network/LoadBalancers", Region: "centralus", Count: 2},
+			{Name: "network/PublicIPAddresses", Region: "centralus", Count: 2},
+			{Name: "network/StandardSkuPublicIpAddresses", Region: "centralus", Count: 2},
+		},
+	}, {
+		name:    "dual-stack",
+		ipv6:    true,
+		workers: []machineapi.MachineSet{machineSet("Standard_D4s_v3", 0)},
+		exp: []quota.Constraint{
+			{Name: "compute/cores", Region: "centralus", Count: 32},
+			{Name: "compute/standardDSv3Family", Region: "centralus", Count: 32},
+			{Name: "network/LoadBalancers", Region: "centralus", Count: 2},
+			{Name: "network/PublicIPAddresses", Region: "centralus", Count: 4},
+			{Name: "network/StandardSkuPublicIpAddresses", Region: "centralus", Count: 4},
+		},
+	}, {
+		name:    "internal",
+		publish: types.InternalPublishingStrategy,
+		exp: []quota.Constraint{
+			{Name: "compute/cores", Region: "centralus", Count: 32},
+			{Name: "compute/standardDSv3Family", Region: "centralus", Count: 32},
+			{Name: "network/LoadBalancers", Region: "centralus", Count: 2},
+			{Name: "network/PublicIPAddresses", Region: "centralus", Count: 1},
+			{Name: "network/StandardSkuPublicIpAddresses", Region: "centralus", Count: 1},
+		},
+	}, {
+		name:     "internal with user-defined routing",
+		publish:  types.InternalPublishingStrategy,
+		outbound: typesazure.UserDefinedRoutingOutboundType,
+		exp: []quota.Constraint{
+			{Name: "compute/cores", Region: "centralus", Count: 32},
+			{Name: "compute/standardDSv3Family", Region: "centralus", Count: 32},
+			{Name: "network/LoadBalancers", Region: "centralus", Count: 2},
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			publish := tc.publish
+			if publish == "" {
+				publish = types.ExternalPublishingStrategy
+			}
+			serviceNetwork := []ipnet.IPNet{*ipnet.MustParseCIDR("172.30.0.0/16")}
+			if tc.ipv6 {
+				serviceNetwork = append(serviceNetwork, *ipnet.MustParseCIDR("fd02::/112"))
+			}
+			config := &types.InstallConfig{
+				Networking: &types.Networking{ServiceNetwork: serviceNetwork},
+				Publish:    publish,
+				Platform: types.Platform{
+					Azure: &typesazure.Platform{Region: "centralus", OutboundType: tc.outbound},
+				},
+			}
+			assert.Equal(t, tc.exp, Constraints(config, masters, tc.workers, instanceTypes))
+		})
+	}
+}
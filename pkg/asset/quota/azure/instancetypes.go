@@ -0,0 +1,54 @@
+package azure
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+
+	azureconfig "github.com/openshift/installer/pkg/asset/installconfig/azure"
+)
+
+// InstanceTypeInfo describes the instance type
+type InstanceTypeInfo struct {
+	Name string
+	// Family is the VM family of the instance type, which is also the name
+	// of its vCPU quota, e.g. "standardDSv3Family".
+	Family string
+	vCPU   int64
+}
+
+// InstanceTypes returns information on the named instance types in a region.
+// It returns a map of instance type name to it's information.
+func InstanceTypes(ctx context.Context, client azureconfig.API, region string, names ...string) (map[string]InstanceTypeInfo, error) {
+	ret := map[string]InstanceTypeInfo{}
+	for _, name := range names {
+		if _, ok := ret[name]; ok {
+			continue
+		}
+		sku, err := client.GetVirtualMachineSku(ctx, name, region)
+		if err != nil {
+			return nil, err
+		}
+		if sku == nil {
+			return nil, errors.Errorf("instance type %s not found in region %s", name, region)
+		}
+		info := InstanceTypeInfo{Name: name, Family: to.String(sku.Family)}
+		if sku.Capabilities != nil {
+			for _, capability := range *sku.Capabilities {
+				if !strings.EqualFold(to.String(capability.Name), "vCPUs") {
+					continue
+				}
+				cpus, err := strconv.ParseInt(to.String(capability.Value), 10, 64)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to parse the vCPUs of instance type %s", name)
+				}
+				info.vCPU = cpus
+			}
+		}
+		ret[name] = info
+	}
+	return ret, nil
+}
@@ -0,0 +1,113 @@
+package ovirt
+
+import (
+	"sort"
+
+	ovirtprovider "github.com/openshift/cluster-api-provider-ovirt/pkg/apis/ovirtprovider/v1beta1"
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	"github.com/openshift/installer/pkg/quota"
+	quotaovirt "github.com/openshift/installer/pkg/quota/ovirt"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// The bootstrap machine's CPU and memory are fixed by its Terraform module,
+// and its disk comes from the same template as the control plane's.
+const (
+	bootstrapCPUs      = 4
+	bootstrapMemoryMiB = 8192
+)
+
+// Constraints returns a list of quota constraints based on the InstallConfig.
+// These constraints can be used to check if there is enough capacity in the
+// cluster and storage domain for creating a cluster for the install config.
+// Machines sized by an instance type only constrain storage.
+func Constraints(config *types.InstallConfig, controlPlanes []machineapi.Machine, computes []machineapi.MachineSet) []quota.Constraint {
+	ctrplConfigs := make([]*ovirtprovider.OvirtMachineProviderSpec, len(controlPlanes))
+	for i, m := range controlPlanes {
+		ctrplConfigs[i] = m.Spec.ProviderSpec.Value.Object.(*ovirtprovider.OvirtMachineProviderSpec)
+	}
+	computeReplicas := make([]int64, len(computes))
+	computeConfigs := make([]*ovirtprovider.OvirtMachineProviderSpec, len(computes))
+	for i, w := range computes {
+		computeReplicas[i] = int64(*w.Spec.Replicas)
+		computeConfigs[i] = w.Spec.Template.Spec.ProviderSpec.Value.Object.(*ovirtprovider.OvirtMachineProviderSpec)
+	}
+
+	var ret []quota.Constraint
+	ret = append(ret, bootstrap(config, ctrplConfigs)...)
+	for _, m := range ctrplConfigs {
+		ret = append(ret, machineToQuota(config, m, 1)...)
+	}
+	for idx, m := range computeConfigs {
+		ret = append(ret, machineToQuota(config, m, computeReplicas[idx])...)
+	}
+	return aggregate(ret)
+}
+
+func aggregate(quotas []quota.Constraint) []quota.Constraint {
+	sort.SliceStable(quotas, func(i, j int) bool {
+		return quotas[i].Name < quotas[j].Name
+	})
+
+	i := 0
+	for j := 1; j < len(quotas); j++ {
+		if quotas[i].Name == quotas[j].Name && quotas[i].Region == quotas[j].Region {
+			quotas[i].Count += quotas[j].Count
+		} else {
+			i++
+			if i != j {
+				quotas[i] = quotas[j]
+			}
+		}
+	}
+	return quotas[:i+1]
+}
+
+func bootstrap(config *types.InstallConfig, controlPlanes []*ovirtprovider.OvirtMachineProviderSpec) []quota.Constraint {
+	ret := []quota.Constraint{{
+		Name:   quotaovirt.CPU,
+		Region: config.Platform.Ovirt.ClusterID,
+		Count:  bootstrapCPUs,
+	}, {
+		Name:   quotaovirt.Memory,
+		Region: config.Platform.Ovirt.ClusterID,
+		Count:  bootstrapMemoryMiB,
+	}}
+	if len(controlPlanes) > 0 && controlPlanes[0].OSDisk != nil {
+		ret = append(ret, quota.Constraint{
+			Name:   quotaovirt.Storage,
+			Region: config.Platform.Ovirt.StorageDomainID,
+			Count:  controlPlanes[0].OSDisk.SizeGB,
+		})
+	}
+	return ret
+}
+
+// machineToQuota returns the CPU, memory and storage constraints for count
+// machines with the provider spec m.
+func machineToQuota(config *types.InstallConfig, m *ovirtprovider.OvirtMachineProviderSpec, count int64) []quota.Constraint {
+	var ret []quota.Constraint
+	if m.CPU != nil {
+		ret = append(ret, quota.Constraint{
+			Name:   quotaovirt.CPU,
+			Region: config.Platform.Ovirt.ClusterID,
+			Count:  int64(m.CPU.Sockets) * int64(m.CPU.Cores) * count,
+		})
+	}
+	if m.MemoryMB != 0 {
+		ret = append(ret, quota.Constraint{
+			Name:   quotaovirt.Memory,
+			Region: config.Platform.Ovirt.ClusterID,
+			Count:  int64(m.MemoryMB) * count,
+		})
+	}
+	if m.OSDisk != nil {
+		ret = append(ret, quota.Constraint{
+			Name:   quotaovirt.Storage,
+			Region: config.Platform.Ovirt.StorageDomainID,
+			Count:  m.OSDisk.SizeGB * count,
+		})
+	}
+	return ret
+}
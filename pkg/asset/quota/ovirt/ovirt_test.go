@@ -0,0 +1,54 @@
+package ovirt
+
+import (
+	"testing"
+
+	ovirtprovider "github.com/openshift/cluster-api-provider-ovirt/pkg/apis/ovirtprovider/v1beta1"
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/ovirt"
+)
+
+func TestConstraints(t *testing.T) {
+	config := &types.InstallConfig{
+		Platform: types.Platform{
+			Ovirt: &ovirt.Platform{ClusterID: "c1", StorageDomainID: "sd1"},
+		},
+	}
+	masters := make([]machineapi.Machine, 3)
+	for i := range masters {
+		masters[i].Spec.ProviderSpec.Value = &runtime.RawExtension{Object: &ovirtprovider.OvirtMachineProviderSpec{
+			CPU:      &ovirtprovider.CPU{Sockets: 1, Cores: 4},
+			MemoryMB: 16348,
+			OSDisk:   &ovirtprovider.Disk{SizeGB: 120},
+		}}
+	}
+	replicas := int32(2)
+	workers := []machineapi.MachineSet{{
+		Spec: machineapi.MachineSetSpec{
+			Replicas: &replicas,
+			Template: machineapi.MachineTemplateSpec{
+				Spec: machineapi.MachineSpec{
+					ProviderSpec: machineapi.ProviderSpec{
+						Value: &runtime.RawExtension{Object: &ovirtprovider.OvirtMachineProviderSpec{
+							// sized by an instance type
+							InstanceTypeId: "it1",
+							OSDisk:         &ovirtprovider.Disk{SizeGB: 120},
+						}},
+					},
+				},
+			},
+		},
+	}}
+
+	exp := []quota.Constraint{
+		{Name: "ovirt/cpu", Region: "c1", Count: 16},
+		{Name: "ovirt/memory", Region: "c1", Count: 57236},
+		{Name: "ovirt/storage", Region: "sd1", Count: 720},
+	}
+	assert.Equal(t, exp, Constraints(config, masters, workers))
+}
@@ -7,19 +7,27 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	azureprovider "sigs.k8s.io/cluster-api-provider-azure/pkg/apis/azureprovider/v1beta1"
 
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	configgcp "github.com/openshift/installer/pkg/asset/installconfig/gcp"
 	openstackvalidation "github.com/openshift/installer/pkg/asset/installconfig/openstack/validation"
+	ovirtconfig "github.com/openshift/installer/pkg/asset/installconfig/ovirt"
 	"github.com/openshift/installer/pkg/asset/machines"
 	"github.com/openshift/installer/pkg/asset/quota/aws"
+	azurequota "github.com/openshift/installer/pkg/asset/quota/azure"
 	"github.com/openshift/installer/pkg/asset/quota/gcp"
 	"github.com/openshift/installer/pkg/asset/quota/openstack"
+	ovirtconstraints "github.com/openshift/installer/pkg/asset/quota/ovirt"
+	vsphereconstraints "github.com/openshift/installer/pkg/asset/quota/vsphere"
 	"github.com/openshift/installer/pkg/diagnostics"
 	"github.com/openshift/installer/pkg/quota"
 	quotaaws "github.com/openshift/installer/pkg/quota/aws"
+	quotaazure "github.com/openshift/installer/pkg/quota/azure"
 	quotagcp "github.com/openshift/installer/pkg/quota/gcp"
+	quotaovirt "github.com/openshift/installer/pkg/quota/ovirt"
+	quotavsphere "github.com/openshift/installer/pkg/quota/vsphere"
 	typesaws "github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/baremetal"
@@ -121,6 +129,39 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 			return summarizeFailingReport(reports)
 		}
 		summarizeReport(reports)
+	case azure.Name:
+		session, err := ic.Azure.Session()
+		if err != nil {
+			return errors.Wrap(err, "failed to load Azure session")
+		}
+		q, err := quotaazure.Load(context.TODO(), session, ic.Config.Platform.Azure.Region)
+		if quotaazure.IsUnauthorized(err) {
+			logrus.Warnf("Missing permissions to fetch Quotas and therefore will skip checking them: %v, make sure you have `Microsoft.Compute/locations/usages/read` and `Microsoft.Network/locations/usages/read` permissions available to the user.", err)
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to load Quota for region %s", ic.Config.Platform.Azure.Region)
+		}
+		client, err := ic.Azure.Client()
+		if err != nil {
+			return errors.Wrap(err, "failed to create client for quota constraints")
+		}
+		var vmSizes []string
+		for _, m := range masters {
+			vmSizes = append(vmSizes, m.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec).VMSize)
+		}
+		for _, w := range workers {
+			vmSizes = append(vmSizes, w.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec).VMSize)
+		}
+		instanceTypes, err := azurequota.InstanceTypes(context.TODO(), client, ic.Config.Platform.Azure.Region, vmSizes...)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load instance types for %s", ic.Config.Platform.Azure.Region)
+		}
+		reports, err := quota.Check(q, azurequota.Constraints(ic.Config, masters, workers, instanceTypes))
+		if err != nil {
+			return summarizeFailingReport(reports)
+		}
+		summarizeReport(reports)
 	case typesopenstack.Name:
 		ci, err := openstackvalidation.GetCloudInfo(ic.Config)
 		if err != nil {
@@ -131,7 +172,45 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 			return summarizeFailingReport(reports)
 		}
 		summarizeReport(reports)
-	case azure.Name, baremetal.Name, libvirt.Name, none.Name, ovirt.Name, vsphere.Name, kubevirt.Name:
+	case vsphere.Name:
+		if ic.Config.Platform.VSphere.Cluster == "" {
+			// user-provisioned infrastructure
+			return nil
+		}
+		client, _, err := vsphere.CreateVSphereClients(context.TODO(), ic.Config.VSphere.VCenter, ic.Config.VSphere.Username, ic.Config.VSphere.Password)
+		if err != nil {
+			return errors.Wrap(err, "unable to connect to vCenter API")
+		}
+		q, err := quotavsphere.Load(context.TODO(), client, ic.Config.VSphere.Datacenter, ic.Config.VSphere.Cluster, ic.Config.VSphere.DefaultDatastore)
+		if err != nil {
+			return errors.Wrap(err, "failed to load the capacity of the vSphere cluster and datastore")
+		}
+		for _, fd := range ic.Config.VSphere.FailureDomains {
+			fdq, err := quotavsphere.Load(context.TODO(), client, fd.Datacenter, fd.Cluster, fd.Datastore)
+			if err != nil {
+				return errors.Wrapf(err, "failed to load the capacity of the vSphere cluster and datastore of failure domain %s", fd.Name)
+			}
+			q = append(q, fdq...)
+		}
+		// The hypervisor can overcommit CPU and memory and the disks can be
+		// thin provisioned, so missing capacity only warns.
+		reports, _ := quota.Check(q, vsphereconstraints.Constraints(ic.Config, masters, workers))
+		summarizeOvercommittedReport(reports)
+	case ovirt.Name:
+		con, err := ovirtconfig.NewConnection()
+		if err != nil {
+			return errors.Wrap(err, "failed to connect to the oVirt Engine")
+		}
+		defer con.Close()
+		q, err := quotaovirt.Load(con, ic.Config.Ovirt.ClusterID, ic.Config.Ovirt.StorageDomainID)
+		if err != nil {
+			return errors.Wrap(err, "failed to load the capacity of the oVirt cluster and storage domain")
+		}
+		// The hypervisor can overcommit CPU and memory and the disks can be
+		// thin provisioned, so missing capacity only warns.
+		reports, _ := quota.Check(q, ovirtconstraints.Constraints(ic.Config, masters, workers))
+		summarizeOvercommittedReport(reports)
+	case baremetal.Name, libvirt.Name, none.Name, kubevirt.Name:
 		// no special provisioning requirements to check
 	default:
 		err = fmt.Errorf("unknown platform type %q", platform)
@@ -178,6 +257,28 @@ func summarizeFailingReport(reports []quota.ConstraintReport) error {
 	return &diagnostics.Err{Reason: "MissingQuota", Message: msg}
 }
 
+// summarizeOvercommittedReport summarizes a report for capacity which can be
+// overcommitted, warning about failing constraints instead of failing.
+func summarizeOvercommittedReport(reports []quota.ConstraintReport) {
+	var notavailable []string
+	var unknown []string
+	for _, report := range reports {
+		switch report.Result {
+		case quota.NotAvailable:
+			notavailable = append(notavailable, fmt.Sprintf("%s is not available in %s because %s", report.For.Name, report.For.Region, report.Message))
+		case quota.Unknown:
+			unknown = append(unknown, report.For.Name)
+		}
+	}
+	if len(notavailable) > 0 {
+		logrus.Warnf("%s; the installation will only succeed if the platform overcommits them", strings.Join(notavailable, ", "))
+	}
+	if len(unknown) > 0 {
+		logrus.Warnf("Failed to find information on quotas %s", strings.Join(unknown, ", "))
+	}
+	summarizeReport(reports)
+}
+
 // summarizeReport summarizes a report when there are availble.
 func summarizeReport(reports []quota.ConstraintReport) {
 	var low []string
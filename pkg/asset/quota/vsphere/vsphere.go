@@ -0,0 +1,124 @@
+package vsphere
+
+import (
+	"sort"
+	"strings"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+	vsphereprovider "github.com/openshift/machine-api-operator/pkg/apis/vsphereprovider/v1beta1"
+
+	"github.com/openshift/installer/pkg/quota"
+	quotavsphere "github.com/openshift/installer/pkg/quota/vsphere"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// The bootstrap machine's size is fixed by its Terraform module.
+const (
+	bootstrapNumCPUs   = 4
+	bootstrapMemoryMiB = 16384
+	bootstrapDiskGiB   = 120
+)
+
+// Constraints returns a list of quota constraints based on the InstallConfig.
+// These constraints can be used to check if there is enough capacity in the
+// cluster and datastore for creating a cluster for the install config.
+func Constraints(config *types.InstallConfig, controlPlanes []machineapi.Machine, computes []machineapi.MachineSet) []quota.Constraint {
+	ctrplConfigs := make([]*vsphereprovider.VSphereMachineProviderSpec, len(controlPlanes))
+	for i, m := range controlPlanes {
+		ctrplConfigs[i] = m.Spec.ProviderSpec.Value.Object.(*vsphereprovider.VSphereMachineProviderSpec)
+	}
+	computeReplicas := make([]int64, len(computes))
+	computeConfigs := make([]*vsphereprovider.VSphereMachineProviderSpec, len(computes))
+	for i, w := range computes {
+		computeReplicas[i] = int64(*w.Spec.Replicas)
+		computeConfigs[i] = w.Spec.Template.Spec.ProviderSpec.Value.Object.(*vsphereprovider.VSphereMachineProviderSpec)
+	}
+
+	var ret []quota.Constraint
+	ret = append(ret, bootstrap(config, ctrplConfigs)...)
+	for _, m := range ctrplConfigs {
+		ret = append(ret, machineToQuota(config, m, 1)...)
+	}
+	for idx, m := range computeConfigs {
+		ret = append(ret, machineToQuota(config, m, computeReplicas[idx])...)
+	}
+	return aggregate(ret)
+}
+
+func aggregate(quotas []quota.Constraint) []quota.Constraint {
+	sort.SliceStable(quotas, func(i, j int) bool {
+		return quotas[i].Name < quotas[j].Name
+	})
+
+	i := 0
+	for j := 1; j < len(quotas); j++ {
+		if quotas[i].Name == quotas[j].Name && quotas[i].Region == quotas[j].Region {
+			quotas[i].Count += quotas[j].Count
+		} else {
+			i++
+			if i != j {
+				quotas[i] = quotas[j]
+			}
+		}
+	}
+	return quotas[:i+1]
+}
+
+// bootstrap returns the constraints for the bootstrap machine, which is
+// created next to the first control plane machine.
+func bootstrap(config *types.InstallConfig, ctrplConfigs []*vsphereprovider.VSphereMachineProviderSpec) []quota.Constraint {
+	cluster, datastore := config.Platform.VSphere.Cluster, config.Platform.VSphere.DefaultDatastore
+	if len(ctrplConfigs) > 0 {
+		cluster, datastore = location(config, ctrplConfigs[0])
+	}
+	return []quota.Constraint{{
+		Name:   quotavsphere.CPU,
+		Region: cluster,
+		Count:  bootstrapNumCPUs,
+	}, {
+		Name:   quotavsphere.Memory,
+		Region: cluster,
+		Count:  bootstrapMemoryMiB,
+	}, {
+		Name:   quotavsphere.Storage,
+		Region: datastore,
+		Count:  bootstrapDiskGiB,
+	}}
+}
+
+// location returns the cluster and the datastore of the machines with the
+// provider spec m, defaulting to those of the platform.
+func location(config *types.InstallConfig, m *vsphereprovider.VSphereMachineProviderSpec) (string, string) {
+	cluster := config.Platform.VSphere.Cluster
+	datastore := config.Platform.VSphere.DefaultDatastore
+	if m.Workspace == nil {
+		return cluster, datastore
+	}
+	// The resource pool is always of the form /<datacenter>/host/<cluster>/Resources.
+	if parts := strings.SplitAfterN(m.Workspace.ResourcePool, "host/", 2); len(parts) == 2 {
+		cluster = strings.TrimSuffix(parts[1], "/Resources")
+	}
+	if m.Workspace.Datastore != "" {
+		datastore = m.Workspace.Datastore
+	}
+	return cluster, datastore
+}
+
+// machineToQuota returns the CPU, memory and storage constraints for count
+// machines with the provider spec m.
+func machineToQuota(config *types.InstallConfig, m *vsphereprovider.VSphereMachineProviderSpec, count int64) []quota.Constraint {
+	cluster, datastore := location(config, m)
+	return []quota.Constraint{{
+		Name:   quotavsphere.CPU,
+		Region: cluster,
+		Count:  int64(m.NumCPUs) * count,
+	}, {
+		Name:   quotavsphere.Memory,
+		Region: cluster,
+		Count:  m.MemoryMiB * count,
+	}, {
+		Name:   quotavsphere.Storage,
+		Region: datastore,
+		Count:  int64(m.DiskGiB) * count,
+	}}
+}
@@ -0,0 +1,77 @@
+package vsphere
+
+import (
+	"testing"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+	vsphereprovider "github.com/openshift/machine-api-operator/pkg/apis/vsphereprovider/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/vsphere"
+)
+
+func providerSpec(cpus int32, memoryMiB int64, diskGiB int32, datastore string) machineapi.ProviderSpec {
+	return machineapi.ProviderSpec{
+		Value: &runtime.RawExtension{Object: &vsphereprovider.VSphereMachineProviderSpec{
+			NumCPUs:   cpus,
+			MemoryMiB: memoryMiB,
+			DiskGiB:   diskGiB,
+			Workspace: &vsphereprovider.Workspace{Datastore: datastore},
+		}},
+	}
+}
+
+func TestConstraints(t *testing.T) {
+	config := &types.InstallConfig{
+		Platform: types.Platform{
+			VSphere: &vsphere.Platform{Cluster: "cluster", DefaultDatastore: "ds"},
+		},
+	}
+	masters := make([]machineapi.Machine, 3)
+	for i := range masters {
+		masters[i].Spec.ProviderSpec = providerSpec(4, 16384, 120, "ds")
+	}
+	replicas := int32(3)
+	workers := []machineapi.MachineSet{{
+		Spec: machineapi.MachineSetSpec{
+			Replicas: &replicas,
+			Template: machineapi.MachineTemplateSpec{
+				Spec: machineapi.MachineSpec{ProviderSpec: providerSpec(2, 8192, 120, "")},
+			},
+		},
+	}}
+
+	exp := []quota.Constraint{
+		{Name: "vsphere/cpu", Region: "cluster", Count: 22},
+		{Name: "vsphere/memory", Region: "cluster", Count: 90112},
+		{Name: "vsphere/storage", Region: "ds", Count: 840},
+	}
+	assert.Equal(t, exp, Constraints(config, masters, workers))
+}
+
+func TestConstraintsFailureDomains(t *testing.T) {
+	config := &types.InstallConfig{
+		Platform: types.Platform{
+			VSphere: &vsphere.Platform{Cluster: "cluster", DefaultDatastore: "ds"},
+		},
+	}
+	masters := make([]machineapi.Machine, 2)
+	for i, location := range []string{"a", "b"} {
+		masters[i].Spec.ProviderSpec = providerSpec(4, 16384, 120, "ds-"+location)
+		spec := masters[i].Spec.ProviderSpec.Value.Object.(*vsphereprovider.VSphereMachineProviderSpec)
+		spec.Workspace.ResourcePool = "/dc/host/cluster-" + location + "/Resources"
+	}
+
+	exp := []quota.Constraint{
+		{Name: "vsphere/cpu", Region: "cluster-a", Count: 8},
+		{Name: "vsphere/cpu", Region: "cluster-b", Count: 4},
+		{Name: "vsphere/memory", Region: "cluster-a", Count: 32768},
+		{Name: "vsphere/memory", Region: "cluster-b", Count: 16384},
+		{Name: "vsphere/storage", Region: "ds-a", Count: 240},
+		{Name: "vsphere/storage", Region: "ds-b", Count: 120},
+	}
+	assert.Equal(t, exp, Constraints(config, masters, nil))
+}
@@ -0,0 +1,46 @@
+package asset
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// secretFilePatterns match the names of the files, relative to the asset
+// directory, which hold secrets that the installer generated. They are
+// encrypted like the state file when a key provider is configured. The
+// Ignition configs also hold secrets, but they are read by the machines and
+// so are never encrypted.
+var secretFilePatterns = []string{
+	filepath.Join("auth", "kubeconfig"),
+	filepath.Join("auth", "kubeadmin-password"),
+	filepath.Join("tls", "*.key"),
+}
+
+// IsSecretFile returns true if the file with the given name, relative to the
+// asset directory, holds secrets that the installer generated.
+func IsSecretFile(filename string) bool {
+	for _, pattern := range secretFilePatterns {
+		if matched, _ := filepath.Match(pattern, filepath.Clean(filename)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretFiles returns the paths of the files in directory which hold secrets
+// that the installer generated.
+func SecretFiles(directory string) ([]string, error) {
+	var paths []string
+	for _, pattern := range secretFilePatterns {
+		matches, err := filepath.Glob(filepath.Join(directory, pattern))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range matches {
+			if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths, nil
+}
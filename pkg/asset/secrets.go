@@ -0,0 +1,38 @@
+package asset
+
+import (
+	"github.com/openshift/installer/pkg/types/secret"
+)
+
+// RedactSecrets returns copies of files in which the secrets read from secret
+// references are replaced by placeholders, so that the files can be saved in
+// the state file without them.
+func RedactSecrets(files []*File) []*File {
+	if files == nil {
+		return nil
+	}
+	redacted := make([]*File, len(files))
+	for i, f := range files {
+		if f == nil {
+			continue
+		}
+		redacted[i] = &File{Filename: f.Filename, Data: secret.Redact(f.Data)}
+	}
+	return redacted
+}
+
+// RestoreSecrets replaces the placeholders that RedactSecrets left in files
+// with the secrets, reading their references again.
+func RestoreSecrets(files []*File) error {
+	for _, f := range files {
+		if f == nil {
+			continue
+		}
+		data, err := secret.Restore(f.Data)
+		if err != nil {
+			return err
+		}
+		f.Data = data
+	}
+	return nil
+}
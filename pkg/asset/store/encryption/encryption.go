@@ -0,0 +1,207 @@
+// Package encryption encrypts the installer's state file and the secrets that
+// it generates in the asset directory.
+//
+// The data is encrypted with AES-256-GCM under a random data key, and the
+// data key is encrypted by a KeyProvider and stored with the ciphertext. Key
+// providers are configured by environment variables. The passphrase and key
+// file providers are built in, and others, such as a KMS, can be added with
+// RegisterKeyProvider.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// version is the version of the encrypted state format.
+const version = 1
+
+// KeyProvider protects the data keys that encrypt the state.
+type KeyProvider interface {
+	// Name is the name the provider is registered with.
+	Name() string
+
+	// EncryptKey encrypts a data key.
+	EncryptKey(key []byte) ([]byte, error)
+
+	// DecryptKey decrypts a data key encrypted by EncryptKey.
+	DecryptKey(encryptedKey []byte) ([]byte, error)
+}
+
+// registration is a registered key provider.
+type registration struct {
+	env         string
+	newProvider func(value string) (KeyProvider, error)
+}
+
+var providers = map[string]registration{}
+
+// RegisterKeyProvider registers a key provider that is configured by the
+// environment variable env. When env is set, newProvider is called with its
+// value to create the provider.
+func RegisterKeyProvider(name string, env string, newProvider func(value string) (KeyProvider, error)) {
+	if _, ok := providers[name]; ok {
+		panic(errors.Errorf("key provider %q is already registered", name))
+	}
+	providers[name] = registration{env: env, newProvider: newProvider}
+}
+
+// Envs returns the environment variables configuring the registered key
+// providers, sorted.
+func Envs() []string {
+	var envs []string
+	for _, p := range providers {
+		envs = append(envs, p.env)
+	}
+	sort.Strings(envs)
+	return envs
+}
+
+// Configured returns the key provider configured in the environment, or nil if
+// none is.
+func Configured() (KeyProvider, error) {
+	var configured []string
+	for name, p := range providers {
+		if os.Getenv(p.env) != "" {
+			configured = append(configured, name)
+		}
+	}
+	switch len(configured) {
+	case 0:
+		return nil, nil
+	case 1:
+		return provider(configured[0])
+	default:
+		sort.Strings(configured)
+		var envs []string
+		for _, name := range configured {
+			envs = append(envs, providers[name].env)
+		}
+		return nil, errors.Errorf("only one of %s may be set", strings.Join(envs, ", "))
+	}
+}
+
+// provider returns the registered provider name, configured from the
+// environment.
+func provider(name string) (KeyProvider, error) {
+	p, ok := providers[name]
+	if !ok {
+		return nil, errors.Errorf("unknown key provider %q", name)
+	}
+	value := os.Getenv(p.env)
+	if value == "" {
+		return nil, errors.Errorf("it is encrypted by the %s key provider; set %s to decrypt it", name, p.env)
+	}
+	kp, err := p.newProvider(value)
+	return kp, errors.Wrapf(err, "failed to configure the %s key provider from %s", name, p.env)
+}
+
+// header describes how encrypted state was encrypted.
+type header struct {
+	Version  int    `json:"version"`
+	Provider string `json:"provider"`
+	Key      []byte `json:"key"`
+}
+
+// envelope is the encrypted state.
+type envelope struct {
+	Encryption *header `json:"encryption"`
+	Nonce      []byte  `json:"nonce"`
+	Ciphertext []byte  `json:"ciphertext"`
+}
+
+// IsEncrypted returns true if data is encrypted state.
+func IsEncrypted(data []byte) bool {
+	var e envelope
+	return json.Unmarshal(data, &e) == nil && e.Encryption != nil
+}
+
+// Encrypt encrypts plaintext under a new data key protected by kp.
+func Encrypt(kp KeyProvider, plaintext []byte) ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, errors.Wrap(err, "failed to generate data key")
+	}
+	encryptedKey, err := kp.EncryptKey(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to encrypt data key with the %s key provider", kp.Name())
+	}
+	nonce, ciphertext, err := seal(key, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(envelope{
+		Encryption: &header{Version: version, Provider: kp.Name(), Key: encryptedKey},
+		Nonce:      nonce,
+		Ciphertext: ciphertext,
+	}, "", "    ")
+}
+
+// Decrypt decrypts data encrypted by Encrypt, with the key provider it was
+// encrypted by configured from the environment.
+func Decrypt(data []byte) ([]byte, error) {
+	var e envelope
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	if e.Encryption == nil {
+		return nil, errors.New("not encrypted")
+	}
+	if e.Encryption.Version != version {
+		return nil, errors.Errorf("unsupported encryption version %d", e.Encryption.Version)
+	}
+	kp, err := provider(e.Encryption.Provider)
+	if err != nil {
+		return nil, err
+	}
+	key, err := kp.DecryptKey(e.Encryption.Key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decrypt data key with the %s key provider", kp.Name())
+	}
+	return open(key, e.Nonce, e.Ciphertext)
+}
+
+// seal encrypts plaintext with AES-GCM under key and a random nonce.
+func seal(key []byte, plaintext []byte) (nonce []byte, ciphertext []byte, err error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce = make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate nonce")
+	}
+	return nonce, aead.Seal(nil, nonce, plaintext, nil), nil
+}
+
+// open decrypts ciphertext encrypted by seal.
+func open(key []byte, nonce []byte, ciphertext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce")
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("wrong key or corrupted data")
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,161 @@
+package encryption
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPassphraseIterations(t *testing.T) {
+	defer func(iterations int) { passphraseIterations = iterations }(passphraseIterations)
+	p := &passphraseProvider{passphrase: []byte("correct horse")}
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	cases := []struct {
+		iterations int
+		err        string
+	}{
+		{iterations: 1000, err: `^the encrypted key uses 1000 PBKDF2 iterations, outside of the accepted range of 100000 to 10000000$`},
+		{iterations: minPassphraseIterations},
+		{iterations: maxPassphraseIterations + 1, err: `^the encrypted key uses 10000001 PBKDF2 iterations, outside of the accepted range of 100000 to 10000000$`},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprint(tc.iterations), func(t *testing.T) {
+			// Keys with iteration counts out of range are built by hand,
+			// since deriving them would be slow.
+			encryptedKey := make([]byte, saltSize+4)
+			binary.BigEndian.PutUint32(encryptedKey[saltSize:], uint32(tc.iterations))
+			if tc.err == "" {
+				passphraseIterations = tc.iterations
+				var err error
+				encryptedKey, err = p.EncryptKey(key)
+				require.NoError(t, err)
+			} else {
+				encryptedKey = append(encryptedKey, make([]byte, 12+16)...)
+			}
+
+			decrypted, err := p.DecryptKey(encryptedKey)
+			if tc.err != "" {
+				assert.Regexp(t, tc.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, key, decrypted)
+		})
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	defer func(iterations int) { passphraseIterations = iterations }(passphraseIterations)
+	passphraseIterations = minPassphraseIterations
+
+	dir, err := ioutil.TempDir("", "encryption")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "key")
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte("MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=\n"), 0600))
+
+	plaintext := []byte(`{"*installconfig.InstallConfig": {}}`)
+	cases := []struct {
+		name     string
+		provider string
+		env      string
+		value    string
+		wrong    string
+	}{
+		{name: "passphrase", provider: "passphrase", env: PassphraseEnv, value: "correct horse", wrong: "battery staple"},
+		{name: "key file", provider: "keyFile", env: KeyFileEnv, value: keyFile, wrong: filepath.Join(dir, "other-key")},
+	}
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "other-key"), []byte("fedcba9876543210fedcba9876543210"), 0600))
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Setenv(tc.env, tc.value)
+			defer os.Unsetenv(tc.env)
+
+			kp, err := Configured()
+			require.NoError(t, err)
+			require.NotNil(t, kp)
+			assert.Equal(t, tc.provider, kp.Name())
+
+			assert.False(t, IsEncrypted(plaintext))
+			data, err := Encrypt(kp, plaintext)
+			require.NoError(t, err)
+			assert.True(t, IsEncrypted(data))
+			assert.NotContains(t, string(data), "installconfig")
+
+			decrypted, err := Decrypt(data)
+			require.NoError(t, err)
+			assert.Equal(t, plaintext, decrypted)
+
+			os.Setenv(tc.env, tc.wrong)
+			_, err = Decrypt(data)
+			assert.Error(t, err)
+
+			os.Unsetenv(tc.env)
+			_, err = Decrypt(data)
+			assert.EqualError(t, err, "it is encrypted by the "+tc.provider+" key provider; set "+tc.env+" to decrypt it")
+		})
+	}
+}
+
+func TestConfigured(t *testing.T) {
+	kp, err := Configured()
+	assert.NoError(t, err)
+	assert.Nil(t, kp)
+
+	os.Setenv(PassphraseEnv, "passphrase")
+	defer os.Unsetenv(PassphraseEnv)
+	os.Setenv(KeyFileEnv, "key")
+	defer os.Unsetenv(KeyFileEnv)
+	_, err = Configured()
+	assert.EqualError(t, err, "only one of "+KeyFileEnv+", "+PassphraseEnv+" may be set")
+}
+
+func TestKeyFileInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "encryption")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "key")
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte("too short"), 0600))
+
+	_, err = newKeyFileProvider(keyFile)
+	assert.EqualError(t, err, keyFile+" must hold a 32-byte key, or its base64 encoding")
+}
+
+func TestReadWriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "encryption")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "key")
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte("0123456789abcdef0123456789abcdef"), 0600))
+	path := filepath.Join(dir, "kubeadmin-password")
+	plaintext := []byte("Passw0rd")
+
+	require.NoError(t, WriteFile(path, plaintext, 0600))
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, data)
+
+	os.Setenv(KeyFileEnv, keyFile)
+	defer os.Unsetenv(KeyFileEnv)
+	require.NoError(t, WriteFile(path, plaintext, 0600))
+	data, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(data))
+	data, err = ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, data)
+
+	os.Unsetenv(KeyFileEnv)
+	_, err = ReadFile(path)
+	assert.EqualError(t, err, `failed to decrypt "`+path+`": it is encrypted by the keyFile key provider; set `+KeyFileEnv+` to decrypt it`)
+
+	_, err = ReadFile(filepath.Join(dir, "missing"))
+	assert.True(t, os.IsNotExist(err))
+}
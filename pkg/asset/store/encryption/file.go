@@ -0,0 +1,41 @@
+package encryption
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// ReadFile returns the contents of the file at path, decrypted if it is
+// encrypted. Errors reading the file are returned as they are.
+func ReadFile(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+	data, err = Decrypt(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decrypt %q", path)
+	}
+	return data, nil
+}
+
+// WriteFile writes data to the file at path, encrypted with the key provider
+// configured in the environment if there is one.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	kp, err := Configured()
+	if err != nil {
+		return err
+	}
+	if kp != nil {
+		data, err = Encrypt(kp, data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to encrypt %q", path)
+		}
+	}
+	return ioutil.WriteFile(path, data, perm)
+}
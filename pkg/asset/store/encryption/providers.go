@@ -0,0 +1,134 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// PassphraseEnv is the environment variable holding the passphrase of
+	// the passphrase key provider.
+	PassphraseEnv = "OPENSHIFT_INSTALL_STATE_PASSPHRASE"
+
+	// KeyFileEnv is the environment variable naming the key file of the key
+	// file key provider.
+	KeyFileEnv = "OPENSHIFT_INSTALL_STATE_KEY_FILE"
+
+	saltSize = 16
+
+	// minPassphraseIterations and maxPassphraseIterations bound the PBKDF2
+	// iteration count read from an encrypted key, so that a tampered state
+	// file can neither weaken the key derivation nor make it run for hours.
+	minPassphraseIterations = 100000
+	maxPassphraseIterations = 10000000
+)
+
+// passphraseIterations is the number of PBKDF2 iterations deriving keys from
+// passphrases. It is recorded with each encrypted key, so it can be raised
+// without breaking existing state.
+var passphraseIterations = 600000
+
+func init() {
+	RegisterKeyProvider("passphrase", PassphraseEnv, func(value string) (KeyProvider, error) {
+		return &passphraseProvider{passphrase: []byte(value)}, nil
+	})
+	RegisterKeyProvider("keyFile", KeyFileEnv, newKeyFileProvider)
+}
+
+// passphraseProvider encrypts data keys with a key derived from a passphrase
+// by PBKDF2-HMAC-SHA256 with a random salt.
+type passphraseProvider struct {
+	passphrase []byte
+}
+
+func (p *passphraseProvider) Name() string {
+	return "passphrase"
+}
+
+// EncryptKey returns the salt, the big-endian iteration count, the nonce and
+// the encrypted key, concatenated.
+func (p *passphraseProvider) EncryptKey(key []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+	iterations := make([]byte, 4)
+	binary.BigEndian.PutUint32(iterations, uint32(passphraseIterations))
+	nonce, ciphertext, err := seal(pbkdf2.Key(p.passphrase, salt, passphraseIterations, 32, sha256.New), key)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Join([][]byte{salt, iterations, nonce, ciphertext}, nil), nil
+}
+
+func (p *passphraseProvider) DecryptKey(encryptedKey []byte) ([]byte, error) {
+	if len(encryptedKey) < saltSize+4+12 {
+		return nil, errors.New("encrypted key is too short")
+	}
+	salt, encryptedKey := encryptedKey[:saltSize], encryptedKey[saltSize:]
+	iterations, encryptedKey := binary.BigEndian.Uint32(encryptedKey[:4]), encryptedKey[4:]
+	if iterations < minPassphraseIterations || iterations > maxPassphraseIterations {
+		return nil, errors.Errorf("the encrypted key uses %d PBKDF2 iterations, outside of the accepted range of %d to %d", iterations, minPassphraseIterations, maxPassphraseIterations)
+	}
+	nonce, ciphertext := encryptedKey[:12], encryptedKey[12:]
+	key, err := open(pbkdf2.Key(p.passphrase, salt, int(iterations), 32, sha256.New), nonce, ciphertext)
+	if err != nil {
+		return nil, errors.New("wrong passphrase")
+	}
+	return key, nil
+}
+
+// keyFileProvider encrypts data keys with a 256-bit key read from a file.
+type keyFileProvider struct {
+	key []byte
+}
+
+// newKeyFileProvider reads the key from the file at path, which holds either
+// the 32 bytes of the key or their base64 encoding, as written by
+// "openssl rand -base64 32".
+func newKeyFileProvider(path string) (KeyProvider, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key := data
+	if len(key) != 32 {
+		key, err = base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+		if err != nil || len(key) != 32 {
+			return nil, errors.Errorf("%s must hold a 32-byte key, or its base64 encoding", path)
+		}
+	}
+	return &keyFileProvider{key: key}, nil
+}
+
+func (p *keyFileProvider) Name() string {
+	return "keyFile"
+}
+
+// EncryptKey returns the nonce and the encrypted key, concatenated.
+func (p *keyFileProvider) EncryptKey(key []byte) ([]byte, error) {
+	nonce, ciphertext, err := seal(p.key, key)
+	if err != nil {
+		return nil, err
+	}
+	return append(nonce, ciphertext...), nil
+}
+
+func (p *keyFileProvider) DecryptKey(encryptedKey []byte) ([]byte, error) {
+	if len(encryptedKey) < 12 {
+		return nil, errors.New("encrypted key is too short")
+	}
+	key, err := open(p.key, encryptedKey[:12], encryptedKey[12:])
+	if err != nil {
+		return nil, errors.New("wrong key")
+	}
+	return key, nil
+}
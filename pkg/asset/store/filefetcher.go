@@ -1,26 +1,28 @@
 package store
 
 import (
-	"io/ioutil"
 	"path/filepath"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/store/encryption"
 )
 
 type fileFetcher struct {
 	directory string
 }
 
-// FetchByName returns the file with the given name.
+// FetchByName returns the file with the given name, decrypted if it is
+// encrypted.
 func (f *fileFetcher) FetchByName(name string) (*asset.File, error) {
-	data, err := ioutil.ReadFile(filepath.Join(f.directory, name))
+	data, err := encryption.ReadFile(filepath.Join(f.directory, name))
 	if err != nil {
 		return nil, err
 	}
 	return &asset.File{Filename: name, Data: data}, nil
 }
 
-// FetchByPattern returns the files whose name match the given regexp.
+// FetchByPattern returns the files whose name match the given regexp,
+// decrypted if they are encrypted.
 func (f *fileFetcher) FetchByPattern(pattern string) (files []*asset.File, err error) {
 	matches, err := filepath.Glob(filepath.Join(f.directory, pattern))
 	if err != nil {
@@ -29,7 +31,7 @@ func (f *fileFetcher) FetchByPattern(pattern string) (files []*asset.File, err e
 
 	files = make([]*asset.File, 0, len(matches))
 	for _, path := range matches {
-		data, err := ioutil.ReadFile(path)
+		data, err := encryption.ReadFile(path)
 		if err != nil {
 			return nil, err
 		}
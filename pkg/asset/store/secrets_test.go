@@ -0,0 +1,182 @@
+package store
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/vincent-petithory/dataurl"
+
+	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
+	"github.com/openshift/installer/pkg/asset/targets"
+)
+
+const secretsTestPassword = "store-test-Passw0rd<&>"
+
+// containsSecret returns whether data contains the secret, also in base64,
+// and in the strings of the YAML and JSON documents in data, at any depth.
+func containsSecret(data []byte, secret string) bool {
+	if bytes.Contains(data, []byte(secret)) || bytes.Contains(data, []byte(base64.StdEncoding.EncodeToString([]byte(secret)))) {
+		return true
+	}
+	for _, doc := range bytes.Split(data, []byte("\n---")) {
+		var obj interface{}
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			continue
+		}
+		switch obj.(type) {
+		case map[string]interface{}, []interface{}:
+			if objectContainsSecret(obj, secret) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// objectContainsSecret returns whether the secret is in the strings of obj,
+// which may themselves be documents, base64 or data URLs.
+func objectContainsSecret(obj interface{}, secret string) bool {
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		for _, v := range o {
+			if objectContainsSecret(v, secret) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, v := range o {
+			if objectContainsSecret(v, secret) {
+				return true
+			}
+		}
+	case string:
+		if strings.Contains(o, secret) {
+			return true
+		}
+		if decoded, err := base64.StdEncoding.DecodeString(o); err == nil && containsSecret(decoded, secret) {
+			return true
+		}
+		if u, err := dataurl.DecodeString(o); err == nil && containsSecret(u.Data, secret) {
+			return true
+		}
+		return containsSecret([]byte(o), secret)
+	}
+	return false
+}
+
+func TestSecretsNotSaved(t *testing.T) {
+	os.Setenv("STORE_TEST_PASSWORD", secretsTestPassword)
+	defer os.Unsetenv("STORE_TEST_PASSWORD")
+
+	cases := []struct {
+		name          string
+		installConfig string
+	}{
+		{
+			name: "vsphere",
+			installConfig: `
+apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: test-cluster
+platform:
+  vsphere:
+    vCenter: vcenter.example.com
+    username: administrator@vsphere.local
+    passwordRef:
+      env: STORE_TEST_PASSWORD
+    datacenter: dc
+    defaultDatastore: ds
+    cluster: cluster
+    network: network
+    apiVIP: 192.168.111.5
+    ingressVIP: 192.168.111.4
+pullSecret: '{"auths": {"example.com": {"auth": "test-auth"}}}'
+`,
+		},
+		{
+			name: "baremetal",
+			installConfig: `
+apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: test-cluster
+networking:
+  machineNetwork:
+  - cidr: 192.168.111.0/24
+compute:
+- name: worker
+  replicas: 0
+controlPlane:
+  name: master
+  replicas: 1
+platform:
+  baremetal:
+    apiVIP: 192.168.111.5
+    ingressVIP: 192.168.111.4
+    provisioningNetworkInterface: enp1s0
+    hosts:
+    - name: master-0
+      role: master
+      bmc:
+        address: ipmi://192.168.111.1:6230
+        username: admin
+        passwordRef:
+          env: STORE_TEST_PASSWORD
+      bootMACAddress: 00:11:07:4e:f6:68
+pullSecret: '{"auths": {"example.com": {"auth": "test-auth"}}}'
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "TestSecretsNotSaved")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			if err := ioutil.WriteFile(filepath.Join(dir, "install-config.yaml"), []byte(tc.installConfig), 0640); err != nil {
+				t.Fatal(err)
+			}
+
+			assetStore, err := newStore(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, a := range targets.IgnitionConfigs {
+				if err := assetStore.Fetch(a, targets.IgnitionConfigs...); err != nil {
+					t.Fatalf("failed to fetch %q: %v", a.Name(), err)
+				}
+			}
+			generated := &bootstrap.Bootstrap{}
+			if err := assetStore.Fetch(generated); err != nil {
+				t.Fatal(err)
+			}
+			if !assert.True(t, containsSecret(generated.File.Data, secretsTestPassword), "the bootstrap config delivers the password") {
+				return
+			}
+
+			state, err := ioutil.ReadFile(filepath.Join(dir, stateFileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.False(t, containsSecret(state, secretsTestPassword), "the password is saved in the state file")
+
+			newAssetStore, err := newStore(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			loaded := &bootstrap.Bootstrap{}
+			if assert.NoError(t, newAssetStore.Fetch(loaded)) {
+				assert.Equal(t, generated, loaded)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/store/encryption"
+)
+
+// readStateFile returns the contents of the state file at path, decrypted if
+// it is encrypted. Errors reading the file are returned as they are.
+func readStateFile(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !encryption.IsEncrypted(data) {
+		return data, nil
+	}
+	data, err = encryption.Decrypt(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decrypt state file %q", path)
+	}
+	return data, nil
+}
+
+// replaceFile replaces the file at path with data, keeping its permissions and
+// without leaving a partially written file behind on failure.
+func replaceFile(path string, data []byte) error {
+	perm := os.FileMode(0640)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// EncryptState encrypts the state file and the secret files in dir with the
+// key provider configured in the environment. Files that are already
+// encrypted are encrypted again under a new data key.
+func EncryptState(dir string) error {
+	kp, err := encryption.Configured()
+	if err != nil {
+		return err
+	}
+	if kp == nil {
+		return errors.Errorf("no key provider is configured; set %s", strings.Join(encryption.Envs(), " or "))
+	}
+
+	paths, err := stateFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		data, err := encryption.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %q", path)
+		}
+		data, err = encryption.Encrypt(kp, data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to encrypt %q", path)
+		}
+		if err := replaceFile(path, data); err != nil {
+			return errors.Wrapf(err, "failed to write %q", path)
+		}
+		logrus.Infof("Encrypted %s with the %s key provider", path, kp.Name())
+	}
+	return nil
+}
+
+// DecryptState decrypts the state file and the secret files in dir with the
+// key providers they were encrypted by.
+func DecryptState(dir string) error {
+	paths, err := stateFiles(dir)
+	if err != nil {
+		return err
+	}
+	decrypted := false
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %q", path)
+		}
+		if !encryption.IsEncrypted(data) {
+			logrus.Infof("%s is not encrypted", path)
+			continue
+		}
+		data, err = encryption.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := replaceFile(path, data); err != nil {
+			return errors.Wrapf(err, "failed to write %q", path)
+		}
+		logrus.Infof("Decrypted %s", path)
+		decrypted = true
+	}
+	if decrypted {
+		logrus.Warnf("The installer encrypts the state and the secret files again when it writes them while %s is set", strings.Join(encryption.Envs(), " or "))
+	}
+	return nil
+}
+
+// stateFiles returns the paths of the state file and of the secret files in
+// dir. The state file must exist.
+func stateFiles(dir string) ([]string, error) {
+	path := filepath.Join(dir, stateFileName)
+	if _, err := os.Stat(path); err != nil {
+		return nil, errors.Wrap(err, "failed to read state")
+	}
+	secretFiles, err := asset.SecretFiles(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find secret files")
+	}
+	return append([]string{path}, secretFiles...), nil
+}
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/store/encryption"
+)
+
+func TestEncryptedState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestEncryptedState")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, stateFileName)
+	require.NoError(t, ioutil.WriteFile(path, []byte(userProvidedAssets), 0640))
+	passwordPath := filepath.Join(dir, "auth", "kubeadmin-password")
+	require.NoError(t, os.MkdirAll(filepath.Dir(passwordPath), 0750))
+	require.NoError(t, ioutil.WriteFile(passwordPath, []byte("Passw0rd"), 0640))
+	keyFile := filepath.Join(dir, "state.key")
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte("0123456789abcdef0123456789abcdef"), 0600))
+
+	assert.EqualError(t, EncryptState(dir), "no key provider is configured; set "+encryption.KeyFileEnv+" or "+encryption.PassphraseEnv)
+
+	os.Setenv(encryption.KeyFileEnv, keyFile)
+	defer os.Unsetenv(encryption.KeyFileEnv)
+	require.NoError(t, EncryptState(dir))
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, encryption.IsEncrypted(data))
+	assert.NotContains(t, string(data), "test-auth")
+	data, err = ioutil.ReadFile(passwordPath)
+	require.NoError(t, err)
+	assert.True(t, encryption.IsEncrypted(data))
+
+	// Encrypted files are read from the asset directory transparently.
+	file, err := (&fileFetcher{directory: dir}).FetchByName(filepath.Join("auth", "kubeadmin-password"))
+	require.NoError(t, err)
+	assert.Equal(t, "Passw0rd", string(file.Data))
+
+	// The store loads and saves encrypted state transparently.
+	store, err := newStore(dir)
+	require.NoError(t, err)
+	installConfig := &installconfig.InstallConfig{}
+	require.NoError(t, store.Fetch(installConfig))
+	assert.Equal(t, "test-domain", installConfig.Config.BaseDomain)
+	data, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, encryption.IsEncrypted(data))
+
+	os.Unsetenv(encryption.KeyFileEnv)
+	_, err = newStore(dir)
+	assert.EqualError(t, err, `failed to decrypt state file "`+path+`": it is encrypted by the keyFile key provider; set `+encryption.KeyFileEnv+` to decrypt it`)
+
+	os.Setenv(encryption.KeyFileEnv, keyFile)
+	require.NoError(t, DecryptState(dir))
+	os.Unsetenv(encryption.KeyFileEnv)
+	data, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.False(t, encryption.IsEncrypted(data))
+	data, err = ioutil.ReadFile(passwordPath)
+	require.NoError(t, err)
+	assert.Equal(t, "Passw0rd", string(data))
+	store, err = newStore(dir)
+	require.NoError(t, err)
+	assert.True(t, store.isAssetInState(&installconfig.InstallConfig{}))
+}
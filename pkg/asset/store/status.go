@@ -0,0 +1,152 @@
+package store
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// String returns the name of the source used in status reports.
+func (s assetSource) String() string {
+	switch s {
+	case generatedSource:
+		return "generated"
+	case onDiskSource:
+		return "onDisk"
+	case stateFileSource:
+		return "stateFile"
+	default:
+		return "unfetched"
+	}
+}
+
+// AssetStatus describes how the store would fetch an asset.
+type AssetStatus struct {
+	// Name is the human-friendly name of the asset.
+	Name string `json:"name"`
+
+	// Type is the type of the asset, as it is keyed in the state file.
+	Type string `json:"type"`
+
+	// Source is where the asset would be fetched from: "onDisk" for the
+	// target directory, "stateFile" for the state file, or "generated" if
+	// it would be generated.
+	Source string `json:"source"`
+
+	// Writable is true if the asset is written to the target directory.
+	Writable bool `json:"writable"`
+
+	// PresentOnDisk is true if the asset is in the target directory, whether
+	// or not it would be used.
+	PresentOnDisk bool `json:"presentOnDisk"`
+
+	// DirtyParents are the names of the dependencies that were changed in the
+	// target directory, or that would be regenerated because their own
+	// dependencies were. The asset is regenerated if there are any, and its
+	// copy in the target directory is discarded.
+	DirtyParents []string `json:"dirtyParents,omitempty"`
+
+	// Files are the files the asset would write. They are only known for
+	// generated assets if the state file has a previous copy of the asset.
+	Files []string `json:"files,omitempty"`
+
+	// Purged is true if the asset's files would be removed from the target
+	// directory, because a later asset consumes them.
+	Purged bool `json:"purged,omitempty"`
+}
+
+// Status returns the status of each of the targets and of their dependencies
+// in the store in dir, in the order the store would fetch them. Assets are
+// loaded from the target directory and the state file as they are when they
+// are fetched, but are never generated, and nothing is written.
+func Status(dir string, targets []asset.WritableAsset) ([]AssetStatus, error) {
+	s, err := newStore(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create asset store")
+	}
+	return s.status(targets)
+}
+
+func (s *storeImpl) status(targets []asset.WritableAsset) ([]AssetStatus, error) {
+	preserved := make(map[reflect.Type]bool, len(targets))
+	for _, a := range targets {
+		preserved[reflect.TypeOf(a)] = true
+	}
+
+	var statuses []AssetStatus
+	visited := map[reflect.Type]bool{}
+	var walk func(a asset.Asset) error
+	walk = func(a asset.Asset) error {
+		if visited[reflect.TypeOf(a)] {
+			return nil
+		}
+		visited[reflect.TypeOf(a)] = true
+		state, err := s.load(a, "")
+		if err != nil {
+			return err
+		}
+
+		// Dependencies are fetched before the assets that depend on them.
+		var dirtyParents []string
+		for _, d := range a.Dependencies() {
+			if err := walk(d); err != nil {
+				return err
+			}
+			parent := s.assets[reflect.TypeOf(d)]
+			if parent.anyParentsDirty || parent.source == onDiskSource {
+				dirtyParents = append(dirtyParents, d.Name())
+			}
+		}
+
+		status := AssetStatus{
+			Name:          a.Name(),
+			Type:          reflect.TypeOf(a).String(),
+			Source:        state.source.String(),
+			PresentOnDisk: state.presentOnDisk,
+			DirtyParents:  dirtyParents,
+		}
+		if state.source == unfetched {
+			status.Source = generatedSource.String()
+		}
+		if _, ok := a.(asset.WritableAsset); ok {
+			status.Writable = true
+			status.Purged = state.presentOnDisk && !preserved[reflect.TypeOf(a)]
+			files, err := s.files(a, state)
+			if err != nil {
+				return err
+			}
+			status.Files = files
+		}
+		statuses = append(statuses, status)
+		return nil
+	}
+
+	for _, a := range targets {
+		if err := walk(a); err != nil {
+			return nil, err
+		}
+	}
+	return statuses, nil
+}
+
+// files returns the names of the files of a writable asset. Assets that would
+// be generated are represented by their copy in the state file, if any.
+func (s *storeImpl) files(a asset.Asset, state *assetState) ([]string, error) {
+	wa, _ := state.asset.(asset.WritableAsset)
+	if wa == nil && s.isAssetInState(a) {
+		wa = reflect.New(reflect.TypeOf(a).Elem()).Interface().(asset.WritableAsset)
+		if err := s.loadAssetFromState(wa); err != nil {
+			return nil, errors.Wrapf(err, "failed to load asset %q from state file", a.Name())
+		}
+	}
+	if wa == nil {
+		return nil, nil
+	}
+	var files []string
+	for _, f := range wa.Files() {
+		files = append(files, f.Filename)
+	}
+	return files, nil
+}
@@ -0,0 +1,136 @@
+package store
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/targets"
+)
+
+func TestStoreStatus(t *testing.T) {
+	cases := []struct {
+		name             string
+		onDiskAssets     []string
+		stateFileAssets  []string
+		expectedStatuses []AssetStatus
+	}{
+		{
+			name: "nothing fetched",
+			expectedStatuses: []AssetStatus{
+				{Name: "c", Source: "generated"},
+				{Name: "b", Source: "generated"},
+				{Name: "a", Source: "generated"},
+			},
+		},
+		{
+			name:            "state file",
+			stateFileAssets: []string{"a", "b", "c"},
+			expectedStatuses: []AssetStatus{
+				{Name: "c", Source: "stateFile", Files: []string{"c"}},
+				{Name: "b", Source: "stateFile", Files: []string{"b"}},
+				{Name: "a", Source: "stateFile", Files: []string{"a"}},
+			},
+		},
+		{
+			name:            "on-disk ancestor",
+			onDiskAssets:    []string{"c"},
+			stateFileAssets: []string{"a", "b"},
+			expectedStatuses: []AssetStatus{
+				{Name: "c", Source: "onDisk", PresentOnDisk: true, Files: []string{"c"}, Purged: true},
+				{Name: "b", Source: "generated", DirtyParents: []string{"c"}, Files: []string{"b"}},
+				{Name: "a", Source: "generated", DirtyParents: []string{"b"}, Files: []string{"a"}},
+			},
+		},
+		{
+			name:            "on-disk copy matching the state file",
+			onDiskAssets:    []string{"c"},
+			stateFileAssets: []string{"a", "b", "c"},
+			expectedStatuses: []AssetStatus{
+				{Name: "c", Source: "stateFile", PresentOnDisk: true, Files: []string{"c"}, Purged: true},
+				{Name: "b", Source: "stateFile", Files: []string{"b"}},
+				{Name: "a", Source: "stateFile", Files: []string{"a"}},
+			},
+		},
+		{
+			name:         "on-disk target",
+			onDiskAssets: []string{"a"},
+			expectedStatuses: []AssetStatus{
+				{Name: "c", Source: "generated"},
+				{Name: "b", Source: "generated"},
+				{Name: "a", Source: "onDisk", PresentOnDisk: true, Files: []string{"a"}},
+			},
+		},
+		{
+			name:         "discarded on-disk asset",
+			onDiskAssets: []string{"a", "b"},
+			expectedStatuses: []AssetStatus{
+				{Name: "c", Source: "generated"},
+				{Name: "b", Source: "onDisk", PresentOnDisk: true, Files: []string{"b"}, Purged: true},
+				{Name: "a", Source: "generated", PresentOnDisk: true, DirtyParents: []string{"b"}},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearAssetBehaviors()
+			a, b, c := &testStoreAssetA{}, &testStoreAssetB{}, &testStoreAssetC{}
+			dependencies[reflect.TypeOf(a)] = []asset.Asset{b}
+			dependencies[reflect.TypeOf(b)] = []asset.Asset{c}
+			store := &storeImpl{
+				assets:          map[reflect.Type]*assetState{},
+				stateFileAssets: map[string]json.RawMessage{},
+			}
+			for _, name := range tc.onDiskAssets {
+				onDiskAssets[reflect.TypeOf(newTestStoreAsset(name))] = true
+			}
+			for _, name := range tc.stateFileAssets {
+				store.stateFileAssets[reflect.TypeOf(newTestStoreAsset(name)).String()] = json.RawMessage("{}")
+			}
+
+			statuses, err := store.status([]asset.WritableAsset{a})
+			require.NoError(t, err)
+			for i := range statuses {
+				assert.NotEmpty(t, statuses[i].Type)
+				assert.True(t, statuses[i].Writable)
+				statuses[i].Type = ""
+				statuses[i].Writable = false
+			}
+			assert.Equal(t, tc.expectedStatuses, statuses)
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestStatus")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, stateFileName), []byte(userProvidedAssets), 0666))
+
+	statuses, err := Status(dir, targets.InstallConfig)
+	require.NoError(t, err)
+	sources := map[string]string{}
+	for _, status := range statuses {
+		sources[status.Name] = status.Source
+	}
+	assert.Equal(t, map[string]string{
+		"SSH Key":        "stateFile",
+		"Base Domain":    "stateFile",
+		"Platform":       "stateFile",
+		"Cluster Name":   "stateFile",
+		"Networking":     "generated",
+		"Pull Secret":    "stateFile",
+		"Install Config": "generated",
+	}, sources)
+	assert.Equal(t, "Install Config", statuses[len(statuses)-1].Name, "the target is fetched last")
+
+	_, err = os.Stat(filepath.Join(dir, "install-config.yaml"))
+	assert.True(t, os.IsNotExist(err), "nothing is generated")
+}
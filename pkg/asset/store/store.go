@@ -11,6 +11,8 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/store/encryption"
+	"github.com/openshift/installer/pkg/events"
 )
 
 const (
@@ -130,7 +132,7 @@ func (s *storeImpl) DestroyState() error {
 func (s *storeImpl) loadStateFile() error {
 	path := filepath.Join(s.directory, stateFileName)
 	assets := map[string]json.RawMessage{}
-	data, err := ioutil.ReadFile(path)
+	data, err := readStateFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil
@@ -180,6 +182,17 @@ func (s *storeImpl) saveStateFile() error {
 		return err
 	}
 
+	kp, err := encryption.Configured()
+	if err != nil {
+		return err
+	}
+	if kp != nil {
+		data, err = encryption.Encrypt(kp, data)
+		if err != nil {
+			return errors.Wrap(err, "failed to encrypt state")
+		}
+	}
+
 	path := filepath.Join(s.directory, stateFileName)
 	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
 		return err
@@ -195,6 +208,7 @@ func (s *storeImpl) saveStateFile() error {
 // any errors.
 func (s *storeImpl) fetch(a asset.Asset, indent string) error {
 	logrus.Debugf("%sFetching %s...", indent, a.Name())
+	events.AssetFetched(a.Name())
 
 	assetState, ok := s.assets[reflect.TypeOf(a)]
 	if !ok {
@@ -227,6 +241,7 @@ func (s *storeImpl) fetch(a asset.Asset, indent string) error {
 	if err := a.Generate(parents); err != nil {
 		return errors.Wrapf(err, "failed to generate asset %q", a.Name())
 	}
+	events.AssetGenerated(a.Name())
 	assetState.asset = a
 	assetState.source = generatedSource
 	return nil
@@ -311,11 +326,13 @@ func (s *storeImpl) load(a asset.Asset, indent string) (*assetState, error) {
 	// The asset is sourced from on disk.
 	case foundOnDisk && !onDiskMatchesStateFile:
 		logrus.Debugf("%sUsing %s loaded from target directory", indent, a.Name())
+		events.AssetLoaded(a.Name(), "target directory")
 		assetToStore = onDiskAsset
 		source = onDiskSource
 	// The asset is in the state file. The asset is sourced from state file.
 	case foundInStateFile:
 		logrus.Debugf("%sUsing %s loaded from state file", indent, a.Name())
+		events.AssetLoaded(a.Name(), "state file")
 		assetToStore = stateFileAsset
 		source = stateFileSource
 	// There is no existing source for the asset. The asset will be generated.
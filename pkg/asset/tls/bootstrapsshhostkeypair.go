@@ -0,0 +1,65 @@
+package tls
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// BootstrapSSHHostKeyPair generates the SSH host key pair of the bootstrap-host.
+// The private key is installed as the bootstrap-host's RSA host key so that
+// the public key can be used to verify the bootstrap-host when connecting to
+// it. The key pair is not written to the asset directory; it is only kept in
+// the state file, from which gather loads it.
+type BootstrapSSHHostKeyPair struct {
+	Priv []byte // private key
+	Pub  []byte // public ssh key
+}
+
+const bootstrapSSHHostKeyPairFilenameBase = "bootstrap-ssh-host"
+
+var _ asset.Asset = (*BootstrapSSHHostKeyPair)(nil)
+
+// Dependencies lists the assets required to generate the BootstrapSSHHostKeyPair.
+func (a *BootstrapSSHHostKeyPair) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Name defines a user friendly name for BootstrapSSHHostKeyPair.
+func (a *BootstrapSSHHostKeyPair) Name() string {
+	return "Bootstrap SSH Host Key Pair"
+}
+
+// Generate generates the key pair based on its dependencies.
+func (a *BootstrapSSHHostKeyPair) Generate(dependencies asset.Parents) error {
+	kp := KeyPair{}
+	if err := kp.Generate(bootstrapSSHHostKeyPairFilenameBase); err != nil {
+		return errors.Wrap(err, "failed to generate key pair")
+	}
+
+	publicRSAKey, err := PemToPublicKey(kp.Pub)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse the public RSA key")
+	}
+
+	publicSSHKey, err := ssh.NewPublicKey(publicRSAKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to create public SSH key from public RSA key")
+	}
+
+	a.Priv = kp.Private()
+	a.Pub = ssh.MarshalAuthorizedKey(publicSSHKey)
+
+	return nil
+}
+
+// Public returns the public SSH host key.
+func (a *BootstrapSSHHostKeyPair) Public() []byte {
+	return a.Pub
+}
+
+// Private returns the private host key.
+func (a *BootstrapSSHHostKeyPair) Private() []byte {
+	return a.Priv
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -27,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	awssession "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/destroy/journal"
 	"github.com/openshift/installer/pkg/destroy/providers"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/version"
@@ -36,6 +38,11 @@ var (
 	exists = struct{}{}
 )
 
+const (
+	// defaultConcurrency is the number of resources that are deleted at once.
+	defaultConcurrency = 10
+)
+
 // Filter holds the key/value pairs for the tags we will be matching against.
 //
 // A resource matches the filter if all of the key/value pairs are in its tags.
@@ -68,6 +75,18 @@ type ClusterUninstaller struct {
 	// new session will be created based on the usual credential
 	// configuration (AWS_PROFILE, AWS_ACCESS_KEY_ID, etc.).
 	Session *session.Session
+
+	// journal, if set, records deleted and failed ARNs so that an
+	// interrupted uninstall can be resumed.
+	journal *journal.Journal
+
+	// concurrency is the number of resources deleted at once. If zero,
+	// defaultConcurrency is used.
+	concurrency int
+
+	// limiter spaces the calls to each AWS service. If nil, one with
+	// defaultServiceRates is used.
+	limiter *rateLimiter
 }
 
 // New returns an AWS destroyer from ClusterMetadata.
@@ -94,6 +113,11 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 	}, nil
 }
 
+// SetJournal sets the journal used to record and resume the uninstall.
+func (o *ClusterUninstaller) SetJournal(j *journal.Journal) {
+	o.journal = j
+}
+
 func (o *ClusterUninstaller) validate() error {
 	if len(o.Filters) == 0 {
 		return errors.Errorf("you must specify at least one tag filter")
@@ -106,8 +130,8 @@ func (o *ClusterUninstaller) validate() error {
 }
 
 // Run is the entrypoint to start the uninstall process
-func (o *ClusterUninstaller) Run() error {
-	_, err := o.RunWithContext(context.Background())
+func (o *ClusterUninstaller) Run(ctx context.Context) error {
+	_, err := o.RunWithContext(ctx)
 	return err
 }
 
@@ -119,40 +143,11 @@ func (o *ClusterUninstaller) RunWithContext(ctx context.Context) ([]string, erro
 		return nil, err
 	}
 
-	awsSession := o.Session
-	if awsSession == nil {
-		// Relying on appropriate AWS ENV vars (eg AWS_PROFILE, AWS_ACCESS_KEY_ID, etc)
-		awsSession, err = session.NewSession(aws.NewConfig().WithRegion(o.Region))
-		if err != nil {
-			return nil, err
-		}
-	}
-	awsSession.Handlers.Build.PushBackNamed(request.NamedHandler{
-		Name: "openshiftInstaller.OpenshiftInstallerUserAgentHandler",
-		Fn:   request.MakeAddToUserAgentHandler("OpenShift/4.x Destroyer", version.Raw),
-	})
-
-	tagClients := []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI{
-		resourcegroupstaggingapi.New(awsSession),
-	}
-
-	switch o.Region {
-	case endpoints.CnNorth1RegionID, endpoints.CnNorthwest1RegionID:
-		if o.Region != endpoints.CnNorthwest1RegionID {
-			tagClients = append(tagClients,
-				resourcegroupstaggingapi.New(awsSession, aws.NewConfig().WithRegion(endpoints.CnNorthwest1RegionID)))
-		}
-	case endpoints.UsGovEast1RegionID, endpoints.UsGovWest1RegionID:
-		if o.Region != endpoints.UsGovWest1RegionID {
-			tagClients = append(tagClients,
-				resourcegroupstaggingapi.New(awsSession, aws.NewConfig().WithRegion(endpoints.UsGovWest1RegionID)))
-		}
-	default:
-		if o.Region != endpoints.UsEast1RegionID {
-			tagClients = append(tagClients,
-				resourcegroupstaggingapi.New(awsSession, aws.NewConfig().WithRegion(endpoints.UsEast1RegionID)))
-		}
+	awsSession, err := o.session()
+	if err != nil {
+		return nil, err
 	}
+	tagClients := o.tagClients(awsSession)
 
 	iamClient := iam.New(awsSession)
 	iamRoleSearch := &iamRoleSearch{
@@ -167,8 +162,11 @@ func (o *ClusterUninstaller) RunWithContext(ctx context.Context) ([]string, erro
 	}
 
 	// Get the initial resources to delete, so that they can be returned if the context is canceled while terminating
-	// instances.
-	deleted := sets.NewString()
+	// instances. Resources deleted by a previous run are skipped.
+	deleted := sets.NewString(o.journal.Deleted()...)
+	if deleted.Len() > 0 {
+		o.Logger.Infof("Resuming from journal with %d resources already deleted", deleted.Len())
+	}
 	resourcesToDelete, tagClientsWithResources, err := o.findResourcesToDelete(ctx, tagClients, iamClient, iamRoleSearch, iamUserSearch, deleted)
 	if err != nil {
 		o.Logger.WithError(err).Info("error while finding resources to delete")
@@ -256,6 +254,56 @@ func (o *ClusterUninstaller) RunWithContext(ctx context.Context) ([]string, erro
 	return nil, nil
 }
 
+// session returns the AWS session to use for the uninstall, creating one
+// from the environment if no session was configured.
+func (o *ClusterUninstaller) session() (*session.Session, error) {
+	awsSession := o.Session
+	if awsSession == nil {
+		var err error
+		// Relying on appropriate AWS ENV vars (eg AWS_PROFILE, AWS_ACCESS_KEY_ID, etc)
+		awsSession, err = session.NewSession(aws.NewConfig().WithRegion(o.Region))
+		if err != nil {
+			return nil, err
+		}
+	}
+	awsSession.Handlers.Build.PushBackNamed(request.NamedHandler{
+		Name: "openshiftInstaller.OpenshiftInstallerUserAgentHandler",
+		Fn:   request.MakeAddToUserAgentHandler("OpenShift/4.x Destroyer", version.Raw),
+	})
+	if o.limiter == nil {
+		o.limiter = newRateLimiter(defaultServiceRates)
+	}
+	o.limiter.install(&awsSession.Handlers)
+	return awsSession, nil
+}
+
+// tagClients returns the tagging API clients for the cluster's region and
+// the partition's global region, which holds resources such as Route 53 zones.
+func (o *ClusterUninstaller) tagClients(awsSession *session.Session) []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI {
+	tagClients := []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI{
+		resourcegroupstaggingapi.New(awsSession),
+	}
+
+	switch o.Region {
+	case endpoints.CnNorth1RegionID, endpoints.CnNorthwest1RegionID:
+		if o.Region != endpoints.CnNorthwest1RegionID {
+			tagClients = append(tagClients,
+				resourcegroupstaggingapi.New(awsSession, aws.NewConfig().WithRegion(endpoints.CnNorthwest1RegionID)))
+		}
+	case endpoints.UsGovEast1RegionID, endpoints.UsGovWest1RegionID:
+		if o.Region != endpoints.UsGovWest1RegionID {
+			tagClients = append(tagClients,
+				resourcegroupstaggingapi.New(awsSession, aws.NewConfig().WithRegion(endpoints.UsGovWest1RegionID)))
+		}
+	default:
+		if o.Region != endpoints.UsEast1RegionID {
+			tagClients = append(tagClients,
+				resourcegroupstaggingapi.New(awsSession, aws.NewConfig().WithRegion(endpoints.UsEast1RegionID)))
+		}
+	}
+	return tagClients
+}
+
 // findEC2Instances returns the EC2 instances with tags that satisfy the filters.
 //   deleted - the resources that have already been deleted. Any resources specified in this set will be ignored.
 func (o *ClusterUninstaller) findEC2Instances(ctx context.Context, ec2Client *ec2.EC2, deleted sets.String) ([]string, error) {
@@ -298,6 +346,9 @@ func (o *ClusterUninstaller) findEC2Instances(ctx context.Context, ec2Client *ec
 							if !deleted.Has(arn) {
 								instanceLogger.Info("Terminated")
 								deleted.Insert(arn)
+								if err := o.journal.RecordDeleted(arn); err != nil {
+									instanceLogger.WithError(err).Debug("could not record deletion in journal")
+								}
 							}
 						} else {
 							resources = append(resources, arn)
@@ -381,36 +432,53 @@ func (o *ClusterUninstaller) findResourcesByTag(
 ) (sets.String, error) {
 	resources := sets.NewString()
 	for _, filter := range o.Filters {
-		o.Logger.Debugf("search for matching resources by tag in %s matching %#+v", *tagClient.Config.Region, filter)
-		tagFilters := make([]*resourcegroupstaggingapi.TagFilter, 0, len(filter))
-		for key, value := range filter {
-			tagFilters = append(tagFilters, &resourcegroupstaggingapi.TagFilter{
-				Key:    aws.String(key),
-				Values: []*string{aws.String(value)},
-			})
-		}
-		err := tagClient.GetResourcesPagesWithContext(
-			ctx,
-			&resourcegroupstaggingapi.GetResourcesInput{TagFilters: tagFilters},
-			func(results *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
-				for _, resource := range results.ResourceTagMappingList {
-					arnString := *resource.ResourceARN
-					if !deleted.Has(arnString) {
-						resources.Insert(arnString)
-					}
-				}
-				return !lastPage
-			},
-		)
+		filterResources, err := o.findResourcesByTagFilter(ctx, tagClient, filter, deleted)
+		resources = resources.Union(filterResources)
 		if err != nil {
-			err = errors.Wrap(err, "get tagged resources")
-			o.Logger.Info(err)
 			return resources, err
 		}
 	}
 	return resources, nil
 }
 
+// findResourcesByTagFilter returns the resources with tags that satisfy a single filter.
+//   deleted - the resources that have already been deleted. Any resources specified in this set will be ignored.
+func (o *ClusterUninstaller) findResourcesByTagFilter(
+	ctx context.Context,
+	tagClient *resourcegroupstaggingapi.ResourceGroupsTaggingAPI,
+	filter Filter,
+	deleted sets.String,
+) (sets.String, error) {
+	resources := sets.NewString()
+	o.Logger.Debugf("search for matching resources by tag in %s matching %#+v", *tagClient.Config.Region, filter)
+	tagFilters := make([]*resourcegroupstaggingapi.TagFilter, 0, len(filter))
+	for key, value := range filter {
+		tagFilters = append(tagFilters, &resourcegroupstaggingapi.TagFilter{
+			Key:    aws.String(key),
+			Values: []*string{aws.String(value)},
+		})
+	}
+	err := tagClient.GetResourcesPagesWithContext(
+		ctx,
+		&resourcegroupstaggingapi.GetResourcesInput{TagFilters: tagFilters},
+		func(results *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+			for _, resource := range results.ResourceTagMappingList {
+				arnString := *resource.ResourceARN
+				if !deleted.Has(arnString) {
+					resources.Insert(arnString)
+				}
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		err = errors.Wrap(err, "get tagged resources")
+		o.Logger.Info(err)
+		return resources, err
+	}
+	return resources, nil
+}
+
 // findIAMRoles returns the IAM roles for the cluster.
 //   deleted - the resources that have already been deleted. Any resources specified in this set will be ignored.
 func (o *ClusterUninstaller) findIAMRoles(ctx context.Context, search *iamRoleSearch, deleted sets.String) (sets.String, error) {
@@ -459,28 +527,127 @@ func (o *ClusterUninstaller) findUntaggableResources(ctx context.Context, iamCli
 
 // deleteResources deletes the specified resources.
 //   resources - the resources to be deleted.
+// The resources are deleted in tiers, so that a resource is only deleted once the resources that may depend on it
+// have been attempted: instances first, then network interfaces, then security groups and the other VPC resources,
+// then the VPC. The resources of each tier are deleted concurrently.
 // The first return is the ARNs of the resources that were successfully deleted
 func (o *ClusterUninstaller) deleteResources(ctx context.Context, awsSession *session.Session, resources []string, tracker *errorTracker) (sets.String, error) {
-	deleted := sets.NewString()
+	tiers := make([][]arn.ARN, numDeletionTiers)
 	for _, arnString := range resources {
-		logger := o.Logger.WithField("arn", arnString)
 		parsedARN, err := arn.Parse(arnString)
 		if err != nil {
-			logger.WithError(err).Debug("could not parse ARN")
+			o.Logger.WithField("arn", arnString).WithError(err).Debug("could not parse ARN")
 			continue
 		}
-		if err := deleteARN(ctx, awsSession, parsedARN, o.Logger); err != nil {
-			tracker.suppressWarning(arnString, err, logger)
-			if err := ctx.Err(); err != nil {
-				return deleted, err
-			}
-			continue
+		tier := deletionTier(parsedARN)
+		tiers[tier] = append(tiers[tier], parsedARN)
+	}
+
+	deleted := sets.NewString()
+	for _, tier := range tiers {
+		newlyDeleted, err := o.deleteConcurrently(ctx, awsSession, tier, tracker)
+		deleted = deleted.Union(newlyDeleted)
+		if err != nil {
+			return deleted, err
 		}
-		deleted.Insert(arnString)
 	}
 	return deleted, nil
 }
 
+// deleteConcurrently deletes the specified resources with up to o.concurrency workers.
+// The first return is the ARNs of the resources that were successfully deleted
+func (o *ClusterUninstaller) deleteConcurrently(ctx context.Context, awsSession *session.Session, resources []arn.ARN, tracker *errorTracker) (sets.String, error) {
+	workers := o.concurrency
+	if workers <= 0 {
+		workers = defaultConcurrency
+	}
+	if workers > len(resources) {
+		workers = len(resources)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		deleted = sets.NewString()
+	)
+	queue := make(chan arn.ARN)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for parsedARN := range queue {
+				arnString := parsedARN.String()
+				logger := o.Logger.WithField("arn", arnString)
+				if err := deleteARN(ctx, awsSession, parsedARN, o.Logger); err != nil {
+					tracker.suppressWarning(arnString, err, logger)
+					if ctx.Err() != nil {
+						continue
+					}
+					if err := o.journal.RecordFailed(arnString, err); err != nil {
+						logger.WithError(err).Debug("could not record failure in journal")
+					}
+					continue
+				}
+				mu.Lock()
+				deleted.Insert(arnString)
+				mu.Unlock()
+				if err := o.journal.RecordDeleted(arnString); err != nil {
+					logger.WithError(err).Debug("could not record deletion in journal")
+				}
+			}
+		}()
+	}
+
+	for _, parsedARN := range resources {
+		if ctx.Err() != nil {
+			break
+		}
+		queue <- parsedARN
+	}
+	close(queue)
+	wg.Wait()
+	return deleted, ctx.Err()
+}
+
+// Deletion tiers, in the order in which they are deleted.
+const (
+	instanceTier = iota
+	defaultTier
+	networkInterfaceTier
+	vpcResourceTier
+	vpcTier
+	vpcOptionsTier
+	numDeletionTiers
+)
+
+// deletionTier returns the tier of a resource. Instances come first, as they may create new resources while they
+// are running. Load balancers and NAT gateways, which own network interfaces, come with the rest of the resources,
+// before the network interfaces, which in turn hold on to security groups and subnets. DHCP options sets cannot be
+// deleted until the VPC they are associated with is.
+func deletionTier(parsedARN arn.ARN) int {
+	if parsedARN.Service != "ec2" {
+		return defaultTier
+	}
+	resourceType, _, err := splitSlash("resource", parsedARN.Resource)
+	if err != nil {
+		return defaultTier
+	}
+	switch resourceType {
+	case "instance":
+		return instanceTier
+	case "network-interface":
+		return networkInterfaceTier
+	case "internet-gateway", "route-table", "security-group", "subnet", "vpc-endpoint", "vpc-peering-connection":
+		return vpcResourceTier
+	case "vpc":
+		return vpcTier
+	case "dhcp-options":
+		return vpcOptionsTier
+	default:
+		return defaultTier
+	}
+}
+
 func splitSlash(name string, input string) (base string, suffix string, err error) {
 	segments := strings.SplitN(input, "/", 2)
 	if len(segments) != 2 {
@@ -2041,29 +2208,17 @@ func removeSharedTag(ctx context.Context, tagClients []*resourcegroupstaggingapi
 		for _, tagClient := range tagClients {
 			logger.Debugf("Search for and remove tags in %s matching %s: shared", *tagClient.Config.Region, key)
 			arns := []string{}
-			err := tagClient.GetResourcesPagesWithContext(
-				ctx,
-				&resourcegroupstaggingapi.GetResourcesInput{TagFilters: []*resourcegroupstaggingapi.TagFilter{{
-					Key:    aws.String(key),
-					Values: []*string{aws.String("shared")},
-				}}},
-				func(results *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
-					for _, resource := range results.ResourceTagMappingList {
-						arn := *resource.ResourceARN
-						if _, ok := removed[arn]; !ok {
-							arns = append(arns, arn)
-						}
-					}
-
-					return !lastPage
-				},
-			)
+			shared, err := findSharedResources(ctx, tagClient, key)
 			if err != nil {
-				err = errors.Wrap(err, "get tagged resources")
 				logger.Info(err)
 				nextTagClients = append(nextTagClients, tagClient)
 				continue
 			}
+			for _, arn := range shared {
+				if _, ok := removed[arn]; !ok {
+					arns = append(arns, arn)
+				}
+			}
 			if len(arns) == 0 {
 				logger.Debugf("No matches in %s for %s: shared, removing client", *tagClient.Config.Region, key)
 				continue
@@ -2093,3 +2248,26 @@ func removeSharedTag(ctx context.Context, tagClients []*resourcegroupstaggingapi
 
 	return nil
 }
+
+// findSharedResources returns the ARNs of the resources tagged with key: shared.
+func findSharedResources(ctx context.Context, tagClient *resourcegroupstaggingapi.ResourceGroupsTaggingAPI, key string) ([]string, error) {
+	arns := []string{}
+	err := tagClient.GetResourcesPagesWithContext(
+		ctx,
+		&resourcegroupstaggingapi.GetResourcesInput{TagFilters: []*resourcegroupstaggingapi.TagFilter{{
+			Key:    aws.String(key),
+			Values: []*string{aws.String("shared")},
+		}}},
+		func(results *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+			for _, resource := range results.ResourceTagMappingList {
+				arns = append(arns, *resource.ResourceARN)
+			}
+
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return arns, errors.Wrap(err, "get tagged resources")
+	}
+	return arns, nil
+}
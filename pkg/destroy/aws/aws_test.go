@@ -0,0 +1,273 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAWS serves the subset of the EC2, ELB and IAM query APIs used to delete
+// instances, network interfaces, security groups, VPCs, classic load balancers
+// and instance profiles. Each service throttles the calls above its rate.
+type mockAWS struct {
+	*httptest.Server
+
+	latency time.Duration
+	rate    float64
+
+	mu        sync.Mutex
+	tokens    map[string]float64
+	refilled  map[string]time.Time
+	calls     []string
+	throttled int64
+}
+
+func newMockAWS(latency time.Duration, rate float64) *mockAWS {
+	m := &mockAWS{
+		latency:  latency,
+		rate:     rate,
+		tokens:   map[string]float64{},
+		refilled: map[string]time.Time{},
+	}
+	m.Server = httptest.NewServer(http.HandlerFunc(m.serve))
+	return m
+}
+
+// services maps the API versions to the services.
+var services = map[string]string{
+	"2016-11-15": "ec2",
+	"2012-06-01": "elb",
+	"2015-12-01": "elbv2",
+	"2010-05-08": "iam",
+}
+
+// take returns true if a call to the service is allowed by its token bucket,
+// which holds up to a tenth of a second of calls.
+func (m *mockAWS) take(service string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	burst := m.rate / 10
+	tokens, ok := m.tokens[service]
+	if !ok {
+		tokens = burst
+	} else {
+		tokens += now.Sub(m.refilled[service]).Seconds() * m.rate
+		if tokens > burst {
+			tokens = burst
+		}
+	}
+	m.refilled[service] = now
+	if tokens < 1 {
+		m.tokens[service] = tokens
+		return false
+	}
+	m.tokens[service] = tokens - 1
+	return true
+}
+
+func (m *mockAWS) serve(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	action := r.Form.Get("Action")
+	service := services[r.Form.Get("Version")]
+	time.Sleep(m.latency)
+
+	if !m.take(service) {
+		atomic.AddInt64(&m.throttled, 1)
+		if service == "ec2" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `<Response><Errors><Error><Code>RequestLimitExceeded</Code><Message>Request limit exceeded.</Message></Error></Errors><RequestID>1</RequestID></Response>`)
+		} else {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>Throttling</Code><Message>Rate exceeded</Message></Error><RequestId>1</RequestId></ErrorResponse>`)
+		}
+		return
+	}
+
+	m.mu.Lock()
+	m.calls = append(m.calls, action)
+	m.mu.Unlock()
+
+	if service == "ec2" {
+		var body string
+		switch action {
+		case "DescribeInstances":
+			body = fmt.Sprintf(`<reservationSet><item><instancesSet><item><instanceId>%s</instanceId><instanceState><code>16</code><name>running</name></instanceState></item></instancesSet></item></reservationSet>`, r.Form.Get("InstanceId.1"))
+		case "DescribeSecurityGroups":
+			if id := r.Form.Get("GroupId.1"); id != "" {
+				body = fmt.Sprintf(`<securityGroupInfo><item><groupId>%s</groupId><groupName>%s</groupName></item></securityGroupInfo>`, id, id)
+			}
+		}
+		fmt.Fprintf(w, `<%sResponse><requestId>1</requestId>%s</%sResponse>`, action, body, action)
+		return
+	}
+
+	var result string
+	if action == "GetInstanceProfile" {
+		result = fmt.Sprintf(`<InstanceProfile><InstanceProfileName>%s</InstanceProfileName><Roles></Roles></InstanceProfile>`, r.Form.Get("InstanceProfileName"))
+	}
+	fmt.Fprintf(w, `<%sResponse><%sResult>%s</%sResult><ResponseMetadata><RequestId>1</RequestId></ResponseMetadata></%sResponse>`, action, action, result, action, action)
+}
+
+func (m *mockAWS) session(t testing.TB) *session.Session {
+	config := aws.NewConfig().
+		WithRegion("us-east-1").
+		WithEndpoint(m.URL).
+		WithCredentials(credentials.NewStaticCredentials("id", "secret", ""))
+	config = request.WithRetryer(config, client.DefaultRetryer{
+		NumMaxRetries:    10,
+		MinRetryDelay:    time.Millisecond,
+		MaxRetryDelay:    50 * time.Millisecond,
+		MinThrottleDelay: time.Millisecond,
+		MaxThrottleDelay: 50 * time.Millisecond,
+	})
+	awsSession, err := session.NewSession(config)
+	require.NoError(t, err)
+	return awsSession
+}
+
+// clusterResources returns the ARNs of a cluster with count of each kind of
+// resource in a single VPC.
+func clusterResources(count int) []string {
+	var resources []string
+	for i := 0; i < count; i++ {
+		resources = append(resources,
+			fmt.Sprintf("arn:aws:ec2:us-east-1:123456789012:instance/i-%d", i),
+			fmt.Sprintf("arn:aws:ec2:us-east-1:123456789012:network-interface/eni-%d", i),
+			fmt.Sprintf("arn:aws:ec2:us-east-1:123456789012:security-group/sg-%d", i),
+			fmt.Sprintf("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/lb-%d", i),
+			fmt.Sprintf("arn:aws:iam::123456789012:instance-profile/profile-%d", i),
+		)
+	}
+	return append(resources, "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0")
+}
+
+func testLogger() logrus.FieldLogger {
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	return logger
+}
+
+func TestDeletionTier(t *testing.T) {
+	cases := []struct {
+		arn      string
+		expected int
+	}{
+		{arn: "arn:aws:ec2:us-east-1:123456789012:instance/i-0123", expected: instanceTier},
+		{arn: "arn:aws:ec2:us-east-1:123456789012:natgateway/nat-0123", expected: defaultTier},
+		{arn: "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/name/0123", expected: defaultTier},
+		{arn: "arn:aws:ec2:us-east-1:123456789012:network-interface/eni-0123", expected: networkInterfaceTier},
+		{arn: "arn:aws:ec2:us-east-1:123456789012:security-group/sg-0123", expected: vpcResourceTier},
+		{arn: "arn:aws:ec2:us-east-1:123456789012:subnet/subnet-0123", expected: vpcResourceTier},
+		{arn: "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0123", expected: vpcTier},
+		{arn: "arn:aws:ec2:us-east-1:123456789012:dhcp-options/dopt-0123", expected: vpcOptionsTier},
+		{arn: "arn:aws:s3:::bucket-name", expected: defaultTier},
+	}
+	for _, tc := range cases {
+		t.Run(tc.arn, func(t *testing.T) {
+			parsed, err := arn.Parse(tc.arn)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, deletionTier(parsed))
+		})
+	}
+}
+
+func TestDeleteResources(t *testing.T) {
+	server := newMockAWS(time.Millisecond, 50)
+	defer server.Close()
+
+	o := &ClusterUninstaller{
+		Logger:      testLogger(),
+		Session:     server.session(t),
+		concurrency: 8,
+		limiter:     newRateLimiter(map[string]float64{"ec2": 200, "elasticloadbalancing": 200, "iam": 200}),
+	}
+	awsSession, err := o.session()
+	require.NoError(t, err)
+
+	resources := clusterResources(10)
+	deleted, err := o.deleteResources(context.Background(), awsSession, resources, new(errorTracker))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, resources, deleted.UnsortedList())
+	assert.NotZero(t, atomic.LoadInt64(&server.throttled), "the mock should have throttled some calls")
+
+	// Instances are terminated before network interfaces are deleted, and
+	// those before security groups and the VPC.
+	order := []string{"TerminateInstances", "DeleteNetworkInterface", "DeleteSecurityGroup", "DeleteVpc"}
+	last := map[string]int{}
+	first := map[string]int{}
+	for i, call := range server.calls {
+		if _, ok := first[call]; !ok {
+			first[call] = i
+		}
+		last[call] = i
+	}
+	for i := 1; i < len(order); i++ {
+		assert.Less(t, last[order[i-1]], first[order[i]], "%s after %s", order[i-1], order[i])
+	}
+}
+
+func TestDeleteResourcesCanceled(t *testing.T) {
+	server := newMockAWS(10*time.Millisecond, 1000)
+	defer server.Close()
+
+	o := &ClusterUninstaller{
+		Logger:  testLogger(),
+		Session: server.session(t),
+	}
+	awsSession, err := o.session()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	resources := clusterResources(20)
+	deleted, err := o.deleteResources(ctx, awsSession, resources, new(errorTracker))
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Less(t, deleted.Len(), len(resources))
+}
+
+// BenchmarkDeleteResources deletes a cluster's resources from a mock API with
+// realistic latency and throttling, with increasing numbers of workers.
+func BenchmarkDeleteResources(b *testing.B) {
+	resources := clusterResources(20)
+	for _, workers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			server := newMockAWS(20*time.Millisecond, 100)
+			defer server.Close()
+
+			for i := 0; i < b.N; i++ {
+				o := &ClusterUninstaller{
+					Logger:      testLogger(),
+					Session:     server.session(b),
+					concurrency: workers,
+					limiter:     newRateLimiter(map[string]float64{"ec2": 200, "elasticloadbalancing": 200, "iam": 200}),
+				}
+				awsSession, err := o.session()
+				require.NoError(b, err)
+				deleted, err := o.deleteResources(context.Background(), awsSession, resources, new(errorTracker))
+				require.NoError(b, err)
+				require.Equal(b, len(resources), deleted.Len())
+			}
+			b.ReportMetric(float64(atomic.LoadInt64(&server.throttled))/float64(b.N), "throttled/op")
+		})
+	}
+}
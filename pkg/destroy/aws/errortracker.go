@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -10,13 +11,16 @@ const (
 	suppressDuration = time.Minute * 5
 )
 
-// errorTracker holds a history of errors
+// errorTracker holds a history of errors. It is safe for concurrent use.
 type errorTracker struct {
+	mu      sync.Mutex
 	history map[string]time.Time
 }
 
 // suppressWarning logs errors WARN once every duration and the rest to DEBUG
 func (o *errorTracker) suppressWarning(identifier string, err error, logger logrus.FieldLogger) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 	if o.history == nil {
 		o.history = map[string]time.Time{}
 	}
@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/iam"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+// Inventory returns the resources that Run would delete or untag, without
+// modifying any of them.
+func (o *ClusterUninstaller) Inventory(ctx context.Context) ([]providers.Resource, error) {
+	err := o.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	awsSession, err := o.session()
+	if err != nil {
+		return nil, err
+	}
+	tagClients := o.tagClients(awsSession)
+	iamClient := iam.New(awsSession)
+
+	found := map[string]providers.Resource{}
+	add := func(arns sets.String, filter string, ownership providers.Ownership) {
+		for _, arnString := range arns.UnsortedList() {
+			if _, ok := found[arnString]; ok {
+				continue
+			}
+			found[arnString] = providers.Resource{
+				Type:      resourceType(arnString),
+				ID:        arnString,
+				Filter:    filter,
+				Ownership: ownership,
+			}
+		}
+	}
+
+	var errs []error
+	none := sets.NewString()
+	for _, tagClient := range tagClients {
+		for _, filter := range o.Filters {
+			resources, err := o.findResourcesByTagFilter(ctx, tagClient, filter, none)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			add(resources, filterString(filter), providers.Owned)
+		}
+	}
+
+	filters := make([]string, 0, len(o.Filters))
+	for _, filter := range o.Filters {
+		filters = append(filters, filterString(filter))
+	}
+	anyFilter := strings.Join(filters, " or ")
+
+	roles, err := o.findIAMRoles(ctx, &iamRoleSearch{client: iamClient, filters: o.Filters, logger: o.Logger}, none)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	add(roles, anyFilter, providers.Owned)
+
+	users, err := o.findIAMUsers(ctx, &iamUserSearch{client: iamClient, filters: o.Filters, logger: o.Logger}, none)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	add(users, anyFilter, providers.Owned)
+
+	untaggable, err := o.findUntaggableResources(ctx, iamClient, none)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	add(untaggable, fmt.Sprintf("name=%s-*-profile", o.ClusterID), providers.Owned)
+
+	for _, filter := range o.Filters {
+		for key, value := range filter {
+			if !strings.HasPrefix(key, clusterTagPrefix) || value != "owned" {
+				continue
+			}
+			for _, tagClient := range tagClients {
+				shared, err := findSharedResources(ctx, tagClient, key)
+				if err != nil {
+					errs = append(errs, err)
+				}
+				add(sets.NewString(shared...), fmt.Sprintf("%s=shared", key), providers.Shared)
+			}
+		}
+	}
+
+	resources := make([]providers.Resource, 0, len(found))
+	for _, resource := range found {
+		resources = append(resources, resource)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Type != resources[j].Type {
+			return resources[i].Type < resources[j].Type
+		}
+		return resources[i].ID < resources[j].ID
+	})
+	return resources, utilerrors.NewAggregate(errs)
+}
+
+// filterString returns a stable, human-readable form of the filter.
+func filterString(filter Filter) string {
+	pairs := make([]string, 0, len(filter))
+	for key, value := range filter {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// resourceType returns the service and resource type of an ARN, for example
+// "ec2:instance" for arn:aws:ec2:us-east-1:123456789012:instance/i-0123.
+func resourceType(arnString string) string {
+	parsed, err := arn.Parse(arnString)
+	if err != nil {
+		return "unknown"
+	}
+	if i := strings.IndexAny(parsed.Resource, "/:"); i >= 0 {
+		return fmt.Sprintf("%s:%s", parsed.Service, parsed.Resource[:i])
+	}
+	return parsed.Service
+}
@@ -0,0 +1,33 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceType(t *testing.T) {
+	cases := []struct {
+		arn      string
+		expected string
+	}{
+		{arn: "arn:aws:ec2:us-east-1:123456789012:instance/i-0123456789abcdef0", expected: "ec2:instance"},
+		{arn: "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/name/0123", expected: "elasticloadbalancing:loadbalancer"},
+		{arn: "arn:aws:route53:::hostedzone/Z0123", expected: "route53:hostedzone"},
+		{arn: "arn:aws:s3:::bucket-name", expected: "s3"},
+		{arn: "not-an-arn", expected: "unknown"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.arn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, resourceType(tc.arn))
+		})
+	}
+}
+
+func TestFilterString(t *testing.T) {
+	filter := Filter{
+		"openshiftClusterID":                 "0123",
+		"kubernetes.io/cluster/cluster-abcd": "owned",
+	}
+	assert.Equal(t, "kubernetes.io/cluster/cluster-abcd=owned,openshiftClusterID=0123", filterString(filter))
+}
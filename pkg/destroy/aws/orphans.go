@@ -0,0 +1,265 @@
+package aws
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	awssession "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+const clusterTagPrefix = "kubernetes.io/cluster/"
+
+// FindOrphans finds the OpenShift clusters in the region with resources
+// tagged kubernetes.io/cluster/<infraID>=owned. A cluster's age is taken from
+// its oldest EC2 instance, volume, NAT gateway, image or snapshot.
+func FindOrphans(ctx context.Context, logger logrus.FieldLogger, opts providers.OrphanOptions) ([]providers.Orphan, error) {
+	if opts.Region == "" {
+		return nil, errors.New("a region is required to find orphaned clusters")
+	}
+	awsSession, err := awssession.GetSessionWithOptions(awssession.WithRegion(opts.Region))
+	if err != nil {
+		return nil, err
+	}
+
+	infraIDs, err := findOwnedInfraIDs(ctx, logger, resourcegroupstaggingapi.New(awsSession))
+	if err != nil {
+		return nil, err
+	}
+	if infraIDs.Len() == 0 {
+		return nil, nil
+	}
+
+	created, err := findEC2CreationTimes(ctx, ec2.New(awsSession), infraIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	orphans := make([]providers.Orphan, 0, infraIDs.Len())
+	for _, infraID := range infraIDs.List() {
+		logger.Debugf("Found resources owned by cluster %s", infraID)
+		orphans = append(orphans, providers.Orphan{
+			Metadata: &types.ClusterMetadata{
+				InfraID: infraID,
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+					AWS: &awstypes.Metadata{
+						Region: opts.Region,
+						Identifier: []map[string]string{{
+							clusterTagPrefix + infraID: "owned",
+						}},
+					},
+				},
+			},
+			Created: created[infraID],
+		})
+	}
+	return orphans, nil
+}
+
+// findOwnedInfraIDs returns the infrastructure IDs of the cluster tag keys
+// which have the value "owned" on at least one resource. Other Kubernetes
+// distributions use the same tag, so a cluster is only returned if one of its
+// resources also has a Name tag starting with the infrastructure ID, as the
+// installer gives them.
+func findOwnedInfraIDs(ctx context.Context, logger logrus.FieldLogger, tagClient resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI) (sets.String, error) {
+	var keys []string
+	err := tagClient.GetTagKeysPagesWithContext(
+		ctx,
+		&resourcegroupstaggingapi.GetTagKeysInput{},
+		func(results *resourcegroupstaggingapi.GetTagKeysOutput, lastPage bool) bool {
+			for _, key := range results.TagKeys {
+				if strings.HasPrefix(*key, clusterTagPrefix) {
+					keys = append(keys, *key)
+				}
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "get tag keys")
+	}
+
+	infraIDs := sets.NewString()
+	for _, key := range keys {
+		owned := false
+		err = tagClient.GetTagValuesPagesWithContext(
+			ctx,
+			&resourcegroupstaggingapi.GetTagValuesInput{Key: aws.String(key)},
+			func(results *resourcegroupstaggingapi.GetTagValuesOutput, lastPage bool) bool {
+				for _, value := range results.TagValues {
+					if *value == "owned" {
+						owned = true
+						return false
+					}
+				}
+				return !lastPage
+			},
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get tag values for %s", key)
+		}
+		if !owned {
+			continue
+		}
+
+		infraID := strings.TrimPrefix(key, clusterTagPrefix)
+		named, err := hasInstallerName(ctx, tagClient, infraID)
+		if err != nil {
+			return nil, err
+		}
+		if !named {
+			logger.Debugf("Skipping cluster %s: none of its resources are named by the installer", infraID)
+			continue
+		}
+		infraIDs.Insert(infraID)
+	}
+	return infraIDs, nil
+}
+
+// hasInstallerName returns true if a resource owned by the cluster has a Name
+// tag of the form <infraID>-<name>.
+func hasInstallerName(ctx context.Context, tagClient resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI, infraID string) (bool, error) {
+	named := false
+	err := tagClient.GetResourcesPagesWithContext(
+		ctx,
+		&resourcegroupstaggingapi.GetResourcesInput{
+			TagFilters: []*resourcegroupstaggingapi.TagFilter{{
+				Key:    aws.String(clusterTagPrefix + infraID),
+				Values: aws.StringSlice([]string{"owned"}),
+			}},
+		},
+		func(results *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+			for _, resource := range results.ResourceTagMappingList {
+				for _, tag := range resource.Tags {
+					if aws.StringValue(tag.Key) == "Name" && strings.HasPrefix(aws.StringValue(tag.Value), infraID+"-") {
+						named = true
+						return false
+					}
+				}
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return false, errors.Wrapf(err, "get resources owned by %s", infraID)
+	}
+	return named, nil
+}
+
+// findEC2CreationTimes returns the creation time of the oldest instance,
+// volume, NAT gateway, image or snapshot owned by each of the clusters. The
+// NAT gateways, image and snapshot are looked at so that clusters whose
+// instances were already deleted are still found.
+func findEC2CreationTimes(ctx context.Context, ec2Client ec2iface.EC2API, infraIDs sets.String) (map[string]time.Time, error) {
+	created := map[string]time.Time{}
+	record := func(tags []*ec2.Tag, t *time.Time) {
+		if t == nil {
+			return
+		}
+		for _, tag := range tags {
+			if tag.Key == nil || tag.Value == nil || *tag.Value != "owned" || !strings.HasPrefix(*tag.Key, clusterTagPrefix) {
+				continue
+			}
+			infraID := strings.TrimPrefix(*tag.Key, clusterTagPrefix)
+			if !infraIDs.Has(infraID) {
+				continue
+			}
+			if previous, ok := created[infraID]; !ok || t.Before(previous) {
+				created[infraID] = *t
+			}
+		}
+	}
+	filters := []*ec2.Filter{{
+		Name:   aws.String("tag-key"),
+		Values: []*string{aws.String(clusterTagPrefix + "*")},
+	}}
+
+	err := ec2Client.DescribeInstancesPagesWithContext(
+		ctx,
+		&ec2.DescribeInstancesInput{Filters: filters},
+		func(results *ec2.DescribeInstancesOutput, lastPage bool) bool {
+			for _, reservation := range results.Reservations {
+				for _, instance := range reservation.Instances {
+					record(instance.Tags, instance.LaunchTime)
+				}
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "describe instances")
+	}
+
+	err = ec2Client.DescribeVolumesPagesWithContext(
+		ctx,
+		&ec2.DescribeVolumesInput{Filters: filters},
+		func(results *ec2.DescribeVolumesOutput, lastPage bool) bool {
+			for _, volume := range results.Volumes {
+				record(volume.Tags, volume.CreateTime)
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "describe volumes")
+	}
+
+	err = ec2Client.DescribeNatGatewaysPagesWithContext(
+		ctx,
+		&ec2.DescribeNatGatewaysInput{Filter: filters},
+		func(results *ec2.DescribeNatGatewaysOutput, lastPage bool) bool {
+			for _, gateway := range results.NatGateways {
+				record(gateway.Tags, gateway.CreateTime)
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "describe NAT gateways")
+	}
+
+	images, err := ec2Client.DescribeImagesWithContext(ctx, &ec2.DescribeImagesInput{
+		Owners:  aws.StringSlice([]string{"self"}),
+		Filters: filters,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describe images")
+	}
+	for _, image := range images.Images {
+		t, err := time.Parse(time.RFC3339, aws.StringValue(image.CreationDate))
+		if err != nil {
+			continue
+		}
+		record(image.Tags, &t)
+	}
+
+	err = ec2Client.DescribeSnapshotsPagesWithContext(
+		ctx,
+		&ec2.DescribeSnapshotsInput{
+			OwnerIds: aws.StringSlice([]string{"self"}),
+			Filters:  filters,
+		},
+		func(results *ec2.DescribeSnapshotsOutput, lastPage bool) bool {
+			for _, snapshot := range results.Snapshots {
+				record(snapshot.Tags, snapshot.StartTime)
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "describe snapshots")
+	}
+	return created, nil
+}
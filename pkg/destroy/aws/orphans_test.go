@@ -0,0 +1,183 @@
+package aws
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// fakeTagging serves the resources in a region through the tagging API.
+type fakeTagging struct {
+	resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+
+	// resources maps resource ARNs to their tags.
+	resources map[string]map[string]string
+}
+
+func (f *fakeTagging) GetTagKeysPagesWithContext(_ aws.Context, _ *resourcegroupstaggingapi.GetTagKeysInput, fn func(*resourcegroupstaggingapi.GetTagKeysOutput, bool) bool, _ ...request.Option) error {
+	keys := sets.NewString()
+	for _, tags := range f.resources {
+		for key := range tags {
+			keys.Insert(key)
+		}
+	}
+	fn(&resourcegroupstaggingapi.GetTagKeysOutput{TagKeys: aws.StringSlice(keys.List())}, true)
+	return nil
+}
+
+func (f *fakeTagging) GetTagValuesPagesWithContext(_ aws.Context, input *resourcegroupstaggingapi.GetTagValuesInput, fn func(*resourcegroupstaggingapi.GetTagValuesOutput, bool) bool, _ ...request.Option) error {
+	values := sets.NewString()
+	for _, tags := range f.resources {
+		if value, ok := tags[*input.Key]; ok {
+			values.Insert(value)
+		}
+	}
+	fn(&resourcegroupstaggingapi.GetTagValuesOutput{TagValues: aws.StringSlice(values.List())}, true)
+	return nil
+}
+
+func (f *fakeTagging) GetResourcesPagesWithContext(_ aws.Context, input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool, _ ...request.Option) error {
+	output := &resourcegroupstaggingapi.GetResourcesOutput{}
+	for arn, tags := range f.resources {
+		matches := true
+		for _, filter := range input.TagFilters {
+			if value, ok := tags[*filter.Key]; !ok || !sets.NewString(aws.StringValueSlice(filter.Values)...).Has(value) {
+				matches = false
+			}
+		}
+		if !matches {
+			continue
+		}
+		mapping := &resourcegroupstaggingapi.ResourceTagMapping{ResourceARN: aws.String(arn)}
+		for key, value := range tags {
+			mapping.Tags = append(mapping.Tags, &resourcegroupstaggingapi.Tag{Key: aws.String(key), Value: aws.String(value)})
+		}
+		output.ResourceTagMappingList = append(output.ResourceTagMappingList, mapping)
+	}
+	fn(output, true)
+	return nil
+}
+
+// fakeEC2 serves EC2 resources, ignoring the filters in the requests.
+type fakeEC2 struct {
+	ec2iface.EC2API
+
+	instances   []*ec2.Instance
+	volumes     []*ec2.Volume
+	natGateways []*ec2.NatGateway
+	images      []*ec2.Image
+	snapshots   []*ec2.Snapshot
+}
+
+func (f *fakeEC2) DescribeInstancesPagesWithContext(_ aws.Context, _ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+	fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: f.instances}}}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeVolumesPagesWithContext(_ aws.Context, _ *ec2.DescribeVolumesInput, fn func(*ec2.DescribeVolumesOutput, bool) bool, _ ...request.Option) error {
+	fn(&ec2.DescribeVolumesOutput{Volumes: f.volumes}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeNatGatewaysPagesWithContext(_ aws.Context, _ *ec2.DescribeNatGatewaysInput, fn func(*ec2.DescribeNatGatewaysOutput, bool) bool, _ ...request.Option) error {
+	fn(&ec2.DescribeNatGatewaysOutput{NatGateways: f.natGateways}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeImagesWithContext(_ aws.Context, _ *ec2.DescribeImagesInput, _ ...request.Option) (*ec2.DescribeImagesOutput, error) {
+	return &ec2.DescribeImagesOutput{Images: f.images}, nil
+}
+
+func (f *fakeEC2) DescribeSnapshotsPagesWithContext(_ aws.Context, _ *ec2.DescribeSnapshotsInput, fn func(*ec2.DescribeSnapshotsOutput, bool) bool, _ ...request.Option) error {
+	fn(&ec2.DescribeSnapshotsOutput{Snapshots: f.snapshots}, true)
+	return nil
+}
+
+func ec2Tags(tags map[string]string) []*ec2.Tag {
+	var ec2Tags []*ec2.Tag
+	for key, value := range tags {
+		ec2Tags = append(ec2Tags, &ec2.Tag{Key: aws.String(key), Value: aws.String(value)})
+	}
+	return ec2Tags
+}
+
+func TestFindOwnedInfraIDs(t *testing.T) {
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	tagging := &fakeTagging{resources: map[string]map[string]string{
+		"arn:aws:ec2:us-east-1:1234:vpc/vpc-1": {
+			"kubernetes.io/cluster/cluster-abcde": "owned",
+			"Name":                                "cluster-abcde-vpc",
+		},
+		"arn:aws:ec2:us-east-1:1234:subnet/subnet-1": {
+			"kubernetes.io/cluster/cluster-abcde": "owned",
+		},
+		// Shared resources do not make a cluster.
+		"arn:aws:ec2:us-east-1:1234:vpc/vpc-2": {
+			"kubernetes.io/cluster/shared-fghij": "shared",
+			"Name":                               "shared-fghij-vpc",
+		},
+		// Other Kubernetes distributions use the cluster tag too, but
+		// not the installer's names.
+		"arn:aws:ec2:us-east-1:1234:instance/i-1": {
+			"kubernetes.io/cluster/kops.example.com": "owned",
+			"Name":                                   "nodes.kops.example.com",
+		},
+		"arn:aws:ec2:us-east-1:1234:volume/vol-1": {
+			"kubernetes.io/cluster/eks": "owned",
+		},
+	}}
+
+	infraIDs, err := findOwnedInfraIDs(context.Background(), logger, tagging)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"cluster-abcde"}, infraIDs.List())
+	}
+}
+
+func TestFindEC2CreationTimes(t *testing.T) {
+	oldest := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := oldest.Add(time.Hour)
+	owned := func(infraID string) []*ec2.Tag {
+		return ec2Tags(map[string]string{"kubernetes.io/cluster/" + infraID: "owned"})
+	}
+	client := &fakeEC2{
+		instances: []*ec2.Instance{
+			{Tags: owned("with-instances"), LaunchTime: aws.Time(later)},
+			{Tags: owned("unknown"), LaunchTime: aws.Time(oldest)},
+		},
+		volumes: []*ec2.Volume{
+			{Tags: owned("with-instances"), CreateTime: aws.Time(oldest)},
+		},
+		natGateways: []*ec2.NatGateway{
+			{Tags: owned("without-instances"), CreateTime: aws.Time(later)},
+			{Tags: ec2Tags(map[string]string{"kubernetes.io/cluster/shared": "shared"}), CreateTime: aws.Time(oldest)},
+		},
+		images: []*ec2.Image{
+			{Tags: owned("without-instances"), CreationDate: aws.String("2020-01-01T00:00:00.000Z")},
+			{Tags: owned("image-only"), CreationDate: aws.String("not a date")},
+		},
+		snapshots: []*ec2.Snapshot{
+			{Tags: owned("snapshot-only"), StartTime: aws.Time(later)},
+		},
+	}
+
+	created, err := findEC2CreationTimes(context.Background(), client, sets.NewString("with-instances", "without-instances", "image-only", "snapshot-only", "shared"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]time.Time{
+			"with-instances":    oldest,
+			"without-instances": oldest,
+			"snapshot-only":     later,
+		}, created)
+	}
+}
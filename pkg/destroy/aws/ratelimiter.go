@@ -0,0 +1,154 @@
+package aws
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+const (
+	// defaultServiceRate is the rate, in calls per second, at which services
+	// without an entry in defaultServiceRates are called.
+	defaultServiceRate = 10
+
+	// maxCallInterval is the longest that throttling slows the calls to a
+	// service down to.
+	maxCallInterval = 5 * time.Second
+)
+
+// defaultServiceRates are the rates, in calls per second, at which each
+// service is called while nothing is being throttled. AWS throttles per account
+// and region, so they leave room for the account's other clients.
+var defaultServiceRates = map[string]float64{
+	"ec2":                  20,
+	"elasticloadbalancing": 10,
+	"iam":                  10,
+	"route53":              4,
+	"s3":                   50,
+	"tagging":              10,
+}
+
+// rateLimiter spaces the calls to each AWS service. The calls to a service
+// are slowed down each time the service throttles one of them, and sped back
+// up to the service's rate as calls succeed.
+type rateLimiter struct {
+	rates map[string]float64
+
+	mu       sync.Mutex
+	services map[string]*serviceLimiter
+}
+
+func newRateLimiter(rates map[string]float64) *rateLimiter {
+	return &rateLimiter{
+		rates:    rates,
+		services: map[string]*serviceLimiter{},
+	}
+}
+
+// install adds the handlers that limit the calls to the handlers of a
+// session or client, replacing those of any previously installed limiter.
+func (l *rateLimiter) install(handlers *request.Handlers) {
+	handlers.Sign.SetFrontNamed(request.NamedHandler{
+		Name: "openshiftInstaller.RateLimitHandler",
+		Fn: func(r *request.Request) {
+			if err := l.service(r.ClientInfo.ServiceName).wait(r.Context()); err != nil {
+				r.Error = awserr.New(request.CanceledErrorCode, "request context canceled", err)
+			}
+		},
+	})
+	handlers.CompleteAttempt.SetBackNamed(request.NamedHandler{
+		Name: "openshiftInstaller.RateLimitFeedbackHandler",
+		Fn: func(r *request.Request) {
+			service := l.service(r.ClientInfo.ServiceName)
+			switch {
+			case request.IsErrorThrottle(r.Error):
+				service.throttled()
+			case r.Error == nil:
+				service.succeeded()
+			}
+		},
+	})
+}
+
+func (l *rateLimiter) service(name string) *serviceLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	service, ok := l.services[name]
+	if !ok {
+		rate, ok := l.rates[name]
+		if !ok || rate <= 0 {
+			rate = defaultServiceRate
+		}
+		interval := time.Duration(float64(time.Second) / rate)
+		service = &serviceLimiter{base: interval, interval: interval}
+		l.services[name] = service
+	}
+	return service
+}
+
+// serviceLimiter spaces the calls to a single service.
+type serviceLimiter struct {
+	mu sync.Mutex
+	// base is the interval between calls while nothing is being throttled.
+	base time.Duration
+	// interval is the current interval between calls.
+	interval time.Duration
+	// next is the time at which the next call may be made.
+	next time.Time
+	// slowed is the time at which the interval was last increased.
+	slowed time.Time
+}
+
+// wait blocks until the next call may be made, or the context is done.
+func (s *serviceLimiter) wait(ctx context.Context) error {
+	s.mu.Lock()
+	now := time.Now()
+	at := s.next
+	if at.Before(now) {
+		at = now
+	}
+	s.next = at.Add(s.interval)
+	s.mu.Unlock()
+
+	delay := at.Sub(now)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// throttled doubles the interval between calls. The calls made at the old
+// interval may still be throttled, so the interval is doubled at most once per
+// interval.
+func (s *serviceLimiter) throttled() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if now.Sub(s.slowed) < s.interval {
+		return
+	}
+	s.slowed = now
+	s.interval *= 2
+	if s.interval > maxCallInterval {
+		s.interval = maxCallInterval
+	}
+	s.next = now.Add(s.interval)
+}
+
+// succeeded moves the interval between calls a quarter of the way back to the
+// base interval.
+func (s *serviceLimiter) succeeded() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.interval -= (s.interval - s.base) / 4
+}
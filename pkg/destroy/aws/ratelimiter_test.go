@@ -0,0 +1,46 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceLimiter(t *testing.T) {
+	l := newRateLimiter(map[string]float64{"ec2": 100})
+	ec2 := l.service("ec2")
+	assert.Equal(t, 10*time.Millisecond, ec2.interval)
+	assert.Equal(t, time.Second/defaultServiceRate, l.service("route53").interval)
+	assert.Same(t, ec2, l.service("ec2"))
+
+	ec2.throttled()
+	assert.Equal(t, 20*time.Millisecond, ec2.interval)
+	ec2.throttled()
+	assert.Equal(t, 20*time.Millisecond, ec2.interval, "throttled twice within the interval")
+	for i := 0; i < 10; i++ {
+		ec2.slowed = time.Time{}
+		ec2.throttled()
+	}
+	assert.Equal(t, maxCallInterval, ec2.interval)
+
+	for i := 0; i < 50; i++ {
+		ec2.succeeded()
+	}
+	assert.InDelta(t, 10*time.Millisecond, ec2.interval, float64(time.Millisecond))
+}
+
+func TestServiceLimiterWait(t *testing.T) {
+	s := &serviceLimiter{base: 20 * time.Millisecond, interval: 20 * time.Millisecond}
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, s.wait(context.Background()))
+	}
+	assert.True(t, time.Since(start) >= 60*time.Millisecond, "waited %s", time.Since(start))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.throttled()
+	assert.Equal(t, context.Canceled, s.wait(ctx))
+}
@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	azuresession "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	"github.com/openshift/installer/pkg/destroy/journal"
 	"github.com/openshift/installer/pkg/destroy/providers"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/azure"
@@ -40,6 +41,9 @@ type ClusterUninstaller struct {
 
 	Logger logrus.FieldLogger
 
+	// journal, if set, records the deleted and failed resources by ID.
+	journal *journal.Journal
+
 	resourceGroupsClient    resources.GroupsClient
 	zonesClient             dns.ZonesClient
 	recordsClient           dns.RecordSetsClient
@@ -100,8 +104,16 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 	}, nil
 }
 
+// SetJournal sets the journal used to record the uninstall. Deleted
+// resources are not found again, so the journal records the outcome of each
+// deletion, which is reported when the uninstall fails, but nothing is
+// skipped on resume.
+func (o *ClusterUninstaller) SetJournal(j *journal.Journal) {
+	o.journal = j
+}
+
 // Run is the entrypoint to start the uninstall process.
-func (o *ClusterUninstaller) Run() error {
+func (o *ClusterUninstaller) Run(ctx context.Context) error {
 	var errs []error
 	var err error
 
@@ -109,14 +121,14 @@ func (o *ClusterUninstaller) Run() error {
 
 	// 2 hours
 	timeout := 120 * time.Minute
-	waitCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	wait.UntilWithContext(
 		waitCtx,
 		func(ctx context.Context) {
 			o.Logger.Debugf("deleting public records")
-			err = deletePublicRecords(ctx, o.zonesClient, o.recordsClient, o.privateZonesClient, o.privateRecordSetsClient, o.Logger, o.ResourceGroupName)
+			err = deletePublicRecords(ctx, o.zonesClient, o.recordsClient, o.privateZonesClient, o.privateRecordSetsClient, o.journal, o.Logger, o.ResourceGroupName)
 			if err != nil {
 				o.Logger.Debug(err)
 				if isAuthError(err) {
@@ -134,18 +146,21 @@ func (o *ClusterUninstaller) Run() error {
 		errs = append(errs, errors.Wrap(err, "failed to delete public DNS records"))
 		o.Logger.Debug(err)
 	}
+	if err := ctx.Err(); err != nil {
+		return utilerrors.NewAggregate(append(errs, err))
+	}
 
 	deadline, _ := waitCtx.Deadline()
 	diff := time.Until(deadline)
 	if diff > 0 {
-		waitCtx, cancel = context.WithTimeout(context.Background(), diff)
+		waitCtx, cancel = context.WithTimeout(ctx, diff)
 	}
 
 	wait.UntilWithContext(
 		waitCtx,
 		func(ctx context.Context) {
 			o.Logger.Debugf("deleting resource group")
-			err = deleteResourceGroup(ctx, o.resourceGroupsClient, o.Logger, o.ResourceGroupName)
+			err = deleteResourceGroup(ctx, o.resourceGroupsClient, o.journal, o.Logger, o.ResourceGroupName)
 			if err != nil {
 				o.Logger.Debug(err)
 				if isAuthError(err) {
@@ -163,18 +178,21 @@ func (o *ClusterUninstaller) Run() error {
 		errs = append(errs, errors.Wrap(err, "failed to delete resource group"))
 		o.Logger.Debug(err)
 	}
+	if err := ctx.Err(); err != nil {
+		return utilerrors.NewAggregate(append(errs, err))
+	}
 
 	deadline, _ = waitCtx.Deadline()
 	diff = time.Until(deadline)
 	if diff > 0 {
-		waitCtx, cancel = context.WithTimeout(context.Background(), diff)
+		waitCtx, cancel = context.WithTimeout(ctx, diff)
 	}
 
 	wait.UntilWithContext(
 		waitCtx,
 		func(ctx context.Context) {
 			o.Logger.Debugf("deleting application registrations")
-			err = deleteApplicationRegistrations(ctx, o.applicationsClient, o.serviceprincipalsClient, o.Logger, o.InfraID)
+			err = deleteApplicationRegistrations(ctx, o.applicationsClient, o.serviceprincipalsClient, o.journal, o.Logger, o.InfraID)
 			if err != nil {
 				o.Logger.Debug(err)
 				if isAuthError(err) {
@@ -192,11 +210,14 @@ func (o *ClusterUninstaller) Run() error {
 		errs = append(errs, errors.Wrap(err, "failed to delete application registrations and their service principals"))
 		o.Logger.Debug(err)
 	}
+	if err := ctx.Err(); err != nil {
+		return utilerrors.NewAggregate(append(errs, err))
+	}
 
 	return utilerrors.NewAggregate(errs)
 }
 
-func deletePublicRecords(ctx context.Context, dnsClient dns.ZonesClient, recordsClient dns.RecordSetsClient, privateDNSClient privatedns.PrivateZonesClient, privateRecordsClient privatedns.RecordSetsClient, logger logrus.FieldLogger, rgName string) error {
+func deletePublicRecords(ctx context.Context, dnsClient dns.ZonesClient, recordsClient dns.RecordSetsClient, privateDNSClient privatedns.PrivateZonesClient, privateRecordsClient privatedns.RecordSetsClient, j *journal.Journal, logger logrus.FieldLogger, rgName string) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancel()
 
@@ -223,7 +244,7 @@ func deletePublicRecords(ctx context.Context, dnsClient dns.ZonesClient, records
 
 		for _, zone := range zonesPage.Values() {
 			if zone.ZoneType == dns.Private {
-				if err := deletePublicRecordsForZone(ctx, dnsClient, recordsClient, logger, rgName, to.String(zone.Name)); err != nil {
+				if err := deletePublicRecordsForZone(ctx, dnsClient, recordsClient, j, logger, rgName, to.String(zone.Name)); err != nil {
 					errs = append(errs, errors.Wrapf(err, "failed to delete public records for %s", to.String(zone.Name)))
 					if isAuthError(err) {
 						return err
@@ -253,7 +274,7 @@ func deletePublicRecords(ctx context.Context, dnsClient dns.ZonesClient, records
 		}
 
 		for _, zone := range privateZonesPage.Values() {
-			if err := deletePublicRecordsForPrivateZone(ctx, privateRecordsClient, dnsClient, recordsClient, logger, rgName, to.String(zone.Name)); err != nil {
+			if err := deletePublicRecordsForPrivateZone(ctx, privateRecordsClient, dnsClient, recordsClient, j, logger, rgName, to.String(zone.Name)); err != nil {
 				errs = append(errs, errors.Wrapf(err, "failed to delete public records for %s", to.String(zone.Name)))
 				if isAuthError(err) {
 					return err
@@ -266,7 +287,7 @@ func deletePublicRecords(ctx context.Context, dnsClient dns.ZonesClient, records
 	return utilerrors.NewAggregate(errs)
 }
 
-func deletePublicRecordsForZone(ctx context.Context, dnsClient dns.ZonesClient, recordsClient dns.RecordSetsClient, logger logrus.FieldLogger, zoneGroup, zoneName string) error {
+func deletePublicRecordsForZone(ctx context.Context, dnsClient dns.ZonesClient, recordsClient dns.RecordSetsClient, j *journal.Journal, logger logrus.FieldLogger, zoneGroup, zoneName string) error {
 	// collect all the records from the zoneName
 	allPrivateRecords := sets.NewString()
 	for recordPages, err := recordsClient.ListByDNSZone(ctx, zoneGroup, zoneName, to.Int32Ptr(100), ""); recordPages.NotDone(); err = recordPages.NextWithContext(ctx) {
@@ -281,10 +302,10 @@ func deletePublicRecordsForZone(ctx context.Context, dnsClient dns.ZonesClient,
 		}
 	}
 
-	return deletePublicRecordsMatchingZoneName(ctx, dnsClient, recordsClient, logger, allPrivateRecords, zoneName)
+	return deletePublicRecordsMatchingZoneName(ctx, dnsClient, recordsClient, j, logger, allPrivateRecords, zoneName)
 }
 
-func deletePublicRecordsForPrivateZone(ctx context.Context, privateRecordsClient privatedns.RecordSetsClient, dnsClient dns.ZonesClient, recordsClient dns.RecordSetsClient, logger logrus.FieldLogger, zoneGroup, zoneName string) error {
+func deletePublicRecordsForPrivateZone(ctx context.Context, privateRecordsClient privatedns.RecordSetsClient, dnsClient dns.ZonesClient, recordsClient dns.RecordSetsClient, j *journal.Journal, logger logrus.FieldLogger, zoneGroup, zoneName string) error {
 	// collect all the records from the zoneName
 	allPrivateRecords := sets.NewString()
 	for recordPages, err := privateRecordsClient.List(ctx, zoneGroup, zoneName, to.Int32Ptr(100), ""); recordPages.NotDone(); err = recordPages.NextWithContext(ctx) {
@@ -299,10 +320,10 @@ func deletePublicRecordsForPrivateZone(ctx context.Context, privateRecordsClient
 		}
 	}
 
-	return deletePublicRecordsMatchingZoneName(ctx, dnsClient, recordsClient, logger, allPrivateRecords, zoneName)
+	return deletePublicRecordsMatchingZoneName(ctx, dnsClient, recordsClient, j, logger, allPrivateRecords, zoneName)
 }
 
-func deletePublicRecordsMatchingZoneName(ctx context.Context, dnsClient dns.ZonesClient, recordsClient dns.RecordSetsClient, logger logrus.FieldLogger, privateRecords sets.String, zoneName string) error {
+func deletePublicRecordsMatchingZoneName(ctx context.Context, dnsClient dns.ZonesClient, recordsClient dns.RecordSetsClient, j *journal.Journal, logger logrus.FieldLogger, privateRecords sets.String, zoneName string) error {
 	sharedZones, err := getSharedDNSZones(ctx, dnsClient, zoneName)
 	if err != nil {
 		return errors.Wrapf(err, "failed to find shared zone for %s", zoneName)
@@ -315,15 +336,19 @@ func deletePublicRecordsMatchingZoneName(ctx context.Context, dnsClient dns.Zone
 			}
 			for _, record := range recordPages.Values() {
 				if privateRecords.Has(fmt.Sprintf("%s.%s", to.String(record.Name), sharedZone.Name)) {
+					recordLogger := logger.WithField("record", to.String(record.Name))
 					resp, err := recordsClient.Delete(ctx, sharedZone.Group, sharedZone.Name, to.String(record.Name), toRecordType(to.String(record.Type)), "")
 					if err != nil {
 						if wasNotFound(resp.Response) {
-							logger.WithField("record", to.String(record.Name)).Debug("already deleted")
+							recordLogger.Debug("already deleted")
 							continue
 						}
-						return errors.Wrapf(err, "failed to delete record %s in zone %s", to.String(record.Name), sharedZone.Name)
+						err = errors.Wrapf(err, "failed to delete record %s in zone %s", to.String(record.Name), sharedZone.Name)
+						recordFailed(ctx, j, recordLogger, to.String(record.ID), err)
+						return err
 					}
-					logger.WithField("record", to.String(record.Name)).Info("deleted")
+					recordDeleted(j, recordLogger, to.String(record.ID))
+					recordLogger.Info("deleted")
 				}
 			}
 		}
@@ -377,8 +402,9 @@ func toRecordType(t string) dns.RecordType {
 	return dns.RecordType(strings.TrimPrefix(t, "Microsoft.Network/dnszones/"))
 }
 
-func deleteResourceGroup(ctx context.Context, client resources.GroupsClient, logger logrus.FieldLogger, name string) error {
+func deleteResourceGroup(ctx context.Context, client resources.GroupsClient, j *journal.Journal, logger logrus.FieldLogger, name string) error {
 	logger = logger.WithField("resource group", name)
+	id := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", client.SubscriptionID, name)
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
 	defer cancel()
 
@@ -388,6 +414,7 @@ func deleteResourceGroup(ctx context.Context, client resources.GroupsClient, log
 			logger.Debug("already deleted")
 			return nil
 		}
+		recordFailed(ctx, j, logger, id, err)
 		return err
 	}
 
@@ -397,12 +424,34 @@ func deleteResourceGroup(ctx context.Context, client resources.GroupsClient, log
 			logger.Debug("already deleted")
 			return nil
 		}
-		return errors.Wrapf(err, "failed to delete %s", name)
+		err = errors.Wrapf(err, "failed to delete %s", name)
+		recordFailed(ctx, j, logger, id, err)
+		return err
 	}
+	recordDeleted(j, logger, id)
 	logger.Info("deleted")
 	return nil
 }
 
+// recordDeleted records in the journal that the resource with the given ID
+// was deleted.
+func recordDeleted(j *journal.Journal, logger logrus.FieldLogger, id string) {
+	if err := j.RecordDeleted(id); err != nil {
+		logger.WithError(err).Debug("could not record deletion in journal")
+	}
+}
+
+// recordFailed records in the journal that an attempt to delete the resource
+// with the given ID failed, unless the attempt was interrupted.
+func recordFailed(ctx context.Context, j *journal.Journal, logger logrus.FieldLogger, id string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	if err := j.RecordFailed(id, err); err != nil {
+		logger.WithError(err).Debug("could not record failure in journal")
+	}
+}
+
 func wasNotFound(resp *http.Response) bool {
 	return resp != nil && resp.StatusCode == http.StatusNotFound
 }
@@ -425,7 +474,7 @@ func isAuthError(err error) bool {
 	return false
 }
 
-func deleteApplicationRegistrations(ctx context.Context, appClient graphrbac.ApplicationsClient, spClient graphrbac.ServicePrincipalsClient, logger logrus.FieldLogger, infraID string) error {
+func deleteApplicationRegistrations(ctx context.Context, appClient graphrbac.ApplicationsClient, spClient graphrbac.ServicePrincipalsClient, j *journal.Journal, logger logrus.FieldLogger, infraID string) error {
 	errorList := []error{}
 
 	tag := fmt.Sprintf("kubernetes.io_cluster.%s=owned", infraID)
@@ -458,12 +507,14 @@ func deleteApplicationRegistrations(ctx context.Context, appClient graphrbac.App
 
 		_, err = appClient.Delete(ctx, *apps[0].ObjectID)
 		if err != nil {
+			recordFailed(ctx, j, logger, *sp.AppID, err)
 			if isAuthError(err) {
 				return err
 			}
 			errorList = append(errorList, err)
 			continue
 		}
+		recordDeleted(j, logger, *sp.AppID)
 		logger.Info("deleted")
 	}
 
@@ -0,0 +1,161 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/dns/mgmt/2018-03-01-preview/dns"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+// Inventory returns the resources that Run would delete, without deleting
+// any of them.
+func (o *ClusterUninstaller) Inventory(ctx context.Context) ([]providers.Resource, error) {
+	o.configureClients()
+
+	groupFilter := fmt.Sprintf("resource group %s", o.ResourceGroupName)
+	resourcesClient := resources.NewClientWithBaseURI(o.Environment.ResourceManagerEndpoint, o.SubscriptionID)
+	resourcesClient.Authorizer = o.Authorizer
+
+	var found []providers.Resource
+	group, err := o.resourceGroupsClient.Get(ctx, o.ResourceGroupName)
+	if err != nil {
+		if !wasNotFound(group.Response.Response) {
+			return nil, errors.Wrapf(err, "failed to get resource group %s", o.ResourceGroupName)
+		}
+	} else {
+		found = append(found, providers.Resource{
+			Type:      "Microsoft.Resources/resourceGroups",
+			ID:        to.String(group.ID),
+			Filter:    groupFilter,
+			Ownership: providers.Owned,
+		})
+		for page, err := resourcesClient.ListByResourceGroup(ctx, o.ResourceGroupName, "", "", nil); page.NotDone(); err = page.NextWithContext(ctx) {
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to list resources in %s", o.ResourceGroupName)
+			}
+			for _, resource := range page.Values() {
+				found = append(found, providers.Resource{
+					Type:      to.String(resource.Type),
+					ID:        to.String(resource.ID),
+					Filter:    groupFilter,
+					Ownership: providers.Owned,
+				})
+			}
+		}
+
+		records, err := o.publicRecordInventory(ctx)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, records...)
+	}
+
+	tag := fmt.Sprintf("kubernetes.io_cluster.%s=owned", o.InfraID)
+	servicePrincipals, err := getServicePrincipalsByTag(ctx, o.serviceprincipalsClient, tag, o.InfraID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to gather list of Service Principals by tag")
+	}
+	for _, sp := range servicePrincipals {
+		found = append(found, providers.Resource{
+			Type:      "Microsoft.Graph/applications",
+			ID:        to.String(sp.AppID),
+			Filter:    fmt.Sprintf("tag %s", tag),
+			Ownership: providers.Owned,
+		})
+	}
+
+	return found, nil
+}
+
+// publicRecordInventory returns the records in shared public zones that
+// match records in the cluster's private zones, which deletePublicRecords
+// would delete.
+func (o *ClusterUninstaller) publicRecordInventory(ctx context.Context) ([]providers.Resource, error) {
+	privateRecords := map[string]sets.String{}
+
+	for zonesPage, err := o.zonesClient.ListByResourceGroup(ctx, o.ResourceGroupName, to.Int32Ptr(100)); zonesPage.NotDone(); err = zonesPage.NextWithContext(ctx) {
+		if err != nil {
+			if zonesPage.Response().IsHTTPStatus(http.StatusNotFound) {
+				return nil, nil
+			}
+			return nil, errors.Wrap(err, "failed to list dns zones")
+		}
+		for _, zone := range zonesPage.Values() {
+			if zone.ZoneType != dns.Private {
+				continue
+			}
+			zoneName := to.String(zone.Name)
+			privateRecords[zoneName] = sets.NewString()
+			for recordPages, err := o.recordsClient.ListByDNSZone(ctx, o.ResourceGroupName, zoneName, to.Int32Ptr(100), ""); recordPages.NotDone(); err = recordPages.NextWithContext(ctx) {
+				if err != nil {
+					return nil, err
+				}
+				for _, record := range recordPages.Values() {
+					if t := toRecordType(to.String(record.Type)); t == dns.SOA || t == dns.NS {
+						continue
+					}
+					privateRecords[zoneName].Insert(fmt.Sprintf("%s.%s", to.String(record.Name), zoneName))
+				}
+			}
+		}
+	}
+
+	for zonesPage, err := o.privateZonesClient.ListByResourceGroup(ctx, o.ResourceGroupName, to.Int32Ptr(100)); zonesPage.NotDone(); err = zonesPage.NextWithContext(ctx) {
+		if err != nil {
+			if zonesPage.Response().IsHTTPStatus(http.StatusNotFound) {
+				return nil, nil
+			}
+			return nil, errors.Wrap(err, "failed to list private dns zones")
+		}
+		for _, zone := range zonesPage.Values() {
+			zoneName := to.String(zone.Name)
+			if _, ok := privateRecords[zoneName]; !ok {
+				privateRecords[zoneName] = sets.NewString()
+			}
+			for recordPages, err := o.privateRecordSetsClient.List(ctx, o.ResourceGroupName, zoneName, to.Int32Ptr(100), ""); recordPages.NotDone(); err = recordPages.NextWithContext(ctx) {
+				if err != nil {
+					return nil, err
+				}
+				for _, record := range recordPages.Values() {
+					if t := toRecordType(to.String(record.Type)); t == dns.SOA || t == dns.NS {
+						continue
+					}
+					privateRecords[zoneName].Insert(fmt.Sprintf("%s.%s", to.String(record.Name), zoneName))
+				}
+			}
+		}
+	}
+
+	var found []providers.Resource
+	for zoneName, records := range privateRecords {
+		sharedZones, err := getSharedDNSZones(ctx, o.zonesClient, zoneName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find shared zone for %s", zoneName)
+		}
+		for _, sharedZone := range sharedZones {
+			for recordPages, err := o.recordsClient.ListByDNSZone(ctx, sharedZone.Group, sharedZone.Name, to.Int32Ptr(100), ""); recordPages.NotDone(); err = recordPages.NextWithContext(ctx) {
+				if err != nil {
+					return nil, err
+				}
+				for _, record := range recordPages.Values() {
+					if records.Has(fmt.Sprintf("%s.%s", to.String(record.Name), sharedZone.Name)) {
+						found = append(found, providers.Resource{
+							Type:      to.String(record.Type),
+							ID:        to.String(record.ID),
+							Filter:    fmt.Sprintf("record in private zone %s", zoneName),
+							Ownership: providers.Owned,
+						})
+					}
+				}
+			}
+		}
+	}
+	return found, nil
+}
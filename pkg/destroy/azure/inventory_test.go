@@ -0,0 +1,109 @@
+package azure
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	azureenv "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+// fakeAzure serves canned responses for the API paths that it knows and
+// 404s for all the others.
+func fakeAzure(t *testing.T, responses map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		body, ok := responses[strings.ToLower(r.URL.Path)]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"code": "NotFound"}}`))
+			return
+		}
+		w.Write([]byte(body))
+	}))
+}
+
+func newFakeUninstaller(server *httptest.Server) *ClusterUninstaller {
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	return &ClusterUninstaller{
+		SubscriptionID:    "sub",
+		TenantID:          "tenant",
+		GraphAuthorizer:   autorest.NullAuthorizer{},
+		Authorizer:        autorest.NullAuthorizer{},
+		Environment:       azureenv.Environment{ResourceManagerEndpoint: server.URL, GraphEndpoint: server.URL},
+		InfraID:           "cluster-abcde",
+		ResourceGroupName: "cluster-abcde-rg",
+		Logger:            logger,
+	}
+}
+
+func TestInventory(t *testing.T) {
+	server := fakeAzure(t, map[string]string{
+		"/subscriptions/sub/resourcegroups/cluster-abcde-rg": `{"id": "/subscriptions/sub/resourceGroups/cluster-abcde-rg", "name": "cluster-abcde-rg"}`,
+		"/subscriptions/sub/resourcegroups/cluster-abcde-rg/resources": `{"value": [
+			{"id": "/subscriptions/sub/resourceGroups/cluster-abcde-rg/providers/Microsoft.Compute/virtualMachines/cluster-abcde-master-0", "type": "Microsoft.Compute/virtualMachines"}
+		]}`,
+		"/subscriptions/sub/resourcegroups/cluster-abcde-rg/providers/microsoft.network/privatednszones": `{"value": [
+			{"id": "/subscriptions/sub/resourceGroups/cluster-abcde-rg/providers/Microsoft.Network/privateDnsZones/cluster.example.com", "name": "cluster.example.com"}
+		]}`,
+		"/subscriptions/sub/resourcegroups/cluster-abcde-rg/providers/microsoft.network/privatednszones/cluster.example.com/all": `{"value": [
+			{"name": "@", "type": "Microsoft.Network/privateDnsZones/SOA"},
+			{"name": "api", "type": "Microsoft.Network/privateDnsZones/A"}
+		]}`,
+		"/subscriptions/sub/providers/microsoft.network/dnszones": `{"value": [
+			{"id": "/subscriptions/sub/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com", "name": "example.com", "properties": {"zoneType": "Public"}}
+		]}`,
+		"/subscriptions/sub/resourcegroups/dns-rg/providers/microsoft.network/dnszones/example.com/recordsets": `{"value": [
+			{"id": "/subscriptions/sub/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com/A/api.cluster", "name": "api.cluster", "type": "Microsoft.Network/dnszones/A"},
+			{"id": "/subscriptions/sub/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com/A/www", "name": "www", "type": "Microsoft.Network/dnszones/A"}
+		]}`,
+		"/tenant/serviceprincipals": `{"value": [
+			{"appId": "0000-1111", "displayName": "cluster-abcde-identity", "tags": ["kubernetes.io_cluster.cluster-abcde=owned"]},
+			{"appId": "2222-3333", "displayName": "cluster-abcde-other", "tags": []}
+		]}`,
+	})
+	defer server.Close()
+
+	resources, err := newFakeUninstaller(server).Inventory(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	groupFilter := "resource group cluster-abcde-rg"
+	assert.Equal(t, []providers.Resource{
+		{Type: "Microsoft.Resources/resourceGroups", ID: "/subscriptions/sub/resourceGroups/cluster-abcde-rg", Filter: groupFilter, Ownership: providers.Owned},
+		{Type: "Microsoft.Compute/virtualMachines", ID: "/subscriptions/sub/resourceGroups/cluster-abcde-rg/providers/Microsoft.Compute/virtualMachines/cluster-abcde-master-0", Filter: groupFilter, Ownership: providers.Owned},
+		{Type: "Microsoft.Network/dnszones/A", ID: "/subscriptions/sub/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com/A/api.cluster", Filter: "record in private zone cluster.example.com", Ownership: providers.Owned},
+		{Type: "Microsoft.Graph/applications", ID: "0000-1111", Filter: "tag kubernetes.io_cluster.cluster-abcde=owned", Ownership: providers.Owned},
+	}, resources)
+}
+
+func TestInventoryMissingResourceGroup(t *testing.T) {
+	server := fakeAzure(t, map[string]string{
+		"/tenant/serviceprincipals": `{"value": [
+			{"appId": "0000-1111", "displayName": "cluster-abcde-identity", "tags": ["kubernetes.io_cluster.cluster-abcde=owned"]}
+		]}`,
+	})
+	defer server.Close()
+
+	resources, err := newFakeUninstaller(server).Inventory(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []providers.Resource{
+		{Type: "Microsoft.Graph/applications", ID: "0000-1111", Filter: "tag kubernetes.io_cluster.cluster-abcde=owned", Ownership: providers.Owned},
+	}, resources)
+}
@@ -0,0 +1,68 @@
+package azure
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/installer/pkg/destroy/journal"
+)
+
+func TestDeleteResourceGroupJournal(t *testing.T) {
+	const groupID = "/subscriptions/sub/resourceGroups/cluster-abcde-rg"
+	cases := []struct {
+		name     string
+		status   int
+		deleted  []string
+		failures []string
+	}{
+		{
+			name:    "deleted",
+			status:  http.StatusOK,
+			deleted: []string{groupID},
+		},
+		{
+			name:     "failed",
+			status:   http.StatusConflict,
+			failures: []string{groupID},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != "/subscriptions/sub/resourcegroups/cluster-abcde-rg" {
+					t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				w.Write([]byte("{}"))
+			}))
+			defer server.Close()
+
+			dir, err := ioutil.TempDir("", "journal")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+			j, err := journal.Open(dir)
+			require.NoError(t, err)
+			defer j.Close()
+
+			o := newFakeUninstaller(server)
+			o.configureClients()
+			err = deleteResourceGroup(context.Background(), o.resourceGroupsClient, j, o.Logger, o.ResourceGroupName)
+			assert.Equal(t, tc.failures == nil, err == nil, "unexpected error: %v", err)
+
+			assert.Equal(t, tc.deleted, j.Deleted())
+			var failures []string
+			for _, f := range j.Failures() {
+				failures = append(failures, f.ID)
+			}
+			assert.Equal(t, tc.failures, failures)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package azure
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	azuresession "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/azure"
+)
+
+const clusterTagPrefix = "kubernetes.io_cluster."
+
+// FindOrphans finds the clusters whose resource groups are tagged
+// kubernetes.io_cluster.<infraID>=owned. A cluster's age is taken from the
+// oldest resource in its resource group. If a region is given, only
+// resource groups in that region are considered.
+func FindOrphans(ctx context.Context, logger logrus.FieldLogger, opts providers.OrphanOptions) ([]providers.Orphan, error) {
+	session, err := azuresession.GetSession(azure.PublicCloud)
+	if err != nil {
+		return nil, err
+	}
+
+	groupsClient := resources.NewGroupsClientWithBaseURI(session.Environment.ResourceManagerEndpoint, session.Credentials.SubscriptionID)
+	groupsClient.Authorizer = session.Authorizer
+	resourcesClient := resources.NewClientWithBaseURI(session.Environment.ResourceManagerEndpoint, session.Credentials.SubscriptionID)
+	resourcesClient.Authorizer = session.Authorizer
+
+	var orphans []providers.Orphan
+	for page, err := groupsClient.List(ctx, "", nil); page.NotDone(); err = page.NextWithContext(ctx) {
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list resource groups")
+		}
+		for _, group := range page.Values() {
+			location := to.String(group.Location)
+			if opts.Region != "" && !strings.EqualFold(location, opts.Region) {
+				continue
+			}
+			infraID := ownedInfraID(group.Tags)
+			if infraID == "" {
+				continue
+			}
+
+			name := to.String(group.Name)
+			logger.Debugf("Found resource group %s owned by cluster %s", name, infraID)
+			created, err := oldestResource(ctx, resourcesClient, name)
+			if err != nil {
+				return nil, err
+			}
+			orphans = append(orphans, providers.Orphan{
+				Metadata: &types.ClusterMetadata{
+					InfraID: infraID,
+					ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+						Azure: &azure.Metadata{
+							CloudName:         azure.PublicCloud,
+							Region:            location,
+							ResourceGroupName: name,
+						},
+					},
+				},
+				Created: created,
+			})
+		}
+	}
+	return orphans, nil
+}
+
+// ownedInfraID returns the infra ID from a kubernetes.io_cluster.<infraID>
+// tag with the value "owned", or an empty string if there is none.
+func ownedInfraID(tags map[string]*string) string {
+	for key, value := range tags {
+		if strings.HasPrefix(key, clusterTagPrefix) && to.String(value) == "owned" {
+			return strings.TrimPrefix(key, clusterTagPrefix)
+		}
+	}
+	return ""
+}
+
+// oldestResource returns the creation time of the oldest resource in the
+// resource group, or the zero time if the group is empty.
+func oldestResource(ctx context.Context, client resources.Client, group string) (time.Time, error) {
+	var oldest time.Time
+	for page, err := client.ListByResourceGroup(ctx, group, "", "createdTime", nil); page.NotDone(); err = page.NextWithContext(ctx) {
+		if err != nil {
+			return time.Time{}, errors.Wrapf(err, "failed to list resources in %s", group)
+		}
+		for _, resource := range page.Values() {
+			if resource.CreatedTime == nil {
+				continue
+			}
+			if created := resource.CreatedTime.ToTime(); oldest.IsZero() || created.Before(oldest) {
+				oldest = created
+			}
+		}
+	}
+	return oldest, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/openshift/installer/pkg/asset/cluster"
 	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
 )
 
 // New returns a Destroyer based on `metadata.json` in `rootDir`.
@@ -14,7 +15,11 @@ func New(logger logrus.FieldLogger, rootDir string) (providers.Destroyer, error)
 	if err != nil {
 		return nil, err
 	}
+	return NewFromMetadata(logger, metadata)
+}
 
+// NewFromMetadata returns a Destroyer for the cluster described by metadata.
+func NewFromMetadata(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.Destroyer, error) {
 	platform := metadata.Platform()
 	if platform == "" {
 		return nil, errors.New("no platform configured in metadata")
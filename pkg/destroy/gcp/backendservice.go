@@ -59,6 +59,7 @@ func (o *ClusterUninstaller) deleteBackendService(item cloudResource) error {
 	if (err != nil && isNoOp(err)) || (op != nil && op.Status == "DONE") {
 		o.resetRequestID(item.typeName, item.name)
 		o.deletePendingItems(item.typeName, []cloudResource{item})
+		o.recordDeleted(item)
 		o.Logger.Infof("Deleted backend service %s", item.name)
 	}
 	return nil
@@ -76,6 +77,7 @@ func (o *ClusterUninstaller) destroyBackendServices() error {
 		err := o.deleteBackendService(item)
 		if err != nil {
 			o.errorTracker.suppressWarning(item.key, err, o.Logger)
+			o.recordFailed(item, err)
 		}
 	}
 	if items = o.getPendingItems("backendservice"); len(items) > 0 {
@@ -60,6 +60,7 @@ func (o *ClusterUninstaller) deleteBucket(item cloudResource) error {
 		return errors.Wrapf(err, "failed to delete bucket %s", item.name)
 	}
 	o.deletePendingItems(item.typeName, []cloudResource{item})
+	o.recordDeleted(item)
 	o.Logger.Infof("Deleted bucket %s", item.name)
 	return nil
 }
@@ -82,11 +83,13 @@ func (o *ClusterUninstaller) destroyBuckets() error {
 			err = o.deleteBucketObject(item, object)
 			if err != nil {
 				o.errorTracker.suppressWarning(object.key, err, o.Logger)
+				o.recordFailed(object, err)
 			}
 		}
 		err = o.deleteBucket(item)
 		if err != nil {
 			o.errorTracker.suppressWarning(item.key, err, o.Logger)
+			o.recordFailed(item, err)
 		}
 	}
 	if items = o.getPendingItems("bucket"); len(items) > 0 {
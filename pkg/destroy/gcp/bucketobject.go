@@ -38,6 +38,7 @@ func (o *ClusterUninstaller) deleteBucketObject(bucket cloudResource, item cloud
 		return errors.Wrapf(err, "failed to delete bucket object %s/%s", bucket.name, item.name)
 	}
 	o.deletePendingItems(item.typeName, []cloudResource{item})
+	o.recordDeleted(item)
 	o.Logger.Infof("Deleted bucket object %s", item.name)
 	return nil
 }
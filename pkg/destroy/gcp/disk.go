@@ -65,6 +65,7 @@ func (o *ClusterUninstaller) deleteDisk(item cloudResource) error {
 	if (err != nil && isNoOp(err)) || (op != nil && op.Status == "DONE") {
 		o.resetRequestID(item.typeName, item.name)
 		o.deletePendingItems(item.typeName, []cloudResource{item})
+		o.recordDeleted(item)
 		o.Logger.Infof("Deleted disk %s", item.name)
 	}
 	return nil
@@ -82,6 +83,7 @@ func (o *ClusterUninstaller) destroyDisks() error {
 		err := o.deleteDisk(item)
 		if err != nil {
 			o.errorTracker.suppressWarning(item.key, err, o.Logger)
+			o.recordFailed(item, err)
 		}
 	}
 	if items = o.getPendingItems("disk"); len(items) > 0 {
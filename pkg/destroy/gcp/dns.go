@@ -43,12 +43,16 @@ func (o *ClusterUninstaller) listDNSZones() (private *dnsZone, public []dnsZone,
 
 func (o *ClusterUninstaller) deleteDNSZone(name string) error {
 	o.Logger.Debugf("Deleting DNS zones %s", name)
+	zone := cloudResource{key: name, name: name, typeName: "dnszone"}
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 	err := o.dnsSvc.ManagedZones.Delete(o.ProjectID, name).Context(ctx).Do()
 	if err != nil && !isNoOp(err) {
-		return errors.Wrapf(err, "failed to delete DNS zone %s", name)
+		err = errors.Wrapf(err, "failed to delete DNS zone %s", name)
+		o.recordFailed(zone, err)
+		return err
 	}
+	o.recordDeleted(zone)
 	o.Logger.Infof("Deleted DNS zone %s", name)
 	return nil
 }
@@ -22,6 +22,7 @@ import (
 	storage "google.golang.org/api/storage/v1"
 
 	gcpconfig "github.com/openshift/installer/pkg/asset/installconfig/gcp"
+	"github.com/openshift/installer/pkg/destroy/journal"
 	"github.com/openshift/installer/pkg/destroy/providers"
 	"github.com/openshift/installer/pkg/types"
 	gcptypes "github.com/openshift/installer/pkg/types/gcp"
@@ -51,6 +52,9 @@ type ClusterUninstaller struct {
 	// from metadata or by inferring it from existing cluster resources.
 	cloudControllerUID string
 
+	// journal, if set, records the deleted and failed resources.
+	journal *journal.Journal
+
 	errorTracker
 	requestIDTracker
 	pendingItemTracker
@@ -70,8 +74,40 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 	}, nil
 }
 
+// SetJournal sets the journal used to record the uninstall. GCP resources
+// are listed again on every pass and deleted ones are not found again, so
+// the journal records the outcome of each deletion, which is reported when
+// the uninstall fails, but nothing is skipped on resume.
+func (o *ClusterUninstaller) SetJournal(j *journal.Journal) {
+	o.journal = j
+}
+
 // Run is the entrypoint to start the uninstall process
-func (o *ClusterUninstaller) Run() error {
+func (o *ClusterUninstaller) Run(ctx context.Context) error {
+	o.Context = ctx
+	err := o.configureClients()
+	if err != nil {
+		return err
+	}
+
+	err = wait.PollImmediateUntil(
+		time.Second*10,
+		o.destroyCluster,
+		ctx.Done(),
+	)
+	if err == wait.ErrWaitTimeout {
+		return ctx.Err()
+	}
+	return err
+}
+
+// configureClients creates the API services used to find and delete resources,
+// unless they have already been created.
+func (o *ClusterUninstaller) configureClients() error {
+	if o.computeSvc != nil {
+		return nil
+	}
+
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
@@ -110,12 +146,7 @@ func (o *ClusterUninstaller) Run() error {
 		return errors.Wrap(err, "failed to create resourcemanager service")
 	}
 
-	err = wait.PollImmediateInfinite(
-		time.Second*10,
-		o.destroyCluster,
-	)
 	return nil
-
 }
 
 func (o *ClusterUninstaller) destroyCluster() (bool, error) {
@@ -151,6 +182,9 @@ func (o *ClusterUninstaller) destroyCluster() (bool, error) {
 	for _, stage := range stagedFuncs {
 		if done {
 			for _, f := range stage {
+				if o.Context.Err() != nil {
+					return false, nil
+				}
 				err := f.execute()
 				if err != nil {
 					o.Logger.Debugf("%s: %v", f.name, err)
@@ -210,6 +244,29 @@ func (o *ClusterUninstaller) contextWithTimeout() (context.Context, context.Canc
 	return context.WithTimeout(o.Context, defaultTimeout)
 }
 
+// journalID returns the journal ID of a resource.
+func journalID(item cloudResource) string {
+	return fmt.Sprintf("gcp:%s/%s", item.typeName, item.key)
+}
+
+// recordDeleted records in the journal that the resource was deleted.
+func (o *ClusterUninstaller) recordDeleted(item cloudResource) {
+	if err := o.journal.RecordDeleted(journalID(item)); err != nil {
+		o.Logger.WithError(err).Debug("could not record deletion in journal")
+	}
+}
+
+// recordFailed records in the journal that an attempt to delete the resource
+// failed, unless the uninstall was interrupted.
+func (o *ClusterUninstaller) recordFailed(item cloudResource, err error) {
+	if o.Context.Err() != nil {
+		return
+	}
+	if err := o.journal.RecordFailed(journalID(item), err); err != nil {
+		o.Logger.WithError(err).Debug("could not record failure in journal")
+	}
+}
+
 // requestIDTracker keeps track of a set of request IDs mapped to a unique resource
 // identifier
 type requestIDTracker struct {
@@ -90,6 +90,7 @@ func (o *ClusterUninstaller) deleteInstance(item cloudResource) error {
 	if (err != nil && isNoOp(err)) || (op != nil && op.Status == "DONE") {
 		o.resetRequestID(item.typeName, item.name)
 		o.deletePendingItems(item.typeName, []cloudResource{item})
+		o.recordDeleted(item)
 		o.Logger.Infof("Deleted instance %s", item.name)
 	}
 	return nil
@@ -108,6 +109,7 @@ func (o *ClusterUninstaller) destroyInstances() error {
 		err := o.deleteInstance(item)
 		if err != nil {
 			errs = append(errs, err)
+			o.recordFailed(item, err)
 		}
 	}
 	items = o.getPendingItems("instance")
@@ -66,6 +66,7 @@ func (o *ClusterUninstaller) deleteInstanceGroup(item cloudResource) error {
 	if (err != nil && isNoOp(err)) || (op != nil && op.Status == "DONE") {
 		o.resetRequestID(item.typeName, item.name)
 		o.deletePendingItems(item.typeName, []cloudResource{item})
+		o.recordDeleted(item)
 		o.Logger.Infof("Deleted instance group %s", item.name)
 	}
 	return nil
@@ -83,6 +84,7 @@ func (o *ClusterUninstaller) destroyInstanceGroups() error {
 		err := o.deleteInstanceGroup(item)
 		if err != nil {
 			o.errorTracker.suppressWarning(item.key, err, o.Logger)
+			o.recordFailed(item, err)
 		}
 	}
 	if items = o.getPendingItems("instancegroup"); len(items) > 0 {
@@ -0,0 +1,185 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+// Inventory returns the resources that Run would delete or modify, without
+// modifying any of them.
+func (o *ClusterUninstaller) Inventory(ctx context.Context) ([]providers.Resource, error) {
+	o.Context = ctx
+	if err := o.configureClients(); err != nil {
+		return nil, err
+	}
+
+	found := map[string]providers.Resource{}
+	add := func(items []cloudResource, filter string, ownership providers.Ownership) {
+		for _, item := range items {
+			key := fmt.Sprintf("%s/%s", item.typeName, item.key)
+			if _, ok := found[key]; ok {
+				continue
+			}
+			found[key] = providers.Resource{
+				Type:      item.typeName,
+				ID:        item.key,
+				Filter:    filter,
+				Ownership: ownership,
+			}
+		}
+	}
+
+	// Resources created by the kube cloud controller are not named after the
+	// infra ID, so they are discovered through the instance groups and
+	// instances they point to.
+	if err := o.discoverCloudControllerResources(); err != nil {
+		return nil, err
+	}
+	add(o.GetAllPendingItems(), fmt.Sprintf("cloud controller UID %s", o.cloudControllerUID), providers.Owned)
+
+	nameFilter := o.clusterIDFilter()
+	listFuncs := []struct {
+		filter  string
+		execute func() ([]cloudResource, error)
+	}{
+		{filter: fmt.Sprintf("%s or %s", nameFilter, o.clusterLabelFilter()), execute: o.listInstances},
+		{filter: nameFilter, execute: o.listDisks},
+		{filter: fmt.Sprintf("email or display name prefix %q", o.ClusterID+"-"), execute: o.listServiceAccounts},
+		{filter: nameFilter, execute: o.listImages},
+		{filter: fmt.Sprintf("name prefix %q", o.ClusterID+"-"), execute: o.listBuckets},
+		{filter: nameFilter, execute: o.listRoutes},
+		{filter: nameFilter, execute: o.listFirewalls},
+		{filter: nameFilter, execute: o.listAddresses},
+		{filter: nameFilter, execute: o.listTargetPools},
+		{filter: nameFilter, execute: o.listInstanceGroups},
+		{filter: nameFilter, execute: o.listForwardingRules},
+		{filter: nameFilter, execute: o.listBackendServices},
+		{filter: nameFilter, execute: o.listHealthChecks},
+		{filter: nameFilter, execute: o.listHTTPHealthChecks},
+		{filter: nameFilter, execute: o.listRouters},
+		{filter: nameFilter, execute: o.listSubnetworks},
+		{filter: nameFilter, execute: o.listNetworks},
+	}
+	for _, f := range listFuncs {
+		items, err := f.execute()
+		if err != nil {
+			return nil, err
+		}
+		add(items, f.filter, providers.Owned)
+	}
+
+	resources := make([]providers.Resource, 0, len(found))
+	for _, resource := range found {
+		resources = append(resources, resource)
+	}
+
+	dnsResources, err := o.dnsInventory()
+	if err != nil {
+		return nil, err
+	}
+	resources = append(resources, dnsResources...)
+
+	bindings, err := o.policyBindingInventory(resources)
+	if err != nil {
+		return nil, err
+	}
+	resources = append(resources, bindings...)
+
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Type != resources[j].Type {
+			return resources[i].Type < resources[j].Type
+		}
+		return resources[i].ID < resources[j].ID
+	})
+	return resources, nil
+}
+
+// dnsInventory returns the cluster's private DNS zone and the records in its
+// public parent zone that destroyDNS would delete.
+func (o *ClusterUninstaller) dnsInventory() ([]providers.Resource, error) {
+	privateZone, publicZones, err := o.listDNSZones()
+	if err != nil {
+		return nil, err
+	}
+	if privateZone == nil {
+		return nil, nil
+	}
+
+	resources := []providers.Resource{{
+		Type:      "dnszone",
+		ID:        privateZone.name,
+		Filter:    fmt.Sprintf("name prefix %q", o.ClusterID+"-"),
+		Ownership: providers.Owned,
+	}}
+
+	parentZone := getParentDNSZone(privateZone.domain, publicZones, o.Logger)
+	if parentZone == nil {
+		return resources, nil
+	}
+	zoneRecordSets, err := o.listDNSZoneRecordSets(privateZone.name)
+	if err != nil {
+		return nil, err
+	}
+	parentRecordSets, err := o.listDNSZoneRecordSets(parentZone.name)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range o.getMatchingRecordSets(parentRecordSets, zoneRecordSets) {
+		resources = append(resources, providers.Resource{
+			Type:      "dnsrecordset",
+			ID:        fmt.Sprintf("%s/%s %s", parentZone.name, record.Type, record.Name),
+			Filter:    fmt.Sprintf("record in zone %s", privateZone.name),
+			Ownership: providers.Owned,
+		})
+	}
+	return resources, nil
+}
+
+// policyBindingInventory returns the project IAM policy bindings that
+// destroyIAMPolicyBindings would remove for the given service accounts.
+func (o *ClusterUninstaller) policyBindingInventory(resources []providers.Resource) ([]providers.Resource, error) {
+	serviceAccounts := sets.NewString()
+	for _, resource := range resources {
+		if resource.Type == "serviceaccount" {
+			serviceAccounts.Insert(resource.ID)
+		}
+	}
+	if serviceAccounts.Len() == 0 {
+		return nil, nil
+	}
+
+	sas, err := o.listClusterServiceAccount()
+	if err != nil {
+		return nil, err
+	}
+	emails := sets.NewString()
+	for _, sa := range sas {
+		if serviceAccounts.Has(sa.Name) {
+			emails.Insert(sa.Email)
+		}
+	}
+
+	policy, err := o.getProjectIAMPolicy()
+	if err != nil {
+		return nil, err
+	}
+	bindings := []providers.Resource{}
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			if emails.Has(policyMemberToEmail(member)) {
+				bindings = append(bindings, providers.Resource{
+					Type:      "iampolicybinding",
+					ID:        fmt.Sprintf("%s %s", binding.Role, member),
+					Filter:    "member is a cluster service account",
+					Ownership: providers.Shared,
+				})
+			}
+		}
+	}
+	return bindings, nil
+}
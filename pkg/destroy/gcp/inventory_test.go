@@ -0,0 +1,136 @@
+package gcp
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	resourcemanager "google.golang.org/api/cloudresourcemanager/v1"
+	compute "google.golang.org/api/compute/v1"
+	dns "google.golang.org/api/dns/v1"
+	iam "google.golang.org/api/iam/v1"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+// fakeGCP serves canned responses for the API paths that it knows and empty
+// lists for all the others. Like the real APIs it filters on the server, but
+// only as far as to drop the responses for filters that do not name the
+// cluster.
+func fakeGCP(t *testing.T, responses map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		filter := r.URL.Query().Get("filter")
+		if body, ok := responses[r.URL.Path]; ok && (filter == "" || strings.Contains(filter, "cluster-abcde")) {
+			w.Write([]byte(body))
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+}
+
+func newFakeUninstaller(t *testing.T, server *httptest.Server) *ClusterUninstaller {
+	ctx := context.Background()
+	options := []option.ClientOption{
+		option.WithEndpoint(server.URL + "/"),
+		option.WithHTTPClient(server.Client()),
+	}
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+
+	o := &ClusterUninstaller{
+		Logger:             logger,
+		Region:             "us-east1",
+		ProjectID:          "project",
+		ClusterID:          "cluster-abcde",
+		Context:            ctx,
+		cloudControllerUID: "0123456789abcdef",
+		requestIDTracker:   newRequestIDTracker(),
+		pendingItemTracker: newPendingItemTracker(),
+	}
+	var err error
+	if o.computeSvc, err = compute.NewService(ctx, options...); err != nil {
+		t.Fatal(err)
+	}
+	if o.iamSvc, err = iam.NewService(ctx, options...); err != nil {
+		t.Fatal(err)
+	}
+	if o.dnsSvc, err = dns.NewService(ctx, options...); err != nil {
+		t.Fatal(err)
+	}
+	if o.storageSvc, err = storage.NewService(ctx, options...); err != nil {
+		t.Fatal(err)
+	}
+	if o.rmSvc, err = resourcemanager.NewService(ctx, options...); err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+func TestInventory(t *testing.T) {
+	server := fakeGCP(t, map[string]string{
+		"/project/aggregated/instances": `{"items": {"zones/us-east1-b": {"instances": [
+			{"name": "cluster-abcde-master-0", "zone": "https://www.googleapis.com/compute/v1/projects/project/zones/us-east1-b", "status": "RUNNING"}
+		]}}}`,
+		"/project/aggregated/disks": `{"items": {"zones/us-east1-b": {"disks": [
+			{"name": "cluster-abcde-master-0", "zone": "https://www.googleapis.com/compute/v1/projects/project/zones/us-east1-b"}
+		]}}}`,
+		"/project/global/firewalls": `{"items": [{"name": "cluster-abcde-api"}]}`,
+		"/v1/projects/project/serviceAccounts": `{"accounts": [
+			{"name": "projects/project/serviceAccounts/cluster-abcde-m@project.iam.gserviceaccount.com", "email": "cluster-abcde-m@project.iam.gserviceaccount.com", "displayName": "cluster-abcde-master-node"},
+			{"name": "projects/project/serviceAccounts/other@project.iam.gserviceaccount.com", "email": "other@project.iam.gserviceaccount.com", "displayName": "other"}
+		]}`,
+		"/v1/projects/project:getIamPolicy": `{"bindings": [
+			{"role": "roles/compute.instanceAdmin", "members": ["serviceAccount:cluster-abcde-m@project.iam.gserviceaccount.com", "serviceAccount:other@project.iam.gserviceaccount.com"]}
+		]}`,
+		"/project/managedZones": `{"managedZones": [
+			{"name": "cluster-abcde-private-zone", "dnsName": "cluster.example.com.", "visibility": "private"},
+			{"name": "example", "dnsName": "example.com.", "visibility": "public"}
+		]}`,
+		"/project/managedZones/cluster-abcde-private-zone/rrsets": `{"rrsets": [
+			{"name": "api.cluster.example.com.", "type": "A"}
+		]}`,
+		"/project/managedZones/example/rrsets": `{"rrsets": [
+			{"name": "api.cluster.example.com.", "type": "A"},
+			{"name": "www.example.com.", "type": "A"}
+		]}`,
+	})
+	defer server.Close()
+
+	resources, err := newFakeUninstaller(t, server).Inventory(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	nameFilter := `name eq "cluster-abcde-.*"`
+	assert.Equal(t, []providers.Resource{
+		{Type: "disk", ID: "us-east1-b/cluster-abcde-master-0", Filter: nameFilter, Ownership: providers.Owned},
+		{Type: "dnsrecordset", ID: "example/A api.cluster.example.com.", Filter: "record in zone cluster-abcde-private-zone", Ownership: providers.Owned},
+		{Type: "dnszone", ID: "cluster-abcde-private-zone", Filter: `name prefix "cluster-abcde-"`, Ownership: providers.Owned},
+		{Type: "firewall", ID: "cluster-abcde-api", Filter: nameFilter, Ownership: providers.Owned},
+		{Type: "iampolicybinding", ID: "roles/compute.instanceAdmin serviceAccount:cluster-abcde-m@project.iam.gserviceaccount.com", Filter: "member is a cluster service account", Ownership: providers.Shared},
+		{Type: "instance", ID: "us-east1-b/cluster-abcde-master-0", Filter: nameFilter + ` or labels.kubernetes-io-cluster-cluster-abcde eq "owned"`, Ownership: providers.Owned},
+		{Type: "serviceaccount", ID: "projects/project/serviceAccounts/cluster-abcde-m@project.iam.gserviceaccount.com", Filter: `email or display name prefix "cluster-abcde-"`, Ownership: providers.Owned},
+	}, resources)
+}
+
+func TestInventoryError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, err := newFakeUninstaller(t, server).Inventory(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package gcp
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/installer/pkg/destroy/journal"
+)
+
+func TestDestroyAddressesJournal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /project/regions/us-east1/addresses":
+			w.Write([]byte(`{"items": [{"name": "cluster-abcde-api"}, {"name": "cluster-abcde-ingress"}]}`))
+		case "DELETE /project/regions/us-east1/addresses/cluster-abcde-api":
+			w.Write([]byte(`{"status": "DONE"}`))
+		case "DELETE /project/regions/us-east1/addresses/cluster-abcde-ingress":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"code": 400, "message": "in use"}}`))
+		default:
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "journal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	j, err := journal.Open(dir)
+	require.NoError(t, err)
+	defer j.Close()
+
+	o := newFakeUninstaller(t, server)
+	o.SetJournal(j)
+	assert.Error(t, o.destroyAddresses())
+
+	assert.Equal(t, []string{"gcp:address/cluster-abcde-api"}, j.Deleted())
+	failures := j.Failures()
+	if assert.Len(t, failures, 1) {
+		assert.Equal(t, "gcp:address/cluster-abcde-ingress", failures[0].ID)
+		assert.Contains(t, failures[0].Error, "in use")
+	}
+}
@@ -60,6 +60,7 @@ func (o *ClusterUninstaller) deleteNetwork(item cloudResource) error {
 	if (err != nil && isNoOp(err)) || (op != nil && op.Status == "DONE") {
 		o.resetRequestID(item.typeName, item.name)
 		o.deletePendingItems(item.typeName, []cloudResource{item})
+		o.recordDeleted(item)
 		o.Logger.Infof("Deleted network %s", item.name)
 	}
 	return nil
@@ -77,6 +78,7 @@ func (o *ClusterUninstaller) destroyNetworks() error {
 		foundRoutes, err := o.listNetworkRoutes(item.url)
 		if err != nil {
 			o.errorTracker.suppressWarning(item.key, err, o.Logger)
+			o.recordFailed(item, err)
 			continue
 		}
 		routes := o.insertPendingItems("route", foundRoutes)
@@ -84,11 +86,13 @@ func (o *ClusterUninstaller) destroyNetworks() error {
 			err := o.deleteRoute(route)
 			if err != nil {
 				o.errorTracker.suppressWarning(route.key, err, o.Logger)
+				o.recordFailed(route, err)
 			}
 		}
 		err = o.deleteNetwork(item)
 		if err != nil {
 			o.errorTracker.suppressWarning(item.key, err, o.Logger)
+			o.recordFailed(item, err)
 		}
 	}
 	if items = o.getPendingItems("network"); len(items) > 0 {
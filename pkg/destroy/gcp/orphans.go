@@ -0,0 +1,217 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	gcpconfig "github.com/openshift/installer/pkg/asset/installconfig/gcp"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
+	"github.com/openshift/installer/pkg/version"
+)
+
+const clusterLabelPrefix = "kubernetes-io-cluster-"
+
+// orphanNameRegexp matches the names of the instances, disks, networks,
+// subnetworks and firewall rules the installer creates for every cluster,
+// capturing the cluster's infra ID.
+var orphanNameRegexp = regexp.MustCompile(`^([a-z0-9-]+-[a-z0-9]{5})-(bootstrap|master-[0-9]+|etcd|network|master-subnet|worker-subnet)$`)
+
+// FindOrphans finds the clusters in the project with instances or disks
+// labeled kubernetes-io-cluster-<infraID>=owned, or with the instances,
+// disks, network, subnetworks or etcd firewall rule the installer creates. A
+// cluster's age is taken from the oldest of those resources, and its region
+// from its instances, disks and subnetworks.
+func FindOrphans(ctx context.Context, logger logrus.FieldLogger, opts providers.OrphanOptions) ([]providers.Orphan, error) {
+	if opts.Region == "" {
+		return nil, errors.New("a region is required to find orphaned clusters")
+	}
+
+	ssn, err := gcpconfig.GetSession(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get session")
+	}
+	projectID := opts.ProjectID
+	if projectID == "" {
+		projectID = ssn.Credentials.ProjectID
+	}
+	if projectID == "" {
+		return nil, errors.New("a project is required to find orphaned clusters")
+	}
+
+	computeSvc, err := compute.NewService(ctx,
+		option.WithCredentials(ssn.Credentials),
+		option.WithUserAgent(fmt.Sprintf("OpenShift/4.x Destroyer/%s", version.Raw)),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create compute service")
+	}
+	return findOrphans(ctx, logger, computeSvc, projectID, opts.Region)
+}
+
+func findOrphans(ctx context.Context, logger logrus.FieldLogger, computeSvc *compute.Service, projectID string, region string) ([]providers.Orphan, error) {
+	created := map[string]time.Time{}
+	regions := map[string]sets.String{}
+	record := func(infraID string, resourceRegion string, timestamp string) {
+		if resourceRegion != "" {
+			if _, ok := regions[infraID]; !ok {
+				regions[infraID] = sets.NewString()
+			}
+			regions[infraID].Insert(resourceRegion)
+		}
+		t, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			logger.Debugf("Unable to parse creation timestamp %q for cluster %s", timestamp, infraID)
+			t = time.Time{}
+		}
+		if previous, ok := created[infraID]; !ok || (!t.IsZero() && (previous.IsZero() || t.Before(previous))) {
+			created[infraID] = t
+		}
+	}
+	recordZonal := func(name string, labels map[string]string, zone string, timestamp string) {
+		resourceRegion := zoneRegion(lastPathElement(zone))
+		for key, value := range labels {
+			if strings.HasPrefix(key, clusterLabelPrefix) && value == "owned" {
+				record(strings.TrimPrefix(key, clusterLabelPrefix), resourceRegion, timestamp)
+			}
+		}
+		if infraID := orphanInfraID(name); infraID != "" {
+			record(infraID, resourceRegion, timestamp)
+		}
+	}
+
+	err := computeSvc.Instances.AggregatedList(projectID).
+		Fields(googleapi.Field("items/*/instances(name,zone,labels,creationTimestamp),nextPageToken")).
+		Pages(ctx, func(list *compute.InstanceAggregatedList) error {
+			for _, scopedList := range list.Items {
+				for _, item := range scopedList.Instances {
+					recordZonal(item.Name, item.Labels, item.Zone, item.CreationTimestamp)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch compute instances")
+	}
+
+	err = computeSvc.Disks.AggregatedList(projectID).
+		Fields(googleapi.Field("items/*/disks(name,zone,labels,creationTimestamp),nextPageToken")).
+		Pages(ctx, func(list *compute.DiskAggregatedList) error {
+			for _, scopedList := range list.Items {
+				for _, item := range scopedList.Disks {
+					recordZonal(item.Name, item.Labels, item.Zone, item.CreationTimestamp)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch disks")
+	}
+
+	err = computeSvc.Subnetworks.AggregatedList(projectID).
+		Fields(googleapi.Field("items/*/subnetworks(name,region,creationTimestamp),nextPageToken")).
+		Pages(ctx, func(list *compute.SubnetworkAggregatedList) error {
+			for _, scopedList := range list.Items {
+				for _, item := range scopedList.Subnetworks {
+					if infraID := orphanInfraID(item.Name); infraID != "" {
+						record(infraID, lastPathElement(item.Region), item.CreationTimestamp)
+					}
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list subnetworks")
+	}
+
+	// Networks and firewall rules are global, so they date a cluster but do
+	// not tell in which region it is.
+	err = computeSvc.Networks.List(projectID).
+		Fields(googleapi.Field("items(name,creationTimestamp),nextPageToken")).
+		Pages(ctx, func(list *compute.NetworkList) error {
+			for _, item := range list.Items {
+				if infraID := orphanInfraID(item.Name); infraID != "" {
+					record(infraID, "", item.CreationTimestamp)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list networks")
+	}
+
+	err = computeSvc.Firewalls.List(projectID).
+		Fields(googleapi.Field("items(name,creationTimestamp),nextPageToken")).
+		Pages(ctx, func(list *compute.FirewallList) error {
+			for _, item := range list.Items {
+				if infraID := orphanInfraID(item.Name); infraID != "" {
+					record(infraID, "", item.CreationTimestamp)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list firewall rules")
+	}
+
+	orphans := make([]providers.Orphan, 0, len(created))
+	for infraID, t := range created {
+		switch clusterRegions := regions[infraID]; {
+		case clusterRegions.Len() == 0:
+			logger.Debugf("Skipping cluster %s: unable to determine its region", infraID)
+			continue
+		case !clusterRegions.Has(region):
+			logger.Debugf("Skipping cluster %s: in %s", infraID, strings.Join(clusterRegions.List(), ", "))
+			continue
+		}
+		logger.Debugf("Found resources owned by cluster %s", infraID)
+		orphans = append(orphans, providers.Orphan{
+			Metadata: &types.ClusterMetadata{
+				InfraID: infraID,
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+					GCP: &gcptypes.Metadata{
+						Region:    region,
+						ProjectID: projectID,
+					},
+				},
+			},
+			Created: t,
+		})
+	}
+	return orphans, nil
+}
+
+// orphanInfraID returns the infra ID of the cluster an installer-created
+// resource name belongs to, or an empty string if the name does not match.
+func orphanInfraID(name string) string {
+	match := orphanNameRegexp.FindStringSubmatch(name)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// lastPathElement returns the last path element of a URL, such as the name
+// of a zone or region.
+func lastPathElement(url string) string {
+	return url[strings.LastIndex(url, "/")+1:]
+}
+
+// zoneRegion returns the region of a zone, such as us-east1 for us-east1-b.
+func zoneRegion(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		return zone[:i]
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+package gcp
+
+import (
+	"context"
+	"io/ioutil"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
+)
+
+func TestOrphanInfraID(t *testing.T) {
+	cases := []struct {
+		name    string
+		infraID string
+	}{
+		{name: "mycluster-x7k2p-master-0", infraID: "mycluster-x7k2p"},
+		{name: "my-cluster-x7k2p-master-12", infraID: "my-cluster-x7k2p"},
+		{name: "mycluster-x7k2p-bootstrap", infraID: "mycluster-x7k2p"},
+		{name: "mycluster-x7k2p-etcd", infraID: "mycluster-x7k2p"},
+		{name: "mycluster-x7k2p-network", infraID: "mycluster-x7k2p"},
+		{name: "mycluster-x7k2p-master-subnet", infraID: "mycluster-x7k2p"},
+		{name: "mycluster-x7k2p-worker-subnet", infraID: "mycluster-x7k2p"},
+		{name: "mycluster-x7k2p-worker-b-abcde", infraID: ""},
+		{name: "mycluster-x7k2p-api", infraID: ""},
+		{name: "master-0", infraID: ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.infraID, orphanInfraID(tc.name))
+		})
+	}
+}
+
+func TestFindOrphans(t *testing.T) {
+	zone := func(name string) string {
+		return "https://www.googleapis.com/compute/v1/projects/project/zones/" + name
+	}
+	region := func(name string) string {
+		return "https://www.googleapis.com/compute/v1/projects/project/regions/" + name
+	}
+	server := fakeGCP(t, map[string]string{
+		"/project/aggregated/instances": `{"items": {
+			"zones/us-east1-b": {"instances": [
+				{"name": "east-abcde-master-0", "zone": "` + zone("us-east1-b") + `", "creationTimestamp": "2020-01-01T02:00:00Z"},
+				{"name": "east-abcde-worker-b-fghij", "zone": "` + zone("us-east1-b") + `", "creationTimestamp": "2020-01-01T03:00:00Z", "labels": {"kubernetes-io-cluster-east-abcde": "owned"}},
+				{"name": "unrelated", "zone": "` + zone("us-east1-b") + `", "creationTimestamp": "2020-01-01T00:00:00Z"}
+			]},
+			"zones/us-west1-a": {"instances": [
+				{"name": "west-klmno-master-0", "zone": "` + zone("us-west1-a") + `", "creationTimestamp": "2020-01-01T00:00:00Z"}
+			]}
+		}}`,
+		"/project/aggregated/disks": `{"items": {
+			"zones/us-east1-c": {"disks": [
+				{"name": "disks-pqrst-worker-c-uvwxy", "zone": "` + zone("us-east1-c") + `", "creationTimestamp": "2020-01-02T00:00:00Z", "labels": {"kubernetes-io-cluster-disks-pqrst": "owned"}}
+			]}
+		}}`,
+		"/project/aggregated/subnetworks": `{"items": {
+			"regions/us-east1": {"subnetworks": [
+				{"name": "east-abcde-master-subnet", "region": "` + region("us-east1") + `", "creationTimestamp": "2020-01-01T01:00:00Z"},
+				{"name": "network-zbcdf-master-subnet", "region": "` + region("us-east1") + `", "creationTimestamp": "2020-01-03T01:00:00Z"}
+			]},
+			"regions/us-west1": {"subnetworks": [
+				{"name": "west-klmno-master-subnet", "region": "` + region("us-west1") + `", "creationTimestamp": "2020-01-01T00:00:00Z"}
+			]}
+		}}`,
+		"/project/global/networks": `{"items": [
+			{"name": "network-zbcdf-network", "creationTimestamp": "2020-01-03T00:00:00Z"}
+		]}`,
+		"/project/global/firewalls": `{"items": [
+			{"name": "east-abcde-etcd", "creationTimestamp": "2020-01-01T00:00:00Z"},
+			{"name": "west-klmno-etcd", "creationTimestamp": "2020-01-01T00:00:00Z"},
+			{"name": "global-ghjkl-etcd", "creationTimestamp": "2020-01-01T00:00:00Z"}
+		]}`,
+	})
+	defer server.Close()
+
+	computeSvc, err := compute.NewService(context.Background(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+
+	orphans, err := findOrphans(context.Background(), logger, computeSvc, "project", "us-east1")
+	if !assert.NoError(t, err) {
+		return
+	}
+	sort.Slice(orphans, func(i, j int) bool { return orphans[i].Metadata.InfraID < orphans[j].Metadata.InfraID })
+	orphan := func(infraID string, timestamp string) providers.Orphan {
+		created, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return providers.Orphan{
+			Metadata: &types.ClusterMetadata{
+				InfraID: infraID,
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+					GCP: &gcptypes.Metadata{Region: "us-east1", ProjectID: "project"},
+				},
+			},
+			Created: created,
+		}
+	}
+	assert.Equal(t, []providers.Orphan{
+		orphan("disks-pqrst", "2020-01-02T00:00:00Z"),
+		// The age comes from the global firewall rule, the region from
+		// the instances and subnetwork.
+		orphan("east-abcde", "2020-01-01T00:00:00Z"),
+		// A cluster without instances is found through its network and
+		// subnetwork.
+		orphan("network-zbcdf", "2020-01-03T00:00:00Z"),
+	}, orphans)
+}
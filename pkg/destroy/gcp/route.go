@@ -69,6 +69,7 @@ func (o *ClusterUninstaller) deleteRoute(item cloudResource) error {
 	if (err != nil && isNoOp(err)) || (op != nil && op.Status == "DONE") {
 		o.resetRequestID(item.typeName, item.name)
 		o.deletePendingItems(item.typeName, []cloudResource{item})
+		o.recordDeleted(item)
 		o.Logger.Infof("Deleted route %s", item.name)
 	}
 	return nil
@@ -86,6 +87,7 @@ func (o *ClusterUninstaller) destroyRoutes() error {
 		err := o.deleteRoute(item)
 		if err != nil {
 			o.errorTracker.suppressWarning(item.key, err, o.Logger)
+			o.recordFailed(item, err)
 		}
 	}
 	if items = o.getPendingItems("route"); len(items) > 0 {
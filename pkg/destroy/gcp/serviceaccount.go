@@ -58,6 +58,7 @@ func (o *ClusterUninstaller) deleteServiceAccount(item cloudResource) error {
 		return errors.Wrapf(err, "failed to delete service account %s", item.name)
 	}
 	o.deletePendingItems(item.typeName, []cloudResource{item})
+	o.recordDeleted(item)
 	o.Logger.Infof("Deleted service account %s", item.name)
 	return nil
 }
@@ -76,6 +77,7 @@ func (o *ClusterUninstaller) destroyServiceAccounts() error {
 		err := o.deleteServiceAccount(item)
 		if err != nil {
 			o.errorTracker.suppressWarning(item.key, err, o.Logger)
+			o.recordFailed(item, err)
 		}
 	}
 	if items = o.getPendingItems("serviceaccount"); len(items) > 0 {
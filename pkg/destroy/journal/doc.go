@@ -0,0 +1,3 @@
+// Package journal records the progress of a cluster destroy so that an
+// interrupted destroy can be resumed without rediscovering everything.
+package journal
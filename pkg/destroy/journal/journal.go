@@ -0,0 +1,229 @@
+package journal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// FileName is the name of the journal file in the asset directory.
+	FileName = ".openshift_install_destroy.journal"
+
+	// FailuresFileName is the name of the file in the asset directory which
+	// lists the resources that failed to delete.
+	FailuresFileName = "destroy-failures.json"
+)
+
+// Status is the outcome of an attempt to delete a resource.
+type Status string
+
+const (
+	// Deleted means that the resource was confirmed deleted.
+	Deleted Status = "deleted"
+
+	// Failed means that the last attempt to delete the resource failed.
+	Failed Status = "failed"
+)
+
+// Entry is a single line of the journal.
+type Entry struct {
+	Time   time.Time `json:"time"`
+	ID     string    `json:"id"`
+	Status Status    `json:"status"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// Journal is an append-only record of deleted and failed resources.
+//
+// A nil *Journal is valid and records nothing, so destroyers do not need to
+// check whether a journal was configured.
+type Journal struct {
+	path string
+
+	mu      sync.Mutex
+	file    *os.File
+	entries map[string]Entry
+}
+
+// Open opens the journal in the given directory, loading the entries
+// recorded by previous runs.
+func Open(dir string) (*Journal, error) {
+	path := filepath.Join(dir, FileName)
+	j := &Journal{
+		path:    path,
+		entries: map[string]Entry{},
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			// A partially-written last line is expected after an
+			// interruption, so skip entries which cannot be read.
+			continue
+		}
+		j.entries[entry.ID] = entry
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0640)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", path)
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		// Terminate a partially-written line so that new entries start
+		// on a line of their own.
+		if _, err := f.Write([]byte("\n")); err != nil {
+			f.Close()
+			return nil, errors.Wrapf(err, "failed to write to %s", path)
+		}
+	}
+	j.file = f
+	return j, nil
+}
+
+// Deleted returns the IDs of the resources which were confirmed deleted.
+func (j *Journal) Deleted() []string {
+	return j.withStatus(Deleted)
+}
+
+// IsDeleted returns true if the resource was confirmed deleted.
+func (j *Journal) IsDeleted(id string) bool {
+	if j == nil {
+		return false
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.entries[id].Status == Deleted
+}
+
+// Failures returns the entries for the resources whose last deletion
+// attempt failed, sorted by ID.
+func (j *Journal) Failures() []Entry {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var failures []Entry
+	for _, entry := range j.entries {
+		if entry.Status == Failed {
+			failures = append(failures, entry)
+		}
+	}
+	sort.Slice(failures, func(i, k int) bool { return failures[i].ID < failures[k].ID })
+	return failures
+}
+
+// RecordDeleted records that the resource was deleted.
+func (j *Journal) RecordDeleted(id string) error {
+	return j.record(Entry{ID: id, Status: Deleted})
+}
+
+// RecordFailed records that an attempt to delete the resource failed.
+// Repeated failures with the same error are only recorded once.
+func (j *Journal) RecordFailed(id string, err error) error {
+	return j.record(Entry{ID: id, Status: Failed, Error: err.Error()})
+}
+
+// WriteFailures writes the resources whose last deletion attempt failed to
+// FailuresFileName next to the journal, replacing the list written by a
+// previous run. The file is removed if no resources failed. It returns the
+// path of the file, or "" if it was removed.
+func (j *Journal) WriteFailures() (string, error) {
+	if j == nil {
+		return "", nil
+	}
+	path := filepath.Join(filepath.Dir(j.path), FailuresFileName)
+	failures := j.Failures()
+	if len(failures) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+		return "", nil
+	}
+	data, err := json.MarshalIndent(failures, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(path, append(data, '\n'), 0640); err != nil {
+		return "", errors.Wrapf(err, "failed to write %s", path)
+	}
+	return path, nil
+}
+
+// Close closes the journal file.
+func (j *Journal) Close() error {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.file.Close()
+}
+
+// Remove closes and deletes the journal file and the list of failures. It
+// is called once a destroy has completed and there is nothing left to
+// resume.
+func (j *Journal) Remove() error {
+	if j == nil {
+		return nil
+	}
+	if err := j.Close(); err != nil {
+		return err
+	}
+	for _, path := range []string{j.path, filepath.Join(filepath.Dir(j.path), FailuresFileName)} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *Journal) withStatus(status Status) []string {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var ids []string
+	for id, entry := range j.entries {
+		if entry.Status == status {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (j *Journal) record(entry Entry) error {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if last, ok := j.entries[entry.ID]; ok && last.Status == entry.Status && last.Error == entry.Error {
+		return nil
+	}
+	entry.Time = time.Now().UTC()
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := j.file.Write(append(data, '\n')); err != nil {
+		return errors.Wrapf(err, "failed to write to %s", j.path)
+	}
+	j.entries[entry.ID] = entry
+	return nil
+}
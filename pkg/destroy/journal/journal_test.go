@@ -0,0 +1,112 @@
+package journal
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	j, err := Open(dir)
+	require.NoError(t, err)
+	require.NoError(t, j.RecordDeleted("a"))
+	require.NoError(t, j.RecordFailed("b", errors.New("in use")))
+	require.NoError(t, j.RecordFailed("b", errors.New("in use")))
+	require.NoError(t, j.RecordFailed("c", errors.New("throttled")))
+	require.NoError(t, j.RecordDeleted("c"))
+	require.NoError(t, j.Close())
+
+	// Simulate an interruption in the middle of writing an entry.
+	f, err := os.OpenFile(filepath.Join(dir, FileName), os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"id":"d","sta`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	j, err = Open(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "c"}, j.Deleted())
+	assert.True(t, j.IsDeleted("a"))
+	assert.False(t, j.IsDeleted("b"))
+	assert.False(t, j.IsDeleted("d"))
+
+	failures := j.Failures()
+	require.Len(t, failures, 1)
+	assert.Equal(t, "b", failures[0].ID)
+	assert.Equal(t, "in use", failures[0].Error)
+
+	// Entries written after the interruption are still readable.
+	require.NoError(t, j.RecordDeleted("b"))
+	require.NoError(t, j.Close())
+	j, err = Open(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, j.Deleted())
+	assert.Empty(t, j.Failures())
+
+	require.NoError(t, j.Remove())
+	_, err = os.Stat(filepath.Join(dir, FileName))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestJournalWriteFailures(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	failuresPath := filepath.Join(dir, FailuresFileName)
+
+	j, err := Open(dir)
+	require.NoError(t, err)
+	require.NoError(t, j.RecordDeleted("a"))
+	require.NoError(t, j.RecordFailed("b", errors.New("in use")))
+
+	path, err := j.WriteFailures()
+	require.NoError(t, err)
+	assert.Equal(t, failuresPath, path)
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	var failures []Entry
+	require.NoError(t, json.Unmarshal(data, &failures))
+	require.Len(t, failures, 1)
+	assert.Equal(t, "b", failures[0].ID)
+	assert.Equal(t, Failed, failures[0].Status)
+	assert.Equal(t, "in use", failures[0].Error)
+
+	// The list is removed once nothing has failed.
+	require.NoError(t, j.RecordDeleted("b"))
+	path, err = j.WriteFailures()
+	require.NoError(t, err)
+	assert.Equal(t, "", path)
+	_, err = os.Stat(failuresPath)
+	assert.True(t, os.IsNotExist(err))
+
+	// A successful destroy removes the list along with the journal.
+	require.NoError(t, j.RecordFailed("c", errors.New("throttled")))
+	_, err = j.WriteFailures()
+	require.NoError(t, err)
+	require.NoError(t, j.Remove())
+	_, err = os.Stat(failuresPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestNilJournal(t *testing.T) {
+	var j *Journal
+	assert.NoError(t, j.RecordDeleted("a"))
+	assert.NoError(t, j.RecordFailed("b", errors.New("in use")))
+	assert.False(t, j.IsDeleted("a"))
+	assert.Empty(t, j.Deleted())
+	assert.Empty(t, j.Failures())
+	path, err := j.WriteFailures()
+	assert.NoError(t, err)
+	assert.Equal(t, "", path)
+	assert.NoError(t, j.Remove())
+}
@@ -34,8 +34,7 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 }
 
 // Run is the entrypoint to start the uninstall process.
-func (uninstaller *ClusterUninstaller) Run() error {
-	ctx := context.Background()
+func (uninstaller *ClusterUninstaller) Run(ctx context.Context) error {
 	namespace := uninstaller.Metadata.Kubevirt.Namespace
 
 	listOpts := metav1.ListOptions{LabelSelector: apilabels.FormatLabels(uninstaller.Metadata.Kubevirt.Labels)}
@@ -54,7 +53,12 @@ func (uninstaller *ClusterUninstaller) Run() error {
 					results <- err
 					break
 				}
-				time.Sleep(10 * time.Second)
+				select {
+				case <-ctx.Done():
+					results <- ctx.Err()
+					return
+				case <-time.After(10 * time.Second):
+				}
 			}
 
 		}(i, del)
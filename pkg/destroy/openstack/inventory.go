@@ -0,0 +1,555 @@
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/servergroups"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/apiversions"
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	sg "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/subnetpools"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/trunks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"github.com/gophercloud/gophercloud/openstack/objectstorage/v1/containers"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+// Inventory returns the resources that Run would delete or untag, without
+// modifying any of them.
+func (o *ClusterUninstaller) Inventory(ctx context.Context) ([]providers.Resource, error) {
+	opts := &clientconfig.ClientOpts{
+		Cloud: o.Cloud,
+	}
+	// As in Run, the cloud name takes precedence over OS_CLOUD.
+	os.Unsetenv("OS_CLOUD")
+
+	var resources []providers.Resource
+	found := map[string]bool{}
+	add := func(typeName string, ids []string, filter string, ownership providers.Ownership) {
+		for _, id := range ids {
+			// Load balancers may match both by tag and by description.
+			if found[typeName+"/"+id] {
+				continue
+			}
+			found[typeName+"/"+id] = true
+			resources = append(resources, providers.Resource{
+				Type:      typeName,
+				ID:        id,
+				Filter:    filter,
+				Ownership: ownership,
+			})
+		}
+	}
+	tags := filterTags(o.Filter)
+	tagFilter := fmt.Sprintf("tag %s", strings.Join(tags, " or "))
+	metadataFilter := fmt.Sprintf("metadata %s", strings.Join(tags, " and "))
+	namePrefixFilter := fmt.Sprintf("name prefix %q", clusterIDFromFilter(o.Filter))
+
+	computeClient, err := clientconfig.NewServiceClient("compute", opts)
+	if err != nil {
+		return nil, err
+	}
+	allServers, err := listServers(computeClient, o.Filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list servers")
+	}
+	for _, server := range allServers {
+		add("server", []string{server.ID}, metadataFilter, providers.Owned)
+	}
+	allServerGroups, err := listServerGroups(computeClient, o.Filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list server groups")
+	}
+	for _, serverGroup := range allServerGroups {
+		add("servergroup", []string{serverGroup.ID}, namePrefixFilter, providers.Owned)
+	}
+
+	networkClient, err := clientconfig.NewServiceClient("network", opts)
+	if err != nil {
+		return nil, err
+	}
+	allTrunks, err := listTrunks(networkClient, o.Filter)
+	var gerr404 gophercloud.ErrDefault404
+	if err != nil && !errors.As(err, &gerr404) {
+		return nil, errors.Wrap(err, "failed to list trunks")
+	}
+	for _, trunk := range allTrunks {
+		add("trunk", []string{trunk.ID}, tagFilter, providers.Owned)
+	}
+
+	loadBalancerClient, err := clientconfig.NewServiceClient("load-balancer", opts)
+	var gerrEndpoint *gophercloud.ErrEndpointNotFound
+	if err != nil && !errors.As(err, &gerrEndpoint) {
+		return nil, err
+	}
+	if err == nil {
+		allLoadBalancers, err := listLoadBalancers(loadBalancerClient, o.Filter)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list load balancers")
+		}
+		for _, lb := range allLoadBalancers {
+			add("loadbalancer", []string{lb.ID}, fmt.Sprintf("%s or description %s", tagFilter, strings.Join(tags, ",")), providers.Owned)
+		}
+	}
+
+	allPorts, err := listPorts(networkClient, o.Filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list ports")
+	}
+	for _, port := range allPorts {
+		add("port", []string{port.ID}, tagFilter, providers.Owned)
+	}
+	allGroups, err := listSecurityGroups(networkClient, o.Filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list security groups")
+	}
+	for _, group := range allGroups {
+		add("securitygroup", []string{group.ID}, tagFilter, providers.Owned)
+	}
+	allRouters, err := listRouters(networkClient, o.Filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list routers")
+	}
+	for _, router := range allRouters {
+		add("router", []string{router.ID}, tagFilter, providers.Owned)
+	}
+	allSubnets, err := listSubnets(networkClient, o.Filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list subnets")
+	}
+	for _, subnet := range allSubnets {
+		add("subnet", []string{subnet.ID}, tagFilter, providers.Owned)
+	}
+	allSubnetPools, err := listSubnetPools(networkClient, o.Filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list subnet pools")
+	}
+	for _, subnetPool := range allSubnetPools {
+		add("subnetpool", []string{subnetPool.ID}, tagFilter, providers.Owned)
+	}
+	allNetworks, err := listNetworks(networkClient, o.Filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list networks")
+	}
+	for _, network := range allNetworks {
+		add("network", []string{network.ID}, tagFilter, providers.Owned)
+	}
+	allFloatingIPs, err := listFloatingIPs(networkClient, o.Filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list floating IPs")
+	}
+	for _, floatingIP := range allFloatingIPs {
+		add("floatingip", []string{floatingIP.ID}, tagFilter, providers.Owned)
+	}
+
+	objectStoreClient, err := clientconfig.NewServiceClient("object-store", opts)
+	if err != nil && !errors.As(err, &gerrEndpoint) {
+		return nil, err
+	}
+	if err == nil {
+		allContainers, err := listContainers(objectStoreClient, o.Filter)
+		var gerr403 gophercloud.ErrDefault403
+		var gerr401 gophercloud.ErrDefault401
+		if err != nil && !errors.As(err, &gerr403) && !errors.As(err, &gerr401) {
+			return nil, errors.Wrap(err, "failed to list containers")
+		}
+		add("container", allContainers, fmt.Sprintf("metadata %s", strings.Join(filterTags(titleFilter(o.Filter)), " or ")), providers.Owned)
+	}
+
+	volumeClient, err := clientconfig.NewServiceClient("volume", opts)
+	if err != nil {
+		return nil, err
+	}
+	allVolumes, err := listVolumes(volumeClient, o.Filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list volumes")
+	}
+	for _, volume := range allVolumes {
+		add("volume", []string{volume.ID}, namePrefixFilter, providers.Owned)
+	}
+
+	imageClient, err := clientconfig.NewServiceClient("image", opts)
+	if err != nil {
+		return nil, err
+	}
+	allImages, err := listImages(imageClient, o.Filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list images")
+	}
+	for _, image := range allImages {
+		add("image", []string{image.ID}, fmt.Sprintf("tag %s", strings.Join(tags, " and ")), providers.Owned)
+	}
+
+	// A network provided by the user is only untagged, and the interfaces
+	// of the cluster's subnets are removed from its router.
+	networkTag := o.InfraID + "-primaryClusterNetwork"
+	primaryNetworks, err := listPrimaryNetworks(networkClient, o.Filter, o.InfraID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the primary network")
+	}
+	for _, primaryNetwork := range primaryNetworks {
+		add("network", []string{primaryNetwork.ID}, fmt.Sprintf("tag %s", networkTag), providers.Shared)
+	}
+	if len(primaryNetworks) == 1 {
+		routerID, err := getPrimaryNetworkRouter(networkClient, primaryNetworks[0].ID)
+		if err != nil {
+			return nil, err
+		}
+		if routerID != "" {
+			add("router", []string{routerID}, fmt.Sprintf("router of the network tagged %s", networkTag), providers.Shared)
+		}
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Type != resources[j].Type {
+			return resources[i].Type < resources[j].Type
+		}
+		return resources[i].ID < resources[j].ID
+	})
+	return resources, nil
+}
+
+// clusterIDFromFilter returns the value of the openshiftClusterID tag of the
+// filter, which is the prefix of the names of the server groups and volumes
+// of the cluster.
+func clusterIDFromFilter(filter Filter) string {
+	for k, v := range filter {
+		if strings.ToLower(k) == "openshiftclusterid" {
+			return v
+		}
+	}
+	return ""
+}
+
+// titleFilter returns the filter with its keys as Swift returns them in the
+// metadata of containers.
+func titleFilter(filter Filter) Filter {
+	titled := Filter{}
+	for key, val := range filter {
+		// Swift mangles the case so openshiftClusterID becomes
+		// Openshiftclusterid in the X-Container-Meta- HEAD output
+		titled[strings.Title(strings.ToLower(key))] = val
+	}
+	return titled
+}
+
+// listServers returns the servers with the filter's tags in their metadata.
+func listServers(conn *gophercloud.ServiceClient, filter Filter) ([]ObjectWithTags, error) {
+	allPages, err := servers.List(conn, servers.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+
+	allServers, err := servers.ExtractServers(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	serverObjects := []ObjectWithTags{}
+	for _, server := range allServers {
+		serverObjects = append(
+			serverObjects, ObjectWithTags{
+				ID:   server.ID,
+				Tags: server.Metadata})
+	}
+	return filterObjects(serverObjects, filter), nil
+}
+
+// listServerGroups returns the server groups with names prefixed by the
+// cluster ID.
+func listServerGroups(conn *gophercloud.ServiceClient, filter Filter) ([]servergroups.ServerGroup, error) {
+	clusterID := clusterIDFromFilter(filter)
+
+	allPages, err := servergroups.List(conn).AllPages()
+	if err != nil {
+		return nil, err
+	}
+
+	allServerGroups, err := servergroups.ExtractServerGroups(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredGroups := make([]servergroups.ServerGroup, 0, len(allServerGroups))
+	for _, serverGroup := range allServerGroups {
+		if strings.HasPrefix(serverGroup.Name, clusterID) {
+			filteredGroups = append(filteredGroups, serverGroup)
+		}
+	}
+	return filteredGroups, nil
+}
+
+// listPorts returns the ports with any of the filter's tags.
+func listPorts(conn *gophercloud.ServiceClient, filter Filter) ([]ports.Port, error) {
+	listOpts := ports.ListOpts{
+		TagsAny: strings.Join(filterTags(filter), ","),
+	}
+	allPages, err := ports.List(conn, listOpts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return ports.ExtractPorts(allPages)
+}
+
+// listSecurityGroups returns the security groups with any of the filter's
+// tags.
+func listSecurityGroups(conn *gophercloud.ServiceClient, filter Filter) ([]sg.SecGroup, error) {
+	listOpts := sg.ListOpts{
+		TagsAny: strings.Join(filterTags(filter), ","),
+	}
+	allPages, err := sg.List(conn, listOpts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return sg.ExtractGroups(allPages)
+}
+
+// listRouters returns the routers with any of the filter's tags.
+func listRouters(conn *gophercloud.ServiceClient, filter Filter) ([]routers.Router, error) {
+	listOpts := routers.ListOpts{
+		TagsAny: strings.Join(filterTags(filter), ","),
+	}
+	allPages, err := routers.List(conn, listOpts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return routers.ExtractRouters(allPages)
+}
+
+// listSubnets returns the subnets with any of the filter's tags.
+func listSubnets(conn *gophercloud.ServiceClient, filter Filter) ([]subnets.Subnet, error) {
+	listOpts := subnets.ListOpts{
+		TagsAny: strings.Join(filterTags(filter), ","),
+	}
+	allPages, err := subnets.List(conn, listOpts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return subnets.ExtractSubnets(allPages)
+}
+
+// listNetworks returns the networks with any of the filter's tags.
+func listNetworks(conn *gophercloud.ServiceClient, filter Filter) ([]networks.Network, error) {
+	listOpts := networks.ListOpts{
+		TagsAny: strings.Join(filterTags(filter), ","),
+	}
+	allPages, err := networks.List(conn, listOpts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return networks.ExtractNetworks(allPages)
+}
+
+// listPrimaryNetworks returns the networks which were provided by the user
+// for the cluster, which have the primary cluster network tag but none of the
+// filter's tags.
+func listPrimaryNetworks(conn *gophercloud.ServiceClient, filter Filter, infraID string) ([]networks.Network, error) {
+	listOpts := networks.ListOpts{
+		Tags:    infraID + "-primaryClusterNetwork",
+		NotTags: strings.Join(filterTags(filter), ","),
+	}
+	allPages, err := networks.List(conn, listOpts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return networks.ExtractNetworks(allPages)
+}
+
+// getPrimaryNetworkRouter returns the ID of the router with an interface on
+// the primary network, if any.
+func getPrimaryNetworkRouter(conn *gophercloud.ServiceClient, networkID string) (string, error) {
+	portListOpts := ports.ListOpts{
+		NetworkID: networkID,
+	}
+	allPagesPort, err := ports.List(conn, portListOpts).AllPages()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get ports list")
+	}
+
+	allPrimaryNetworkPorts, err := ports.ExtractPorts(allPagesPort)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to extract ports list")
+	}
+
+	// Discover router by interface from the primary Network
+	return getRouterByPort(conn, allPrimaryNetworkPorts)
+}
+
+// listTrunks returns the trunks with any of the filter's tags.
+func listTrunks(conn *gophercloud.ServiceClient, filter Filter) ([]trunks.Trunk, error) {
+	listOpts := trunks.ListOpts{
+		TagsAny: strings.Join(filterTags(filter), ","),
+	}
+	allPages, err := trunks.List(conn, listOpts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return trunks.ExtractTrunks(allPages)
+}
+
+// listLoadBalancers returns the load balancers with any of the filter's tags,
+// if Octavia supports tags, and those with the tags in their description.
+func listLoadBalancers(conn *gophercloud.ServiceClient, filter Filter) ([]loadbalancers.LoadBalancer, error) {
+	newallPages, err := apiversions.List(conn).AllPages()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list api versions")
+	}
+
+	allAPIVersions, err := apiversions.ExtractAPIVersions(newallPages)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to extract api versions")
+	}
+
+	var octaviaTagSupport bool
+	octaviaTagSupport = false
+	for _, apiVersion := range allAPIVersions {
+		if apiVersion.ID >= minOctaviaVersionWithTagSupport {
+			octaviaTagSupport = true
+		}
+	}
+
+	tags := filterTags(filter)
+	var allLoadBalancers []loadbalancers.LoadBalancer
+	if octaviaTagSupport {
+		listOpts := loadbalancers.ListOpts{
+			TagsAny: tags,
+		}
+		allPages, err := loadbalancers.List(conn, listOpts).AllPages()
+		if err != nil {
+			return nil, err
+		}
+
+		allLoadBalancers, err = loadbalancers.ExtractLoadBalancers(allPages)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	listOpts := loadbalancers.ListOpts{
+		Description: strings.Join(tags, ","),
+	}
+
+	allPages, err := loadbalancers.List(conn, listOpts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+
+	allLoadBalancersWithTaggedDescription, err := loadbalancers.ExtractLoadBalancers(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(allLoadBalancers, allLoadBalancersWithTaggedDescription...), nil
+}
+
+// listSubnetPools returns the subnet pools with any of the filter's tags.
+func listSubnetPools(conn *gophercloud.ServiceClient, filter Filter) ([]subnetpools.SubnetPool, error) {
+	listOpts := subnetpools.ListOpts{
+		TagsAny: strings.Join(filterTags(filter), ","),
+	}
+	allPages, err := subnetpools.List(conn, listOpts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return subnetpools.ExtractSubnetPools(allPages)
+}
+
+// listContainers returns the names of the containers with any of the
+// filter's tags in their metadata.
+func listContainers(conn *gophercloud.ServiceClient, filter Filter) ([]string, error) {
+	listOpts := containers.ListOpts{Full: false}
+
+	allPages, err := containers.List(conn, listOpts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+
+	allContainers, err := containers.ExtractNames(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var clusterContainers []string
+	for _, container := range allContainers {
+		metadata, err := containers.Get(conn, container, nil).ExtractMetadata()
+		if err != nil {
+			// Some containers that we fetched previously can already be deleted in
+			// runtime. We should ignore these cases and continue to iterate through
+			// the remaining containers.
+			var gerr gophercloud.ErrDefault404
+			if errors.As(err, &gerr) {
+				continue
+			}
+			return nil, err
+		}
+		for key, val := range titleFilter(filter) {
+			if metadata[key] == val {
+				clusterContainers = append(clusterContainers, container)
+				break
+			}
+		}
+	}
+	return clusterContainers, nil
+}
+
+// listVolumes returns the volumes with names prefixed by the cluster ID.
+func listVolumes(conn *gophercloud.ServiceClient, filter Filter) ([]volumes.Volume, error) {
+	clusterID := clusterIDFromFilter(filter)
+
+	allPages, err := volumes.List(conn, volumes.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+
+	allVolumes, err := volumes.ExtractVolumes(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var clusterVolumes []volumes.Volume
+	for _, volume := range allVolumes {
+		if strings.HasPrefix(volume.Name, clusterID) {
+			clusterVolumes = append(clusterVolumes, volume)
+		}
+	}
+	return clusterVolumes, nil
+}
+
+// listFloatingIPs returns the floating IPs with any of the filter's tags.
+func listFloatingIPs(conn *gophercloud.ServiceClient, filter Filter) ([]floatingips.FloatingIP, error) {
+	listOpts := floatingips.ListOpts{
+		TagsAny: strings.Join(filterTags(filter), ","),
+	}
+	allPages, err := floatingips.List(conn, listOpts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return floatingips.ExtractFloatingIPs(allPages)
+}
+
+// listImages returns the images with all of the filter's tags.
+func listImages(conn *gophercloud.ServiceClient, filter Filter) ([]images.Image, error) {
+	listOpts := images.ListOpts{
+		Tags: filterTags(filter),
+	}
+	allPages, err := images.List(conn, listOpts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return images.ExtractImages(allPages)
+}
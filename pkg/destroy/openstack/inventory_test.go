@@ -0,0 +1,148 @@
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+// fakeOpenStack serves a Keystone token whose catalog points all of the
+// services at the server, and the canned responses for the listed paths and
+// queries. The clouds.yaml of the cloud "test" that it returns must be used
+// through OS_CLIENT_CONFIG_FILE.
+func fakeOpenStack(t *testing.T, responses map[string]string) (*httptest.Server, string) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && r.URL.Path == "/v3/auth/tokens" {
+			w.Header().Set("X-Subject-Token", "token")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token": {"expires_at": "2100-01-01T00:00:00Z", "catalog": [
+				{"type": "compute", "endpoints": [{"interface": "public", "region_id": "RegionOne", "url": "%[1]s/compute/"}]},
+				{"type": "network", "endpoints": [{"interface": "public", "region_id": "RegionOne", "url": "%[1]s/network/"}]},
+				{"type": "volumev2", "endpoints": [{"interface": "public", "region_id": "RegionOne", "url": "%[1]s/volume/"}]},
+				{"type": "image", "endpoints": [{"interface": "public", "region_id": "RegionOne", "url": "%[1]s/image/"}]}
+			]}}`, server.URL)
+			return
+		}
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if body, ok := responses[r.URL.Path+"?"+r.URL.RawQuery]; ok {
+			w.Write([]byte(body))
+			return
+		}
+		if body, ok := responses[r.URL.Path]; ok {
+			w.Write([]byte(body))
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+
+	dir, err := ioutil.TempDir("", "openstack")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cloudsYAML := filepath.Join(dir, "clouds.yaml")
+	if err := ioutil.WriteFile(cloudsYAML, []byte(fmt.Sprintf(`clouds:
+  test:
+    auth:
+      auth_url: %s/v3/
+      username: user
+      password: password
+      project_name: project
+      user_domain_name: Default
+      project_domain_name: Default
+    region_name: RegionOne
+`, server.URL)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return server, cloudsYAML
+}
+
+func TestInventory(t *testing.T) {
+	tagsAny := "tags-any=openshiftClusterID%3Dtest-id"
+	server, cloudsYAML := fakeOpenStack(t, map[string]string{
+		"/compute/servers/detail": `{"servers": [
+			{"id": "server-1", "metadata": {"openshiftClusterID": "test-id"}},
+			{"id": "server-2", "metadata": {"openshiftClusterID": "other-id"}}
+		]}`,
+		"/compute/os-server-groups": `{"server_groups": [
+			{"id": "group-1", "name": "test-id-master"},
+			{"id": "group-2", "name": "other-id-master"}
+		]}`,
+		"/network/v2.0/ports?" + tagsAny:           `{"ports": [{"id": "port-1"}]}`,
+		"/network/v2.0/ports?network_id=net-2":     `{"ports": [{"id": "port-2", "device_id": "router-2"}]}`,
+		"/network/v2.0/routers?id=router-2":        `{"routers": [{"id": "router-2"}]}`,
+		"/network/v2.0/security-groups?" + tagsAny: `{"security_groups": [{"id": "sg-1"}]}`,
+		"/network/v2.0/networks?" + tagsAny:        `{"networks": [{"id": "net-1"}]}`,
+		"/network/v2.0/networks?not-tags=openshiftClusterID%3Dtest-id&tags=test-id-primaryClusterNetwork": `{"networks": [{"id": "net-2"}]}`,
+		"/volume/volumes/detail": `{"volumes": [
+			{"id": "volume-1", "name": "test-id-pvc"},
+			{"id": "volume-2", "name": "other-id-pvc"}
+		]}`,
+		"/image/v2/images": `{"images": [{"id": "image-1"}]}`,
+	})
+	defer server.Close()
+	defer os.RemoveAll(filepath.Dir(cloudsYAML))
+	os.Setenv("OS_CLIENT_CONFIG_FILE", cloudsYAML)
+	defer os.Unsetenv("OS_CLIENT_CONFIG_FILE")
+
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	o := &ClusterUninstaller{
+		Cloud:   "test",
+		Filter:  Filter{"openshiftClusterID": "test-id"},
+		InfraID: "test-id",
+		Logger:  logger,
+	}
+	resources, err := o.Inventory(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	tagFilter := "tag openshiftClusterID=test-id"
+	namePrefixFilter := `name prefix "test-id"`
+	assert.Equal(t, []providers.Resource{
+		{Type: "image", ID: "image-1", Filter: tagFilter, Ownership: providers.Owned},
+		{Type: "network", ID: "net-1", Filter: tagFilter, Ownership: providers.Owned},
+		{Type: "network", ID: "net-2", Filter: "tag test-id-primaryClusterNetwork", Ownership: providers.Shared},
+		{Type: "port", ID: "port-1", Filter: tagFilter, Ownership: providers.Owned},
+		{Type: "router", ID: "router-2", Filter: "router of the network tagged test-id-primaryClusterNetwork", Ownership: providers.Shared},
+		{Type: "securitygroup", ID: "sg-1", Filter: tagFilter, Ownership: providers.Owned},
+		{Type: "server", ID: "server-1", Filter: "metadata openshiftClusterID=test-id", Ownership: providers.Owned},
+		{Type: "servergroup", ID: "group-1", Filter: namePrefixFilter, Ownership: providers.Owned},
+		{Type: "volume", ID: "volume-1", Filter: namePrefixFilter, Ownership: providers.Owned},
+	}, resources)
+}
+
+func TestInventoryError(t *testing.T) {
+	server, cloudsYAML := fakeOpenStack(t, map[string]string{})
+	defer server.Close()
+	defer os.RemoveAll(filepath.Dir(cloudsYAML))
+	os.Setenv("OS_CLIENT_CONFIG_FILE", cloudsYAML)
+	defer os.Unsetenv("OS_CLIENT_CONFIG_FILE")
+
+	o := &ClusterUninstaller{
+		Cloud:   "missing",
+		Filter:  Filter{"openshiftClusterID": "test-id"},
+		InfraID: "test-id",
+		Logger:  logrus.New(),
+	}
+	_, err := o.Inventory(context.Background())
+	if assert.Error(t, err) {
+		assert.True(t, strings.Contains(err.Error(), "missing"), err.Error())
+	}
+}
@@ -1,10 +1,12 @@
 package openstack
 
 import (
+	"context"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/openshift/installer/pkg/destroy/journal"
 	"github.com/openshift/installer/pkg/destroy/providers"
 	"github.com/openshift/installer/pkg/types"
 
@@ -13,7 +15,6 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/servergroups"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
 	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
-	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/apiversions"
 	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/attributestags"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
@@ -67,6 +68,10 @@ type ClusterUninstaller struct {
 	// InfraID contains unique cluster identifier
 	InfraID string
 	Logger  logrus.FieldLogger
+
+	// journal, if set, records which kinds of resources have been
+	// completely deleted so that an interrupted uninstall can be resumed.
+	journal *journal.Journal
 }
 
 // New returns an OpenStack destroyer from ClusterMetadata.
@@ -79,8 +84,15 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 	}, nil
 }
 
+// SetJournal sets the journal used to record and resume the uninstall.
+// OpenStack resources are deleted by kind, so the journal records each
+// delete function once it has found nothing left to delete.
+func (o *ClusterUninstaller) SetJournal(j *journal.Journal) {
+	o.journal = j
+}
+
 // Run is the entrypoint to start the uninstall process.
-func (o *ClusterUninstaller) Run() error {
+func (o *ClusterUninstaller) Run(ctx context.Context) error {
 	// deleteFuncs contains the functions that will be launched as
 	// goroutines.
 	deleteFuncs := map[string]deleteFunc{
@@ -110,12 +122,18 @@ func (o *ClusterUninstaller) Run() error {
 	// "opts" variable will be ignored if OS_CLOUD contains something.
 	os.Unsetenv("OS_CLOUD")
 
-	// launch goroutines
+	// launch goroutines, skipping the ones a previous run completed
+	for name := range deleteFuncs {
+		if o.journal.IsDeleted(journalID(name)) {
+			o.Logger.Debugf("%s already completed according to the journal, skipping", name)
+			delete(deleteFuncs, name)
+		}
+	}
 	for name, function := range deleteFuncs {
-		go deleteRunner(name, function, opts, o.Filter, o.Logger, returnChannel)
+		go deleteRunner(ctx, name, function, opts, o.Filter, o.Logger, o.journal, returnChannel)
 	}
 
-	err := cleanRouterRunner(opts, o.Filter, o.Logger, o.InfraID)
+	err := cleanRouterRunner(ctx, opts, o.Filter, o.Logger, o.InfraID)
 	if err != nil {
 		return err
 	}
@@ -125,9 +143,12 @@ func (o *ClusterUninstaller) Run() error {
 		res := <-returnChannel
 		o.Logger.Debugf("goroutine %v complete", res)
 	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	// we need to untag the custom network if it was provided by the user
-	err = untagRunner(opts, o.InfraID, o.Logger)
+	err = untagRunner(ctx, opts, o.InfraID, o.Logger)
 	if err != nil {
 		return err
 	}
@@ -135,25 +156,41 @@ func (o *ClusterUninstaller) Run() error {
 	return nil
 }
 
-func deleteRunner(deleteFuncName string, dFunction deleteFunc, opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger, channel chan string) {
+func deleteRunner(ctx context.Context, deleteFuncName string, dFunction deleteFunc, opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger, j *journal.Journal, channel chan string) {
 	backoffSettings := wait.Backoff{
 		Duration: time.Second * 15,
 		Factor:   1.3,
 		Steps:    25,
 	}
 
-	err := wait.ExponentialBackoff(backoffSettings, func() (bool, error) {
+	err := backoffUntil(ctx, backoffSettings, func() (bool, error) {
 		return dFunction(opts, filter, logger)
 	})
 
+	if err != nil && ctx.Err() != nil {
+		logger.Debugf("%s interrupted: %v", deleteFuncName, err)
+		channel <- deleteFuncName
+		return
+	}
 	if err != nil {
+		if err := j.RecordFailed(journalID(deleteFuncName), err); err != nil {
+			logger.WithError(err).Debug("could not record failure in journal")
+		}
 		logger.Fatalf("Unrecoverable error/timed out: %v", err)
 	}
+	if err := j.RecordDeleted(journalID(deleteFuncName)); err != nil {
+		logger.WithError(err).Debug("could not record deletion in journal")
+	}
 
 	// record that the goroutine has run to completion
 	channel <- deleteFuncName
 }
 
+// journalID returns the journal ID for a delete function.
+func journalID(deleteFuncName string) string {
+	return "openstack:" + deleteFuncName
+}
+
 // filterObjects will do client-side filtering given an appropriately filled out
 // list of ObjectWithTags.
 func filterObjects(osObjects []ObjectWithTags, filters Filter) []ObjectWithTags {
@@ -209,27 +246,11 @@ func deleteServers(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 		return false, nil
 	}
 
-	allPages, err := servers.List(conn, servers.ListOpts{}).AllPages()
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-
-	allServers, err := servers.ExtractServers(allPages)
+	filteredServers, err := listServers(conn, filter)
 	if err != nil {
 		logger.Error(err)
 		return false, nil
 	}
-
-	serverObjects := []ObjectWithTags{}
-	for _, server := range allServers {
-		serverObjects = append(
-			serverObjects, ObjectWithTags{
-				ID:   server.ID,
-				Tags: server.Metadata})
-	}
-
-	filteredServers := filterObjects(serverObjects, filter)
 	for _, server := range filteredServers {
 		logger.Debugf("Deleting Server %q", server.ID)
 		err = servers.Delete(conn, server.ID).ExtractErr()
@@ -250,41 +271,20 @@ func deleteServerGroups(opts *clientconfig.ClientOpts, filter Filter, logger log
 	logger.Debug("Deleting openstack server groups")
 	defer logger.Debugf("Exiting deleting openstack server groups")
 
-	// We need to delete all server groups that have names with the cluster
-	// ID as a prefix
-	var clusterID string
-	for k, v := range filter {
-		if strings.ToLower(k) == "openshiftclusterid" {
-			clusterID = v
-			break
-		}
-	}
-
 	conn, err := clientconfig.NewServiceClient("compute", opts)
 	if err != nil {
 		logger.Error(err)
 		return false, nil
 	}
 
-	allPages, err := servergroups.List(conn).AllPages()
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-
-	allServerGroups, err := servergroups.ExtractServerGroups(allPages)
+	// We need to delete all server groups that have names with the cluster
+	// ID as a prefix
+	filteredGroups, err := listServerGroups(conn, filter)
 	if err != nil {
 		logger.Error(err)
 		return false, nil
 	}
 
-	filteredGroups := make([]servergroups.ServerGroup, 0, len(allServerGroups))
-	for _, serverGroup := range allServerGroups {
-		if strings.HasPrefix(serverGroup.Name, clusterID) {
-			filteredGroups = append(filteredGroups, serverGroup)
-		}
-	}
-
 	for _, serverGroup := range filteredGroups {
 		logger.Debugf("Deleting Server Group %q", serverGroup.ID)
 		if err = servergroups.Delete(conn, serverGroup.ID).ExtractErr(); err != nil {
@@ -311,18 +311,7 @@ func deletePorts(opts *clientconfig.ClientOpts, filter Filter, logger logrus.Fie
 		logger.Error(err)
 		return false, nil
 	}
-	tags := filterTags(filter)
-	listOpts := ports.ListOpts{
-		TagsAny: strings.Join(tags, ","),
-	}
-
-	allPages, err := ports.List(conn, listOpts).AllPages()
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-
-	allPorts, err := ports.ExtractPorts(allPages)
+	allPorts, err := listPorts(conn, filter)
 	if err != nil {
 		logger.Error(err)
 		return false, nil
@@ -377,18 +366,7 @@ func deleteSecurityGroups(opts *clientconfig.ClientOpts, filter Filter, logger l
 		logger.Error(err)
 		return false, nil
 	}
-	tags := filterTags(filter)
-	listOpts := sg.ListOpts{
-		TagsAny: strings.Join(tags, ","),
-	}
-
-	allPages, err := sg.List(conn, listOpts).AllPages()
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-
-	allGroups, err := sg.ExtractGroups(allPages)
+	allGroups, err := listSecurityGroups(conn, filter)
 	if err != nil {
 		logger.Error(err)
 		return false, nil
@@ -419,18 +397,7 @@ func deleteRouters(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 		logger.Error(err)
 		return false, nil
 	}
-	tags := filterTags(filter)
-	listOpts := routers.ListOpts{
-		TagsAny: strings.Join(tags, ","),
-	}
-
-	allPages, err := routers.List(conn, listOpts).AllPages()
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-
-	allRouters, err := routers.ExtractRouters(allPages)
+	allRouters, err := listRouters(conn, filter)
 	if err != nil {
 		logger.Error(err)
 		return false, nil
@@ -506,20 +473,7 @@ func deleteCustomRouterInterfaces(opts *clientconfig.ClientOpts, filter Filter,
 		return false, nil
 	}
 
-	tags := filterTags(filter)
-	networkTag := infraID + "-primaryClusterNetwork"
-	listOpts := networks.ListOpts{
-		Tags:    networkTag,
-		NotTags: strings.Join(tags, ","),
-	}
-
-	allPages, err := networks.List(conn, listOpts).AllPages()
-	if err != nil {
-		logger.Debug(err)
-		return false, nil
-	}
-
-	allPrimaryNetworks, err := networks.ExtractNetworks(allPages)
+	allPrimaryNetworks, err := listPrimaryNetworks(conn, filter, infraID)
 	if err != nil {
 		logger.Debug(err)
 		return false, nil
@@ -534,23 +488,7 @@ func deleteCustomRouterInterfaces(opts *clientconfig.ClientOpts, filter Filter,
 		return true, nil
 	}
 
-	portListOpts := ports.ListOpts{
-		NetworkID: allPrimaryNetworks[0].ID,
-	}
-	allPagesPort, err := ports.List(conn, portListOpts).AllPages()
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-
-	allPrimayNetworkPorts, err := ports.ExtractPorts(allPagesPort)
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-
-	// Discover router by interface from the primary Network
-	routerID, err := getRouterByPort(conn, allPrimayNetworkPorts)
+	routerID, err := getPrimaryNetworkRouter(conn, allPrimaryNetworks[0].ID)
 	if err != nil {
 		logger.Debug(err)
 		return false, nil
@@ -671,18 +609,7 @@ func deleteSubnets(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 		logger.Error(err)
 		return false, nil
 	}
-	tags := filterTags(filter)
-	listOpts := subnets.ListOpts{
-		TagsAny: strings.Join(tags, ","),
-	}
-
-	allPages, err := subnets.List(conn, listOpts).AllPages()
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-
-	allSubnets, err := subnets.ExtractSubnets(allPages)
+	allSubnets, err := listSubnets(conn, filter)
 	if err != nil {
 		logger.Error(err)
 		return false, nil
@@ -713,18 +640,7 @@ func deleteNetworks(opts *clientconfig.ClientOpts, filter Filter, logger logrus.
 		logger.Error(err)
 		return false, nil
 	}
-	tags := filterTags(filter)
-	listOpts := networks.ListOpts{
-		TagsAny: strings.Join(tags, ","),
-	}
-
-	allPages, err := networks.List(conn, listOpts).AllPages()
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-
-	allNetworks, err := networks.ExtractNetworks(allPages)
+	allNetworks, err := listNetworks(conn, filter)
 	if err != nil {
 		logger.Error(err)
 		return false, nil
@@ -761,9 +677,7 @@ func deleteContainers(opts *clientconfig.ClientOpts, filter Filter, logger logru
 		logger.Error(err)
 		return false, nil
 	}
-	listOpts := containers.ListOpts{Full: false}
-
-	allPages, err := containers.List(conn, listOpts).AllPages()
+	clusterContainers, err := listContainers(conn, filter)
 	if err != nil {
 		// Ignore the error if the user doesn't have the swiftoperator role.
 		// Depending on the configuration Swift returns different error codes:
@@ -784,77 +698,51 @@ func deleteContainers(opts *clientconfig.ClientOpts, filter Filter, logger logru
 		return false, nil
 	}
 
-	allContainers, err := containers.ExtractNames(allPages)
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-	for _, container := range allContainers {
-		metadata, err := containers.Get(conn, container, nil).ExtractMetadata()
+	for _, container := range clusterContainers {
+		logger.Debugf("Bulk deleting container %q objects", container)
+		pager := objects.List(conn, container, &objects.ListOpts{
+			Limit: 50,
+		})
+		err = pager.EachPage(func(page pagination.Page) (bool, error) {
+			objectsOnPage, err := objects.ExtractNames(page)
+			if err != nil {
+				return false, err
+			}
+			resp, err := objects.BulkDelete(conn, container, objectsOnPage).Extract()
+			if err != nil {
+				return false, err
+			}
+			if len(resp.Errors) > 0 {
+				// Convert resp.Errors to golang errors.
+				// Each error is represented by a list of 2 strings, where the first one
+				// is the object name, and the second one contains an error message.
+				errs := make([]error, len(resp.Errors))
+				for i, objectError := range resp.Errors {
+					errs[i] = errors.Errorf("cannot delete object %v: %v", objectError[0], objectError[1])
+				}
+
+				return false, errors.Errorf("errors occured during bulk deleting of container %v objects: %v", container, k8serrors.NewAggregate(errs))
+			}
+
+			return true, nil
+		})
 		if err != nil {
-			// Some containers that we fetched previously can already be deleted in
-			// runtime. We should ignore these cases and continue to iterate through
-			// the remaining containers.
 			var gerr gophercloud.ErrDefault404
-			if errors.As(err, &gerr) {
-				continue
+			if !errors.As(err, &gerr) {
+				logger.Errorf("Bulk deleting of container %q objects failed: %v", container, err)
+				return false, nil
 			}
-			logger.Error(err)
-			return false, nil
 		}
-		for key, val := range filter {
-			// Swift mangles the case so openshiftClusterID becomes
-			// Openshiftclusterid in the X-Container-Meta- HEAD output
-			titlekey := strings.Title(strings.ToLower(key))
-			if metadata[titlekey] == val {
-				logger.Debugf("Bulk deleting container %q objects", container)
-				pager := objects.List(conn, container, &objects.ListOpts{
-					Limit: 50,
-				})
-				err = pager.EachPage(func(page pagination.Page) (bool, error) {
-					objectsOnPage, err := objects.ExtractNames(page)
-					if err != nil {
-						return false, err
-					}
-					resp, err := objects.BulkDelete(conn, container, objectsOnPage).Extract()
-					if err != nil {
-						return false, err
-					}
-					if len(resp.Errors) > 0 {
-						// Convert resp.Errors to golang errors.
-						// Each error is represented by a list of 2 strings, where the first one
-						// is the object name, and the second one contains an error message.
-						errs := make([]error, len(resp.Errors))
-						for i, objectError := range resp.Errors {
-							errs[i] = errors.Errorf("cannot delete object %v: %v", objectError[0], objectError[1])
-						}
-
-						return false, errors.Errorf("errors occured during bulk deleting of container %v objects: %v", container, k8serrors.NewAggregate(errs))
-					}
-
-					return true, nil
-				})
-				if err != nil {
-					var gerr gophercloud.ErrDefault404
-					if !errors.As(err, &gerr) {
-						logger.Errorf("Bulk deleting of container %q objects failed: %v", container, err)
-						return false, nil
-					}
-				}
-				logger.Debugf("Deleting container %q", container)
-				_, err = containers.Delete(conn, container).Extract()
-				if err != nil {
-					// Ignore the error if the container cannot be found and return with an appropriate message if it's another type of error
-					var gerr gophercloud.ErrDefault404
-					if !errors.As(err, &gerr) {
-						logger.Errorf("Deleting container %q failed: %v", container, err)
-						return false, nil
-					}
-					logger.Debugf("Cannot find container %q. It's probably already been deleted.", container)
-				}
-				// If a metadata key matched, we're done so break from the loop
-				break
+		logger.Debugf("Deleting container %q", container)
+		_, err = containers.Delete(conn, container).Extract()
+		if err != nil {
+			// Ignore the error if the container cannot be found and return with an appropriate message if it's another type of error
+			var gerr gophercloud.ErrDefault404
+			if !errors.As(err, &gerr) {
+				logger.Errorf("Deleting container %q failed: %v", container, err)
+				return false, nil
 			}
+			logger.Debugf("Cannot find container %q. It's probably already been deleted.", container)
 		}
 	}
 	return true, nil
@@ -870,11 +758,7 @@ func deleteTrunks(opts *clientconfig.ClientOpts, filter Filter, logger logrus.Fi
 		return false, nil
 	}
 
-	tags := filterTags(filter)
-	listOpts := trunks.ListOpts{
-		TagsAny: strings.Join(tags, ","),
-	}
-	allPages, err := trunks.List(conn, listOpts).AllPages()
+	allTrunks, err := listTrunks(conn, filter)
 	if err != nil {
 		var gerr gophercloud.ErrDefault404
 		if errors.As(err, &gerr) {
@@ -884,12 +768,6 @@ func deleteTrunks(opts *clientconfig.ClientOpts, filter Filter, logger logrus.Fi
 		logger.Error(err)
 		return false, nil
 	}
-
-	allTrunks, err := trunks.ExtractTrunks(allPages)
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
 	for _, trunk := range allTrunks {
 		logger.Debugf("Deleting Trunk %q", trunk.ID)
 		err = trunks.Delete(conn, trunk.ID).ExtractErr()
@@ -923,62 +801,12 @@ func deleteLoadBalancers(opts *clientconfig.ClientOpts, filter Filter, logger lo
 		return false, nil
 	}
 
-	newallPages, err := apiversions.List(conn).AllPages()
-	if err != nil {
-		logger.Errorf("Unable to list api versions: %v", err)
-		return false, nil
-	}
-
-	allAPIVersions, err := apiversions.ExtractAPIVersions(newallPages)
-	if err != nil {
-		logger.Errorf("Unable to extract api versions: %v", err)
-		return false, nil
-	}
-
-	var octaviaTagSupport bool
-	octaviaTagSupport = false
-	for _, apiVersion := range allAPIVersions {
-		if apiVersion.ID >= minOctaviaVersionWithTagSupport {
-			octaviaTagSupport = true
-		}
-	}
-
-	tags := filterTags(filter)
-	var allLoadBalancers []loadbalancers.LoadBalancer
-	if octaviaTagSupport {
-		listOpts := loadbalancers.ListOpts{
-			TagsAny: tags,
-		}
-		allPages, err := loadbalancers.List(conn, listOpts).AllPages()
-		if err != nil {
-			logger.Error(err)
-			return false, nil
-		}
-
-		allLoadBalancers, err = loadbalancers.ExtractLoadBalancers(allPages)
-		if err != nil {
-			logger.Error(err)
-			return false, nil
-		}
-	}
-
-	listOpts := loadbalancers.ListOpts{
-		Description: strings.Join(tags, ","),
-	}
-
-	allPages, err := loadbalancers.List(conn, listOpts).AllPages()
+	allLoadBalancers, err := listLoadBalancers(conn, filter)
 	if err != nil {
 		logger.Error(err)
 		return false, nil
 	}
 
-	allLoadBalancersWithTaggedDescription, err := loadbalancers.ExtractLoadBalancers(allPages)
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-
-	allLoadBalancers = append(allLoadBalancers, allLoadBalancersWithTaggedDescription...)
 	deleteOpts := loadbalancers.DeleteOpts{
 		Cascade: true,
 	}
@@ -1009,18 +837,7 @@ func deleteSubnetPools(opts *clientconfig.ClientOpts, filter Filter, logger logr
 		logger.Error(err)
 		return false, nil
 	}
-	tags := filterTags(filter)
-	listOpts := subnetpools.ListOpts{
-		TagsAny: strings.Join(tags, ","),
-	}
-
-	allPages, err := subnetpools.List(conn, listOpts).AllPages()
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-
-	allSubnetPools, err := subnetpools.ExtractSubnetPools(allPages)
+	allSubnetPools, err := listSubnetPools(conn, filter)
 	if err != nil {
 		logger.Error(err)
 		return false, nil
@@ -1045,30 +862,14 @@ func deleteVolumes(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 	logger.Debug("Deleting OpenStack volumes")
 	defer logger.Debugf("Exiting deleting OpenStack volumes")
 
-	// We need to delete all volumes that have names with the cluster ID as a prefix
-	var clusterID string
-	for k, v := range filter {
-		if strings.ToLower(k) == "openshiftclusterid" {
-			clusterID = v
-			break
-		}
-	}
-
 	conn, err := clientconfig.NewServiceClient("volume", opts)
 	if err != nil {
 		logger.Error(err)
 		return false, nil
 	}
 
-	listOpts := volumes.ListOpts{}
-
-	allPages, err := volumes.List(conn, listOpts).AllPages()
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-
-	allVolumes, err := volumes.ExtractVolumes(allPages)
+	// We need to delete all volumes that have names with the cluster ID as a prefix
+	allVolumes, err := listVolumes(conn, filter)
 	if err != nil {
 		logger.Error(err)
 		return false, nil
@@ -1076,9 +877,7 @@ func deleteVolumes(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 
 	volumeIDs := []string{}
 	for _, volume := range allVolumes {
-		if strings.HasPrefix(volume.Name, clusterID) {
-			volumeIDs = append(volumeIDs, volume.ID)
-		}
+		volumeIDs = append(volumeIDs, volume.ID)
 	}
 
 	deleteOpts := volumes.DeleteOpts{
@@ -1111,18 +910,7 @@ func deleteFloatingIPs(opts *clientconfig.ClientOpts, filter Filter, logger logr
 		logger.Error(err)
 		return false, nil
 	}
-	tags := filterTags(filter)
-	listOpts := floatingips.ListOpts{
-		TagsAny: strings.Join(tags, ","),
-	}
-
-	allPages, err := floatingips.List(conn, listOpts).AllPages()
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-
-	allFloatingIPs, err := floatingips.ExtractFloatingIPs(allPages)
+	allFloatingIPs, err := listFloatingIPs(conn, filter)
 	if err != nil {
 		logger.Error(err)
 		return false, nil
@@ -1153,17 +941,7 @@ func deleteImages(opts *clientconfig.ClientOpts, filter Filter, logger logrus.Fi
 		return false, nil
 	}
 
-	listOpts := images.ListOpts{
-		Tags: filterTags(filter),
-	}
-
-	allPages, err := images.List(conn, listOpts).AllPages()
-	if err != nil {
-		logger.Error(err)
-		return false, nil
-	}
-
-	allImages, err := images.ExtractImages(allPages)
+	allImages, err := listImages(conn, filter)
 	if err != nil {
 		logger.Error(err)
 		return false, nil
@@ -1181,17 +959,17 @@ func deleteImages(opts *clientconfig.ClientOpts, filter Filter, logger logrus.Fi
 	return true, nil
 }
 
-func untagRunner(opts *clientconfig.ClientOpts, infraID string, logger logrus.FieldLogger) error {
+func untagRunner(ctx context.Context, opts *clientconfig.ClientOpts, infraID string, logger logrus.FieldLogger) error {
 	backoffSettings := wait.Backoff{
 		Duration: time.Second * 10,
 		Steps:    25,
 	}
 
-	err := wait.ExponentialBackoff(backoffSettings, func() (bool, error) {
+	err := backoffUntil(ctx, backoffSettings, func() (bool, error) {
 		return untagPrimaryNetwork(opts, infraID, logger)
 	})
 	if err != nil {
-		if err == wait.ErrWaitTimeout {
+		if err == wait.ErrWaitTimeout || err == ctx.Err() {
 			return err
 		}
 		return errors.Errorf("Unrecoverable error: %v", err)
@@ -1200,18 +978,18 @@ func untagRunner(opts *clientconfig.ClientOpts, infraID string, logger logrus.Fi
 	return nil
 }
 
-func cleanRouterRunner(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger, infraID string) error {
+func cleanRouterRunner(ctx context.Context, opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger, infraID string) error {
 	backoffSettings := wait.Backoff{
 		Duration: time.Second * 15,
 		Factor:   1.3,
 		Steps:    25,
 	}
 
-	err := wait.ExponentialBackoff(backoffSettings, func() (bool, error) {
+	err := backoffUntil(ctx, backoffSettings, func() (bool, error) {
 		return deleteCustomRouterInterfaces(opts, filter, logger, infraID)
 	})
 	if err != nil {
-		if err == wait.ErrWaitTimeout {
+		if err == wait.ErrWaitTimeout || err == ctx.Err() {
 			return err
 		}
 		return errors.Errorf("Unrecoverable error: %v", err)
@@ -1220,6 +998,28 @@ func cleanRouterRunner(opts *clientconfig.ClientOpts, filter Filter, logger logr
 	return nil
 }
 
+// backoffUntil is wait.ExponentialBackoff, except that it stops waiting and
+// returns the context's error once ctx is done.
+func backoffUntil(ctx context.Context, backoff wait.Backoff, condition wait.ConditionFunc) error {
+	for backoff.Steps > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if ok, err := condition(); err != nil || ok {
+			return err
+		}
+		if backoff.Steps == 1 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff.Step()):
+		}
+	}
+	return wait.ErrWaitTimeout
+}
+
 // untagNetwork removes the tag from the primary cluster network based on unfra id
 func untagPrimaryNetwork(opts *clientconfig.ClientOpts, infraID string, logger logrus.FieldLogger) (bool, error) {
 	networkTag := infraID + "-primaryClusterNetwork"
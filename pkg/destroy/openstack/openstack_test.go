@@ -0,0 +1,43 @@
+package openstack
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestBackoffUntil(t *testing.T) {
+	backoff := wait.Backoff{Duration: time.Millisecond, Steps: 3}
+
+	calls := 0
+	err := backoffUntil(context.Background(), backoff, func() (bool, error) {
+		calls++
+		return calls == 2, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	err = backoffUntil(context.Background(), backoff, func() (bool, error) {
+		calls++
+		return false, nil
+	})
+	assert.Equal(t, wait.ErrWaitTimeout, err)
+	assert.Equal(t, 3, calls)
+
+	// A canceled context stops the backoff without waiting for the step.
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	start := time.Now()
+	err = backoffUntil(ctx, wait.Backoff{Duration: time.Hour, Steps: 3}, func() (bool, error) {
+		calls++
+		cancel()
+		return false, nil
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 1, calls)
+	assert.True(t, time.Since(start) < time.Minute)
+}
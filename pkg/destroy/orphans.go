@@ -0,0 +1,51 @@
+package destroy
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// FindOrphans returns the metadata of the clusters found on platform whose
+// oldest resource was created more than olderThan ago. Clusters whose age
+// cannot be determined are skipped.
+func FindOrphans(ctx context.Context, logger logrus.FieldLogger, platform string, olderThan time.Duration, opts providers.OrphanOptions) ([]*types.ClusterMetadata, error) {
+	finder, ok := providers.OrphanRegistry[platform]
+	if !ok {
+		return nil, errors.Errorf("finding orphaned clusters is not supported for %q", platform)
+	}
+
+	orphans, err := finder(ctx, logger, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find orphaned clusters")
+	}
+	return filterOrphans(logger, orphans, time.Now().Add(-olderThan)), nil
+}
+
+// filterOrphans returns the metadata of the orphans created before cutoff,
+// sorted by infrastructure ID.
+func filterOrphans(logger logrus.FieldLogger, orphans []providers.Orphan, cutoff time.Time) []*types.ClusterMetadata {
+	var metadata []*types.ClusterMetadata
+	for _, orphan := range orphans {
+		infraID := orphan.Metadata.InfraID
+		switch {
+		case orphan.Created.IsZero():
+			logger.Warnf("Skipping cluster %s: unable to determine its age", infraID)
+		case orphan.Created.After(cutoff):
+			logger.Debugf("Skipping cluster %s: created %s", infraID, orphan.Created.Format(time.RFC3339))
+		default:
+			logger.Debugf("Found cluster %s: created %s", infraID, orphan.Created.Format(time.RFC3339))
+			metadata = append(metadata, orphan.Metadata)
+		}
+	}
+	sort.Slice(metadata, func(i, j int) bool {
+		return metadata[i].InfraID < metadata[j].InfraID
+	})
+	return metadata
+}
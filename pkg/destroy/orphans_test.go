@@ -0,0 +1,36 @@
+package destroy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestFilterOrphans(t *testing.T) {
+	now := time.Now()
+	orphan := func(infraID string, created time.Time) providers.Orphan {
+		return providers.Orphan{
+			Metadata: &types.ClusterMetadata{InfraID: infraID},
+			Created:  created,
+		}
+	}
+
+	orphans := []providers.Orphan{
+		orphan("recent-abcde", now.Add(-time.Hour)),
+		orphan("old-zzzzz", now.Add(-48*time.Hour)),
+		orphan("unknown-bcdfg", time.Time{}),
+		orphan("old-bbbbb", now.Add(-25*time.Hour)),
+	}
+
+	metadata := filterOrphans(logrus.StandardLogger(), orphans, now.Add(-24*time.Hour))
+	var infraIDs []string
+	for _, m := range metadata {
+		infraIDs = append(infraIDs, m.InfraID)
+	}
+	assert.Equal(t, []string{"old-bbbbb", "old-zzzzz"}, infraIDs)
+}
@@ -1,6 +1,7 @@
 package ovirt
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -20,7 +21,7 @@ type ClusterUninstaller struct {
 }
 
 // Run is the entrypoint to start the uninstall process.
-func (uninstaller *ClusterUninstaller) Run() error {
+func (uninstaller *ClusterUninstaller) Run(ctx context.Context) error {
 	con, err := ovirt.NewConnection()
 	if err != nil {
 		return fmt.Errorf("failed to initialize connection to ovirt-engine's %s", err)
@@ -33,6 +34,9 @@ func (uninstaller *ClusterUninstaller) Run() error {
 	tags := [2]string{tagVMs, tagVMbootstrap}
 
 	for _, tag := range tags {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if err := uninstaller.removeVMs(con, tag); err != nil {
 			uninstaller.Logger.Errorf("failed to remove VMs: %s", err)
 		}
@@ -40,6 +44,9 @@ func (uninstaller *ClusterUninstaller) Run() error {
 			uninstaller.Logger.Errorf("failed to remove tag: %s", err)
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	if err := uninstaller.removeTemplate(con); err != nil {
 		uninstaller.Logger.Errorf("Failed to remove template: %s", err)
 	}
@@ -2,3 +2,7 @@ package providers
 
 // Registry maps ClusterMetadata.Platform() to per-platform Destroyer creators.
 var Registry = make(map[string]NewFunc)
+
+// OrphanRegistry maps platform names to functions which find orphaned
+// clusters on that platform.
+var OrphanRegistry = make(map[string]FindOrphansFunc)
@@ -1,16 +1,91 @@
 package providers
 
 import (
+	"context"
+	"time"
+
 	"github.com/sirupsen/logrus"
 
+	"github.com/openshift/installer/pkg/destroy/journal"
 	"github.com/openshift/installer/pkg/types"
 )
 
 // Destroyer allows multiple implementations of destroy
 // for different platforms.
 type Destroyer interface {
-	Run() error
+	// Run destroys the cluster. It stops between API calls and returns
+	// the context's error once ctx is done.
+	Run(ctx context.Context) error
+}
+
+// Journaler is implemented by destroyers which record deleted and failed
+// resources in a journal, and which skip the resources that a previous,
+// interrupted run already deleted.
+type Journaler interface {
+	SetJournal(j *journal.Journal)
 }
 
 // NewFunc is an interface for creating platform-specific destroyers.
 type NewFunc func(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (Destroyer, error)
+
+// Ownership describes the relationship between a cluster and a resource
+// found by a destroyer.
+type Ownership string
+
+const (
+	// Owned resources were created for the cluster and are deleted with it.
+	Owned Ownership = "owned"
+
+	// Shared resources are used by, but not owned by, the cluster. The
+	// destroyer only removes the cluster's tags or references from them.
+	Shared Ownership = "shared"
+)
+
+// Resource is a single cloud resource found by a destroyer.
+type Resource struct {
+	// Type is the platform-specific kind of the resource, e.g.
+	// "ec2:instance" or "disk".
+	Type string `json:"type"`
+
+	// ID uniquely identifies the resource on its platform, e.g. an ARN.
+	ID string `json:"id"`
+
+	// Filter is the tag, label or name filter that matched the resource.
+	Filter string `json:"filter"`
+
+	// Ownership is whether the resource is owned or shared by the cluster.
+	Ownership Ownership `json:"ownership"`
+}
+
+// Inventory is implemented by destroyers which can report the resources
+// they would act on without deleting anything.
+type Inventory interface {
+	Inventory(ctx context.Context) ([]Resource, error)
+}
+
+// OrphanOptions scopes the search for orphaned clusters.
+type OrphanOptions struct {
+	// Region limits the search to a single region. Platforms which search
+	// all regions may ignore it.
+	Region string
+
+	// ProjectID is the GCP project to search. If empty, the project of
+	// the configured credentials is used.
+	ProjectID string
+}
+
+// Orphan is a cluster whose resources were found on a platform without
+// an install directory to destroy them from.
+type Orphan struct {
+	// Metadata is synthesized from the discovered resources, and is
+	// sufficient to create the platform's destroyer.
+	Metadata *types.ClusterMetadata
+
+	// Created is the creation time of the oldest resource found for the
+	// cluster. It is zero if no resource reported a creation time.
+	Created time.Time
+}
+
+// FindOrphansFunc is an interface for discovering clusters on a platform by
+// the tags or labels their resources carry.
+type FindOrphansFunc func(ctx context.Context, logger logrus.FieldLogger, opts OrphanOptions) ([]Orphan, error)
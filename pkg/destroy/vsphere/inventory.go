@@ -0,0 +1,68 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+// Inventory returns the resources that Run would delete, without deleting
+// any of them.
+func (o *ClusterUninstaller) Inventory(ctx context.Context) ([]providers.Resource, error) {
+	tagAttachedObjects, err := getAttachedObjectsOnTag(ctx, o.RestClient, o.InfraID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := fmt.Sprintf("tag %s", o.InfraID)
+	var folderList []types.ManagedObjectReference
+	var virtualMachineList []types.ManagedObjectReference
+	for _, attachedObject := range tagAttachedObjects {
+		for _, ref := range attachedObject.ObjectIDs {
+			switch ref.Reference().Type {
+			case "Folder":
+				folderList = append(folderList, ref.Reference())
+			case "VirtualMachine":
+				virtualMachineList = append(virtualMachineList, ref.Reference())
+			}
+		}
+	}
+
+	var resources []providers.Resource
+	if len(virtualMachineList) > 0 {
+		virtualMachineMoList, err := getVirtualMachineManagedObjects(ctx, o.Client, virtualMachineList)
+		if err != nil {
+			return nil, err
+		}
+		for _, vmMO := range virtualMachineMoList {
+			resources = append(resources, providers.Resource{
+				Type:      "VirtualMachine",
+				ID:        fmt.Sprintf("%s (%s)", vmMO.Name, vmMO.Reference().Value),
+				Filter:    filter,
+				Ownership: providers.Owned,
+			})
+		}
+	}
+	if len(folderList) > 0 {
+		folderMoList, err := getFolderManagedObjects(ctx, o.Client, folderList)
+		if err != nil {
+			return nil, err
+		}
+		for _, folderMO := range folderMoList {
+			resources = append(resources, providers.Resource{
+				Type:      "Folder",
+				ID:        fmt.Sprintf("%s (%s)", folderMO.Name, folderMO.Reference().Value),
+				Filter:    filter,
+				Ownership: providers.Owned,
+			})
+		}
+	}
+	resources = append(resources,
+		providers.Resource{Type: "Tag", ID: o.InfraID, Filter: filter, Ownership: providers.Owned},
+		providers.Resource{Type: "TagCategory", ID: "openshift-" + o.InfraID, Filter: filter, Ownership: providers.Owned},
+	)
+	return resources, nil
+}
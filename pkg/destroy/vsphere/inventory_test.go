@@ -0,0 +1,80 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vapi/rest"
+	_ "github.com/vmware/govmomi/vapi/simulator"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+func TestInventory(t *testing.T) {
+	simulator.Test(func(ctx context.Context, client *vim25.Client) {
+		restClient := rest.NewClient(client)
+		if err := restClient.Login(ctx, simulator.DefaultLogin); err != nil {
+			t.Fatal(err)
+		}
+
+		finder := find.NewFinder(client)
+		vm, err := finder.VirtualMachine(ctx, "DC0_H0_VM0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		folder, err := finder.Folder(ctx, "/DC0/vm")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tagManager := tags.NewManager(restClient)
+		categoryID, err := tagManager.CreateCategory(ctx, &tags.Category{Name: "openshift-cluster-abcde", Cardinality: "SINGLE"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tagID, err := tagManager.CreateTag(ctx, &tags.Tag{Name: "cluster-abcde", CategoryID: categoryID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, ref := range []mo.Reference{vm, folder} {
+			if err := tagManager.AttachTag(ctx, tagID, ref); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		o := &ClusterUninstaller{
+			ClusterID:  "cluster",
+			InfraID:    "cluster-abcde",
+			Client:     client,
+			RestClient: restClient,
+		}
+		resources, err := o.Inventory(ctx)
+		if !assert.NoError(t, err) {
+			return
+		}
+		filter := "tag cluster-abcde"
+		assert.Equal(t, []providers.Resource{
+			{Type: "VirtualMachine", ID: fmt.Sprintf("DC0_H0_VM0 (%s)", vm.Reference().Value), Filter: filter, Ownership: providers.Owned},
+			{Type: "Folder", ID: fmt.Sprintf("vm (%s)", folder.Reference().Value), Filter: filter, Ownership: providers.Owned},
+			{Type: "Tag", ID: "cluster-abcde", Filter: filter, Ownership: providers.Owned},
+			{Type: "TagCategory", ID: "openshift-cluster-abcde", Filter: filter, Ownership: providers.Owned},
+		}, resources)
+
+		o.InfraID = "other"
+		resources, err = o.Inventory(ctx)
+		if assert.NoError(t, err) {
+			filter := "tag other"
+			assert.Equal(t, []providers.Resource{
+				{Type: "Tag", ID: "other", Filter: filter, Ownership: providers.Owned},
+				{Type: "TagCategory", ID: "openshift-other", Filter: filter, Ownership: providers.Owned},
+			}, resources)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package vsphere
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vapi/rest"
+	_ "github.com/vmware/govmomi/vapi/simulator"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+
+	"github.com/openshift/installer/pkg/destroy/journal"
+)
+
+func TestRunJournal(t *testing.T) {
+	simulator.Test(func(ctx context.Context, client *vim25.Client) {
+		restClient := rest.NewClient(client)
+		require.NoError(t, restClient.Login(ctx, simulator.DefaultLogin))
+
+		finder := find.NewFinder(client)
+		vm, err := finder.VirtualMachine(ctx, "DC0_H0_VM0")
+		require.NoError(t, err)
+		parent, err := finder.Folder(ctx, "/DC0/vm")
+		require.NoError(t, err)
+		folder, err := parent.CreateFolder(ctx, "cluster-abcde")
+		require.NoError(t, err)
+
+		tagManager := tags.NewManager(restClient)
+		categoryID, err := tagManager.CreateCategory(ctx, &tags.Category{Name: "openshift-cluster-abcde", Cardinality: "SINGLE"})
+		require.NoError(t, err)
+		tagID, err := tagManager.CreateTag(ctx, &tags.Tag{Name: "cluster-abcde", CategoryID: categoryID})
+		require.NoError(t, err)
+		for _, ref := range []mo.Reference{vm, folder} {
+			require.NoError(t, tagManager.AttachTag(ctx, tagID, ref))
+		}
+
+		dir, err := ioutil.TempDir("", "journal")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		j, err := journal.Open(dir)
+		require.NoError(t, err)
+		defer j.Close()
+
+		logger := logrus.New()
+		logger.Out = ioutil.Discard
+		o := &ClusterUninstaller{
+			ClusterID:  "cluster",
+			InfraID:    "cluster-abcde",
+			Client:     client,
+			RestClient: restClient,
+			Logger:     logger,
+		}
+		o.SetJournal(j)
+		require.NoError(t, o.Run(ctx))
+		assert.ElementsMatch(t, []string{
+			vm.Reference().String(),
+			folder.Reference().String(),
+			"Tag:cluster-abcde",
+			"TagCategory:openshift-cluster-abcde",
+		}, j.Deleted())
+		assert.Empty(t, j.Failures())
+
+		// The tag and its category are gone, so a resumed uninstall must
+		// not try to delete them again.
+		assert.NoError(t, o.Run(ctx))
+	})
+}
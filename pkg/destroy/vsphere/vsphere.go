@@ -15,6 +15,7 @@ import (
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
 
+	"github.com/openshift/installer/pkg/destroy/journal"
 	"github.com/openshift/installer/pkg/destroy/providers"
 	installertypes "github.com/openshift/installer/pkg/types"
 	vspheretypes "github.com/openshift/installer/pkg/types/vsphere"
@@ -29,6 +30,10 @@ type ClusterUninstaller struct {
 	RestClient *rest.Client
 
 	Logger logrus.FieldLogger
+
+	// journal, if set, records the deleted and failed objects by managed
+	// object reference.
+	journal *journal.Journal
 }
 
 // New returns an VSphere destroyer from ClusterMetadata.
@@ -52,17 +57,30 @@ func New(logger logrus.FieldLogger, metadata *installertypes.ClusterMetadata) (p
 	}, nil
 }
 
-func deleteVirtualMachines(ctx context.Context, client *vim25.Client, virtualMachineMoList []mo.VirtualMachine, logger logrus.FieldLogger) error {
+// SetJournal sets the journal used to record and resume the uninstall.
+// Virtual machines and folders are found through the cluster tag, so deleted
+// ones are not found again, but the tag and its category are skipped on
+// resume once the journal records them as deleted.
+func (o *ClusterUninstaller) SetJournal(j *journal.Journal) {
+	o.journal = j
+}
+
+func deleteVirtualMachines(ctx context.Context, client *vim25.Client, virtualMachineMoList []mo.VirtualMachine, j *journal.Journal, logger logrus.FieldLogger) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Minute*30)
 	defer cancel()
 
 	if len(virtualMachineMoList) != 0 {
 		for _, vmMO := range virtualMachineMoList {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 			virtualMachineLogger := logger.WithField("VirtualMachine", vmMO.Name)
+			id := vmMO.Reference().String()
 			vm := object.NewVirtualMachine(client, vmMO.Reference())
 			if vmMO.Summary.Runtime.PowerState == "poweredOn" {
 				task, err := vm.PowerOff(ctx)
 				if err != nil {
+					recordFailed(ctx, j, virtualMachineLogger, id, err)
 					return err
 				}
 				task.Wait(ctx)
@@ -70,29 +88,38 @@ func deleteVirtualMachines(ctx context.Context, client *vim25.Client, virtualMac
 			}
 
 			task, err := vm.Destroy(ctx)
+			if err == nil {
+				err = task.Wait(ctx)
+			}
 			if err != nil {
+				recordFailed(ctx, j, virtualMachineLogger, id, err)
 				return err
 			}
-			task.Wait(ctx)
+			recordDeleted(j, virtualMachineLogger, id)
 			virtualMachineLogger.Info("Destroyed")
 		}
 	}
 	return nil
 }
-func deleteFolder(ctx context.Context, client *vim25.Client, folderMoList []mo.Folder, logger logrus.FieldLogger) error {
+func deleteFolder(ctx context.Context, client *vim25.Client, folderMoList []mo.Folder, j *journal.Journal, logger logrus.FieldLogger) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*60)
 	defer cancel()
 
 	// If there are no children in the folder go ahead an remove it
 	if len(folderMoList[0].ChildEntity) == 0 {
 		folderLogger := logger.WithField("Folder", folderMoList[0].Name)
+		id := folderMoList[0].Reference().String()
 
 		folder := object.NewFolder(client, folderMoList[0].Reference())
 		task, err := folder.Destroy(ctx)
+		if err == nil {
+			err = task.Wait(ctx)
+		}
 		if err != nil {
+			recordFailed(ctx, j, folderLogger, id, err)
 			return err
 		}
-		task.Wait(ctx)
+		recordDeleted(j, folderLogger, id)
 		folderLogger.Info("Destroyed")
 	} else {
 		return errors.Errorf("Expected Folder %s to be empty", folderMoList[0].Name)
@@ -122,7 +149,24 @@ func getVirtualMachineManagedObjects(ctx context.Context, client *vim25.Client,
 
 func getAttachedObjectsOnTag(ctx context.Context, client *rest.Client, tagName string) ([]tags.AttachedObjects, error) {
 	tagManager := tags.NewManager(client)
-	attached, err := tagManager.GetAttachedObjectsOnTags(ctx, []string{tagName})
+	allTags, err := tagManager.GetTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The associations are looked up by tag ID, so find the ID of the tag.
+	// Nothing is attached to a tag that does not exist.
+	var tagIDs []string
+	for _, tag := range allTags {
+		if tag.Name == tagName {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+	}
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+
+	attached, err := tagManager.GetAttachedObjectsOnTags(ctx, tagIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -148,13 +192,32 @@ func deleteTagCategory(ctx context.Context, client *rest.Client, categoryID stri
 	return err
 }
 
+// recordDeleted records in the journal that the object with the given ID was
+// deleted.
+func recordDeleted(j *journal.Journal, logger logrus.FieldLogger, id string) {
+	if err := j.RecordDeleted(id); err != nil {
+		logger.WithError(err).Debug("could not record deletion in journal")
+	}
+}
+
+// recordFailed records in the journal that an attempt to delete the object
+// with the given ID failed, unless the attempt was interrupted.
+func recordFailed(ctx context.Context, j *journal.Journal, logger logrus.FieldLogger, id string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	if err := j.RecordFailed(id, err); err != nil {
+		logger.WithError(err).Debug("could not record failure in journal")
+	}
+}
+
 // Run is the entrypoint to start the uninstall process.
-func (o *ClusterUninstaller) Run() error {
+func (o *ClusterUninstaller) Run(ctx context.Context) error {
 	var folderList []types.ManagedObjectReference
 	var virtualMachineList []types.ManagedObjectReference
 
 	o.Logger.Debug("Find attached objects on tag")
-	tagAttachedObjects, err := getAttachedObjectsOnTag(context.TODO(), o.RestClient, o.InfraID)
+	tagAttachedObjects, err := getAttachedObjectsOnTag(ctx, o.RestClient, o.InfraID)
 	if err != nil {
 		return err
 	}
@@ -181,12 +244,12 @@ func (o *ClusterUninstaller) Run() error {
 
 	if len(virtualMachineList) > 0 {
 		o.Logger.Debug("Find VirtualMachine objects")
-		virtualMachineMoList, err := getVirtualMachineManagedObjects(context.TODO(), o.Client, virtualMachineList)
+		virtualMachineMoList, err := getVirtualMachineManagedObjects(ctx, o.Client, virtualMachineList)
 		if err != nil {
 			return err
 		}
 		o.Logger.Debug("Delete VirtualMachines")
-		err = deleteVirtualMachines(context.TODO(), o.Client, virtualMachineMoList, o.Logger)
+		err = deleteVirtualMachines(ctx, o.Client, virtualMachineMoList, o.journal, o.Logger)
 		if err != nil {
 			return err
 		}
@@ -196,14 +259,14 @@ func (o *ClusterUninstaller) Run() error {
 
 	if len(folderList) > 0 {
 		o.Logger.Debug("Find Folder objects")
-		folderMoList, err := getFolderManagedObjects(context.TODO(), o.Client, folderList)
+		folderMoList, err := getFolderManagedObjects(ctx, o.Client, folderList)
 		if err != nil {
 			o.Logger.Errorln(err)
 			return err
 		}
 
 		o.Logger.Debug("Delete Folder")
-		err = deleteFolder(context.TODO(), o.Client, folderMoList, o.Logger)
+		err = deleteFolder(ctx, o.Client, folderMoList, o.journal, o.Logger)
 		if err != nil {
 			o.Logger.Errorln(err)
 			return err
@@ -214,19 +277,33 @@ func (o *ClusterUninstaller) Run() error {
 
 	o.Logger.Debug("Delete tag")
 	tagLogger := o.Logger.WithField("Tag", o.InfraID)
-	if err = deleteTag(context.TODO(), o.RestClient, o.InfraID); err != nil {
-		tagLogger.Errorln(err)
-		return err
+	tagID := "Tag:" + o.InfraID
+	if o.journal.IsDeleted(tagID) {
+		tagLogger.Debug("Already destroyed according to the journal")
+	} else {
+		if err = deleteTag(ctx, o.RestClient, o.InfraID); err != nil {
+			recordFailed(ctx, o.journal, tagLogger, tagID, err)
+			tagLogger.Errorln(err)
+			return err
+		}
+		recordDeleted(o.journal, tagLogger, tagID)
+		tagLogger.Info("Destroyed")
 	}
-	tagLogger.Info("Destroyed")
 
 	o.Logger.Debug("Delete tag category")
 	tcLogger := o.Logger.WithField("TagCategory", "openshift-"+o.InfraID)
-	if err = deleteTagCategory(context.TODO(), o.RestClient, "openshift-"+o.InfraID); err != nil {
-		tcLogger.Errorln(err)
-		return err
+	categoryID := "TagCategory:openshift-" + o.InfraID
+	if o.journal.IsDeleted(categoryID) {
+		tcLogger.Debug("Already destroyed according to the journal")
+	} else {
+		if err = deleteTagCategory(ctx, o.RestClient, "openshift-"+o.InfraID); err != nil {
+			recordFailed(ctx, o.journal, tcLogger, categoryID, err)
+			tcLogger.Errorln(err)
+			return err
+		}
+		recordDeleted(o.journal, tcLogger, categoryID)
+		tcLogger.Info("Destroyed")
 	}
-	tcLogger.Info("Destroyed")
 
 	return nil
 }
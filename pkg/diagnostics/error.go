@@ -29,6 +29,10 @@ type Err struct {
 	// diagnostics for the error. When writing messages, make sure to keep in mind
 	// that the audience for message is end-users who might not be experts.
 	Message string
+
+	// Remediation is an optional link to documentation on how to fix or
+	// avoid the error.
+	Remediation string
 }
 
 // Unwrap allows the error to be unwrapped.
@@ -37,7 +41,7 @@ func (e *Err) Unwrap() error { return e.Orig }
 // Error returns a string representation of the Err. The returned value
 // is expected to be a single value.
 // The format of the error string returned is,
-// `error(<Reason>) from <Source>: <Message>: <Cause of Orig>`
+// `error(<Reason>) from <Source>: <Message> (see <Remediation>): <Cause of Orig>`
 func (e *Err) Error() string {
 	buf := &bytes.Buffer{}
 	if len(e.Source) > 0 {
@@ -49,6 +53,9 @@ func (e *Err) Error() string {
 		msg = breakre.ReplaceAllString(msg, " ")
 		fmt.Fprintf(buf, ": %s", msg)
 	}
+	if len(e.Remediation) > 0 {
+		fmt.Fprintf(buf, " (see %s)", e.Remediation)
+	}
 	if c := errors.Cause(e.Orig); c != nil {
 		fmt.Fprintf(buf, ": %s", errors.Cause(e.Orig))
 	}
@@ -64,6 +71,9 @@ func (e *Err) Error() string {
 // Message:
 // <Message>
 //
+// Remediation:
+// <Remediation>
+//
 // Original:
 // <Orig>
 func (e *Err) Print(w io.Writer) {
@@ -73,6 +83,10 @@ func (e *Err) Print(w io.Writer) {
 		fmt.Fprintf(w, "\nMessage:\n")
 		fmt.Fprintln(w, e.Message)
 	}
+	if len(e.Remediation) > 0 {
+		fmt.Fprintf(w, "\nRemediation:\n")
+		fmt.Fprintln(w, e.Remediation)
+	}
 	fmt.Fprintf(w, "\nOriginal error:\n")
 	fmt.Fprintln(w, e.Orig)
 }
@@ -0,0 +1,4 @@
+// Package events emits a machine-readable stream of installer events as
+// newline-delimited JSON. The schema is documented in docs/user/events.md;
+// any incompatible change to it must increment Version.
+package events
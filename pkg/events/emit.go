@@ -0,0 +1,35 @@
+package events
+
+import (
+	"time"
+)
+
+// PhaseStarted emits a phase-start event.
+func PhaseStarted(name string) {
+	Emit(Event{Type: PhaseStart, Phase: &Phase{Name: name}})
+}
+
+// PhaseStopped emits a phase-stop event.
+func PhaseStopped(name string, duration time.Duration) {
+	Emit(Event{Type: PhaseStop, Phase: &Phase{Name: name, DurationSeconds: duration.Seconds()}})
+}
+
+// AssetFetched emits an asset-fetch event.
+func AssetFetched(name string) {
+	Emit(Event{Type: AssetFetch, Asset: &Asset{Name: name}})
+}
+
+// AssetLoaded emits an asset-load event.
+func AssetLoaded(name string, source string) {
+	Emit(Event{Type: AssetLoad, Asset: &Asset{Name: name, Source: source}})
+}
+
+// AssetGenerated emits an asset-generate event.
+func AssetGenerated(name string) {
+	Emit(Event{Type: AssetGenerate, Asset: &Asset{Name: name}})
+}
+
+// BootstrapStatusChanged emits a bootstrap-status event.
+func BootstrapStatusChanged(status string, message string) {
+	Emit(Event{Type: BootstrapStatus, Bootstrap: &Bootstrap{Status: status, Message: message}})
+}
@@ -0,0 +1,224 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/diagnostics"
+)
+
+// Version is the version of the event schema.
+const Version = 1
+
+// Type is the type of an event.
+type Type string
+
+const (
+	// PhaseStart is emitted when a timed installer phase starts.
+	PhaseStart Type = "phase-start"
+
+	// PhaseStop is emitted when a timed installer phase completes.
+	PhaseStop Type = "phase-stop"
+
+	// AssetFetch is emitted when the asset store starts fetching an asset.
+	AssetFetch Type = "asset-fetch"
+
+	// AssetLoad is emitted when the asset store loads an asset from the
+	// state file or the target directory.
+	AssetLoad Type = "asset-load"
+
+	// AssetGenerate is emitted when the asset store generates an asset.
+	AssetGenerate Type = "asset-generate"
+
+	// TerraformProgress is emitted when Terraform reports progress on a
+	// resource.
+	TerraformProgress Type = "terraform-progress"
+
+	// BootstrapStatus is emitted when the status of bootstrapping changes.
+	BootstrapStatus Type = "bootstrap-status"
+
+	// ClusterOperatorCondition is emitted when a condition of a
+	// ClusterOperator changes.
+	ClusterOperatorCondition Type = "clusteroperator-condition"
+
+	// Error is emitted when the installer fails.
+	Error Type = "error"
+)
+
+// Event is a single installer event. Exactly one of the type-specific
+// fields is set, according to the event's type.
+type Event struct {
+	// Version is the version of the event schema.
+	Version int `json:"version"`
+
+	// Time is when the event occurred.
+	Time time.Time `json:"time"`
+
+	// Type is the type of the event.
+	Type Type `json:"type"`
+
+	Phase           *Phase           `json:"phase,omitempty"`
+	Asset           *Asset           `json:"asset,omitempty"`
+	Terraform       *Terraform       `json:"terraform,omitempty"`
+	Bootstrap       *Bootstrap       `json:"bootstrap,omitempty"`
+	ClusterOperator *ClusterOperator `json:"clusterOperator,omitempty"`
+	Error           *Err             `json:"error,omitempty"`
+}
+
+// Phase describes a timed installer phase.
+type Phase struct {
+	// Name is the name of the phase, e.g. "Bootstrap Complete".
+	Name string `json:"name"`
+
+	// DurationSeconds is how long the phase took. It is only set when the
+	// phase stops.
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+}
+
+// Asset describes an asset handled by the asset store.
+type Asset struct {
+	// Name is the human-friendly name of the asset.
+	Name string `json:"name"`
+
+	// Source is where a loaded asset came from: "state file" or
+	// "target directory".
+	Source string `json:"source,omitempty"`
+}
+
+// Terraform describes progress on a Terraform resource.
+type Terraform struct {
+	// Resource is the Terraform address of the resource.
+	Resource string `json:"resource"`
+
+	// Action is what Terraform is doing to the resource: "create",
+	// "modify", "destroy" or "read".
+	Action string `json:"action"`
+
+	// Status is "started", "in-progress" or "complete".
+	Status string `json:"status"`
+
+	// ID is the provider's ID of the resource, when Terraform reports it.
+	ID string `json:"id,omitempty"`
+}
+
+// Bootstrap describes the status of bootstrapping.
+type Bootstrap struct {
+	// Status is a short machine-readable status, e.g. "api-up".
+	Status string `json:"status"`
+
+	// Message is a human-readable description of the status.
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterOperator describes a ClusterOperator condition.
+type ClusterOperator struct {
+	// Name is the name of the ClusterOperator.
+	Name string `json:"name"`
+
+	// Condition is the type of the condition, e.g. "Available".
+	Condition string `json:"condition"`
+
+	// Status is the status of the condition: "True", "False" or "Unknown".
+	Status string `json:"status"`
+
+	// Reason is the reason of the condition.
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the message of the condition.
+	Message string `json:"message,omitempty"`
+}
+
+// Err is a diagnostics.Err describing why the installer failed.
+type Err struct {
+	Source      string `json:"source,omitempty"`
+	Reason      string `json:"reason"`
+	Message     string `json:"message"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+var (
+	mu        sync.Mutex
+	encoder   *json.Encoder
+	now       = time.Now
+	diagnosis *diagnostics.Err
+)
+
+// SetOutput starts writing events to w. A nil w stops writing events.
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	if w == nil {
+		encoder = nil
+		return
+	}
+	encoder = json.NewEncoder(w)
+}
+
+// Enabled returns true if events are being written.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return encoder != nil
+}
+
+// Emit writes the event, filling in its version and time. It does nothing
+// if events are not being written.
+func Emit(event Event) {
+	mu.Lock()
+	defer mu.Unlock()
+	if encoder == nil {
+		return
+	}
+	event.Version = Version
+	if event.Time.IsZero() {
+		event.Time = now().UTC()
+	}
+	if err := encoder.Encode(event); err != nil {
+		logrus.Debugf("Failed to write event: %v", err)
+	}
+}
+
+// Diagnosed records a diagnosis of a failure, so that if the failure turns
+// out to be fatal its error event carries the diagnosis.
+func Diagnosed(err *diagnostics.Err) {
+	mu.Lock()
+	defer mu.Unlock()
+	diagnosis = err
+}
+
+// FatalHook is a logrus hook which emits an error event for fatal log
+// entries.
+type FatalHook struct{}
+
+var _ logrus.Hook = (*FatalHook)(nil)
+
+// Levels returns the levels the hook fires for.
+func (h *FatalHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.FatalLevel, logrus.PanicLevel}
+}
+
+// Fire emits an error event for the entry. If the entry's message includes
+// a recorded diagnosis, the event carries the diagnosis; otherwise its
+// reason is "Unknown" and its message is the entry's message.
+func (h *FatalHook) Fire(entry *logrus.Entry) error {
+	mu.Lock()
+	diag := diagnosis
+	mu.Unlock()
+
+	e := &Err{Reason: "Unknown", Message: entry.Message}
+	if diag != nil && strings.Contains(entry.Message, diag.Error()) {
+		e = &Err{
+			Source:      diag.Source,
+			Reason:      diag.Reason,
+			Message:     diag.Message,
+			Remediation: diag.Remediation,
+		}
+	}
+	Emit(Event{Type: Error, Error: e})
+	return nil
+}
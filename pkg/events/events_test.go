@@ -0,0 +1,89 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/diagnostics"
+)
+
+func setup(t *testing.T) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	SetOutput(buf)
+	now = func() time.Time { return time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() {
+		SetOutput(nil)
+		now = time.Now
+		Diagnosed(nil)
+	})
+	return buf
+}
+
+func TestEmit(t *testing.T) {
+	buf := setup(t)
+	PhaseStarted("Infrastructure")
+	PhaseStopped("Infrastructure", 90*time.Second)
+	AssetLoaded("Install Config", "target directory")
+
+	assert.Equal(t, `{"version":1,"time":"2020-10-01T12:00:00Z","type":"phase-start","phase":{"name":"Infrastructure"}}
+{"version":1,"time":"2020-10-01T12:00:00Z","type":"phase-stop","phase":{"name":"Infrastructure","durationSeconds":90}}
+{"version":1,"time":"2020-10-01T12:00:00Z","type":"asset-load","asset":{"name":"Install Config","source":"target directory"}}
+`, buf.String())
+}
+
+func TestEmitDisabled(t *testing.T) {
+	assert.False(t, Enabled())
+	// Must not panic without an output.
+	PhaseStarted("Infrastructure")
+}
+
+func TestFatalHook(t *testing.T) {
+	diag := &diagnostics.Err{
+		Source:      "Infrastructure Provider",
+		Reason:      "VPCLimitExceeded",
+		Message:     "The maximum number of VPCs has been reached.",
+		Remediation: "https://example.com/vpc",
+	}
+
+	cases := []struct {
+		name      string
+		diagnosis *diagnostics.Err
+		message   string
+		expected  Err
+	}{{
+		name:     "undiagnosed",
+		message:  "failed to fetch Cluster: boom",
+		expected: Err{Reason: "Unknown", Message: "failed to fetch Cluster: boom"},
+	}, {
+		name:      "diagnosed",
+		diagnosis: diag,
+		message:   errors.Wrap(diag, "failed to apply Terraform").Error(),
+		expected:  Err{Source: diag.Source, Reason: diag.Reason, Message: diag.Message, Remediation: diag.Remediation},
+	}, {
+		name:      "unrelated diagnosis",
+		diagnosis: diag,
+		message:   "failed to initialize the cluster",
+		expected:  Err{Reason: "Unknown", Message: "failed to initialize the cluster"},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := setup(t)
+			Diagnosed(tc.diagnosis)
+			err := (&FatalHook{}).Fire(&logrus.Entry{Level: logrus.FatalLevel, Message: tc.message})
+			assert.NoError(t, err)
+
+			var event Event
+			if assert.NoError(t, json.Unmarshal(buf.Bytes(), &event)) {
+				assert.Equal(t, Error, event.Type)
+				assert.Equal(t, &tc.expected, event.Error)
+			}
+		})
+	}
+}
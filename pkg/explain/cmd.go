@@ -1,17 +1,22 @@
 package explain
 
 import (
-	"io/ioutil"
 	"os"
 	"strings"
 
-	"github.com/openshift/installer/data"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
+type options struct {
+	recursive bool
+	template  bool
+	platform  string
+}
+
 // NewCmd returns a subcommand for explain
 func NewCmd() *cobra.Command {
+	opts := &options{}
 	cmd := &cobra.Command{
 		Use:   "explain",
 		Short: "List the fields for supported InstallConfig versions",
@@ -25,14 +30,24 @@ installconfig.<fieldName>[.<fieldName>]
 openshift-install explain installconfig
 
 # Get the documentation of a AWS platform
-openshift-install explain installconfig.platform.aws`,
-		RunE: runCmd,
-	}
+openshift-install explain installconfig.platform.aws
+
+# List all the fields of the AWS platform, and their fields
+openshift-install explain installconfig.platform.aws --recursive
 
+# Print a commented install-config skeleton for AWS
+openshift-install explain installconfig --template --platform aws > install-config.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCmd(opts, args)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.recursive, "recursive", false, "Print the fields of the fields, recursively, without their descriptions")
+	cmd.Flags().BoolVar(&opts.template, "template", false, "Print a commented YAML skeleton of the fields, with their descriptions, defaults and valid values")
+	cmd.Flags().StringVar(&opts.platform, "platform", "", "Limit the platform sections of the --template to this platform (e.g. \"aws\")")
 	return cmd
 }
 
-func runCmd(cmd *cobra.Command, args []string) error {
+func runCmd(opts *options, args []string) error {
 	if len(args) == 0 {
 		return errors.Errorf("You must specify the type of resource to explain\n")
 	}
@@ -40,25 +55,14 @@ func runCmd(cmd *cobra.Command, args []string) error {
 		return errors.Errorf("We accept only this format: explain RESOURCE\n")
 	}
 
-	file, err := data.Assets.Open(installConfigCRDFileName)
-	if err != nil {
-		return errors.Wrap(err, "failed to load InstallConfig CRD")
-	}
-	defer file.Close()
-
-	raw, err := ioutil.ReadAll(file)
-	if err != nil {
-		return errors.Wrap(err, "failed to read InstallConfig CRD")
-	}
-
 	resource, path := splitDotNotation(args[0])
 	if resource != "installconfig" {
 		return errors.Errorf("only installconfig resource is supported")
 	}
 
-	schema, err := loadSchema(raw)
+	schema, err := InstallConfigSchema()
 	if err != nil {
-		return errors.Wrap(err, "failed to load schema")
+		return err
 	}
 
 	fschema, err := lookup(schema, path)
@@ -66,10 +70,24 @@ func runCmd(cmd *cobra.Command, args []string) error {
 		return errors.Wrapf(err, "failed to load schema for the field %s", strings.Join(path, "."))
 	}
 
+	if opts.template {
+		if opts.platform != "" {
+			if _, err := lookup(schema, []string{"platform", opts.platform}); err != nil {
+				return errors.Errorf("unknown platform %q", opts.platform)
+			}
+		}
+		t := templater{Writer: os.Stdout, Platform: opts.platform}
+		return t.PrintTemplate(fschema, len(path) == 0)
+	}
+
 	p := printer{Writer: os.Stdout}
 	p.PrintKindAndVersion()
 	p.PrintResource(fschema)
-	p.PrintFields(fschema)
+	if opts.recursive {
+		p.PrintFieldsRecursive(fschema)
+	} else {
+		p.PrintFields(fschema)
+	}
 	return nil
 }
 
@@ -1,5 +1,34 @@
 package explain
 
+import (
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/openshift/installer/data"
+)
+
 const (
 	installConfigCRDFileName = "install.openshift.io_installconfigs.yaml"
 )
+
+// InstallConfigSchema returns the OpenAPI schema of the InstallConfig CRD.
+func InstallConfigSchema() (*apiextv1.JSONSchemaProps, error) {
+	file, err := data.Assets.Open(installConfigCRDFileName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load InstallConfig CRD")
+	}
+	defer file.Close()
+
+	raw, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read InstallConfig CRD")
+	}
+
+	schema, err := loadSchema(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load schema")
+	}
+	return schema, nil
+}
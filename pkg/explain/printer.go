@@ -41,42 +41,41 @@ func (p printer) PrintResource(schema *apiextv1.JSONSchemaProps) {
 }
 
 func (p printer) PrintFields(schema *apiextv1.JSONSchemaProps) {
-	required := sets.NewString(schema.Required...)
-	properties := map[string]apiextv1.JSONSchemaProps{}
-	if schema.Items != nil && schema.Items.Schema != nil && len(schema.Items.Schema.Properties) > 0 {
-		properties = schema.Items.Schema.Properties
-		required.Insert(schema.Items.Schema.Required...)
-	}
-	if len(schema.Properties) > 0 {
-		properties = schema.Properties
-	}
+	properties, required := fields(schema)
 	if len(properties) == 0 {
 		return
 	}
 
-	var keys []string
-	for pname := range properties {
-		keys = append(keys, pname)
-	}
-	sort.Strings(keys)
-
 	io.WriteString(p.Writer, "FIELDS:\n")
-	for _, pname := range keys {
+	for _, pname := range sortedKeys(properties) {
 		pschema := properties[pname]
 		p.printField(pname, required.Has(pname), &pschema)
 	}
 }
 
-func (p printer) printField(name string, required bool, schema *apiextv1.JSONSchemaProps) {
-	ftype := schema.Type
-	if schema.Items != nil && schema.Items.Schema != nil {
-		ftype = fmt.Sprintf("[]%s", schema.Items.Schema.Type)
+// PrintFieldsRecursive prints the names and types of the fields, and of their
+// fields, indented by their depth.
+func (p printer) PrintFieldsRecursive(schema *apiextv1.JSONSchemaProps) {
+	properties, _ := fields(schema)
+	if len(properties) == 0 {
+		return
 	}
-	title := fmt.Sprintf("%s <%s>", name, ftype)
-	if required {
-		title = fmt.Sprintf("%s -required-", title)
+
+	io.WriteString(p.Writer, "FIELDS:\n")
+	p.printFieldsRecursive(schema, fieldIndent)
+}
+
+func (p printer) printFieldsRecursive(schema *apiextv1.JSONSchemaProps, indent int) {
+	properties, required := fields(schema)
+	for _, pname := range sortedKeys(properties) {
+		pschema := properties[pname]
+		write(indent, p.Writer, fieldTitle(pname, required.Has(pname), &pschema))
+		p.printFieldsRecursive(&pschema, indent+2)
 	}
-	write(fieldIndent, p.Writer, title)
+}
+
+func (p printer) printField(name string, required bool, schema *apiextv1.JSONSchemaProps) {
+	write(fieldIndent, p.Writer, fieldTitle(name, required, schema))
 
 	if schema.Default != nil {
 		write(fieldDescIndent, p.Writer, fmt.Sprintf("Default: %s", defaultString(*schema.Default)))
@@ -99,6 +98,42 @@ func (p printer) printField(name string, required bool, schema *apiextv1.JSONSch
 	io.WriteString(p.Writer, "\n")
 }
 
+// fields returns the properties of an object, or of the items of an array,
+// and the names of those that are required.
+func fields(schema *apiextv1.JSONSchemaProps) (map[string]apiextv1.JSONSchemaProps, sets.String) {
+	required := sets.NewString(schema.Required...)
+	properties := map[string]apiextv1.JSONSchemaProps{}
+	if schema.Items != nil && schema.Items.Schema != nil && len(schema.Items.Schema.Properties) > 0 {
+		properties = schema.Items.Schema.Properties
+		required.Insert(schema.Items.Schema.Required...)
+	}
+	if len(schema.Properties) > 0 {
+		properties = schema.Properties
+	}
+	return properties, required
+}
+
+func fieldTitle(name string, required bool, schema *apiextv1.JSONSchemaProps) string {
+	ftype := schema.Type
+	if schema.Items != nil && schema.Items.Schema != nil {
+		ftype = fmt.Sprintf("[]%s", schema.Items.Schema.Type)
+	}
+	title := fmt.Sprintf("%s <%s>", name, ftype)
+	if required {
+		title = fmt.Sprintf("%s -required-", title)
+	}
+	return title
+}
+
+func sortedKeys(properties map[string]apiextv1.JSONSchemaProps) []string {
+	keys := make([]string, 0, len(properties))
+	for pname := range properties {
+		keys = append(keys, pname)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func write(indentLevel int, w io.Writer, s string) {
 	if strings.TrimSpace(s) == "" {
 		io.WriteString(w, "\n")
@@ -309,3 +309,30 @@ RESOURCE: <string>
 		})
 	}
 }
+
+func Test_PrintFieldsRecursive(t *testing.T) {
+	schema, err := loadSchema(loadCRD(t))
+	assert.NoError(t, err)
+
+	got, err := lookup(schema, []string{"platform", "aws"})
+	assert.NoError(t, err)
+	buf := &bytes.Buffer{}
+	(printer{Writer: buf}).PrintFieldsRecursive(got)
+	assert.Equal(t, `FIELDS:
+    amiID <string>
+    defaultMachinePlatform <object>
+      amiID <string>
+      rootVolume <object>
+        iops <integer>
+        kmsKeyARN <string>
+        size <integer> -required-
+        type <string> -required-
+      type <string>
+      zones <[]string>
+    region <string> -required-
+    serviceEndpoints <[]object>
+      name <string> -required-
+      url <string> -required-
+    subnets <[]string>
+    userTags <object>`, strings.TrimSpace(buf.String()))
+}
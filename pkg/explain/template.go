@@ -0,0 +1,178 @@
+package explain
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+const (
+	// templateWidth is the width at which the descriptions in templates are
+	// wrapped.
+	templateWidth = 72
+)
+
+// templater prints commented YAML skeletons of the InstallConfig. Each field
+// is described by its description, default and valid values. Required fields
+// are set to their defaults, or to empty values for the user to fill in, and
+// optional fields are commented out.
+type templater struct {
+	Writer io.Writer
+
+	// Platform, if set, limits the platform sections of the template to the
+	// named platform, which is then required.
+	Platform string
+}
+
+// PrintTemplate prints a skeleton of the fields of schema. root is true if
+// schema is the schema of the whole InstallConfig.
+func (t templater) PrintTemplate(schema *apiextv1.JSONSchemaProps, root bool) error {
+	properties, _ := fields(schema)
+	if len(properties) == 0 {
+		return errors.New("templates can only be printed for fields with fields")
+	}
+
+	var lines []string
+	if root {
+		lines = append(lines, comment(schema.Description)...)
+		lines = append(lines, "")
+	}
+	for i, field := range t.fields(schema, root) {
+		if i > 0 && root {
+			lines = append(lines, "")
+		}
+		lines = append(lines, field...)
+	}
+	for _, line := range lines {
+		fmt.Fprintln(t.Writer, line)
+	}
+	return nil
+}
+
+// fields returns the lines of each field of an object, or of the items of an
+// array.
+func (t templater) fields(schema *apiextv1.JSONSchemaProps, root bool) [][]string {
+	properties, required := fields(schema)
+	var fields [][]string
+	for _, name := range sortedKeys(properties) {
+		property := properties[name]
+		if isDeprecated(&property) {
+			continue
+		}
+		if root {
+			switch name {
+			case "kind":
+				continue
+			case "apiVersion":
+				fields = append(fields, append(comment(property.Description), "apiVersion: v1"))
+				continue
+			case "metadata":
+				fields = append(fields, []string{
+					"metadata:",
+					"  # Name is the name of the cluster.",
+					`  name: ""`,
+				})
+				continue
+			}
+		}
+		if _, ok := property.Properties[t.Platform]; ok && name == "platform" {
+			property.Properties = map[string]apiextv1.JSONSchemaProps{t.Platform: property.Properties[t.Platform]}
+			property.Required = []string{t.Platform}
+		}
+		fields = append(fields, t.field(name, &property, required.Has(name)))
+	}
+	return fields
+}
+
+// field returns the lines of a field, commented out unless it is required.
+func (t templater) field(name string, schema *apiextv1.JSONSchemaProps, required bool) []string {
+	lines := comment(schema.Description)
+	if schema.Default != nil {
+		lines = append(lines, fmt.Sprintf("# Default: %s", defaultString(*schema.Default)))
+	}
+	if len(schema.Enum) > 0 {
+		lines = append(lines, fmt.Sprintf("# Valid values: %s", strings.Join(validValues(schema.Enum), ", ")))
+	}
+
+	var body []string
+	switch {
+	case schema.Type == "object" && len(schema.Properties) > 0:
+		body = append(body, name+":")
+		for _, field := range t.fields(schema, false) {
+			body = append(body, indent(field)...)
+		}
+	case schema.Type == "array" && schema.Items != nil && schema.Items.Schema != nil && len(schema.Items.Schema.Properties) > 0:
+		body = append(body, name+":", "-")
+		for _, field := range t.fields(schema.Items.Schema, false) {
+			body = append(body, indent(field)...)
+		}
+	default:
+		body = append(body, fmt.Sprintf("%s: %s", name, templateValue(schema)))
+	}
+
+	if !required {
+		for i, line := range body {
+			body[i] = strings.TrimRight("# "+line, " ")
+		}
+	}
+	return append(lines, body...)
+}
+
+// templateValue returns the default of a field, or its empty value.
+func templateValue(schema *apiextv1.JSONSchemaProps) string {
+	if schema.Default != nil {
+		return defaultString(*schema.Default)
+	}
+	switch schema.Type {
+	case "integer", "number":
+		return "0"
+	case "boolean":
+		return "false"
+	case "array":
+		return "[]"
+	case "object":
+		return "{}"
+	default:
+		return `""`
+	}
+}
+
+// isDeprecated returns true if the description of the field says that it is
+// deprecated.
+func isDeprecated(schema *apiextv1.JSONSchemaProps) bool {
+	return strings.HasPrefix(schema.Description, "Deprecated") || strings.Contains(schema.Description, "Deprecated:")
+}
+
+// comment returns the description as comment lines, wrapped at
+// templateWidth.
+func comment(description string) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(description, "\n") {
+		line := "#"
+		for _, word := range strings.Fields(paragraph) {
+			if len(line)+1+len(word) > templateWidth && line != "#" {
+				lines = append(lines, line)
+				line = "#"
+			}
+			line += " " + word
+		}
+		if line != "#" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func indent(lines []string) []string {
+	indented := make([]string, len(lines))
+	for i, line := range lines {
+		if line != "" {
+			line = "  " + line
+		}
+		indented[i] = line
+	}
+	return indented
+}
@@ -0,0 +1,75 @@
+package explain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PrintTemplate(t *testing.T) {
+	schema, err := loadSchema(loadCRD(t))
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, (templater{Writer: buf, Platform: "aws"}).PrintTemplate(schema, true))
+	template := buf.String()
+
+	// Only the required fields are set; the rest are commented out.
+	var config map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(template), &config))
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "v1",
+		"baseDomain": "",
+		"metadata":   map[string]interface{}{"name": ""},
+		"platform": map[string]interface{}{
+			"aws": map[string]interface{}{"region": ""},
+		},
+		"pullSecret": "",
+	}, config)
+
+	assert.Contains(t, template, `
+# Publish controls how the user facing endpoints of the cluster like the
+# Kubernetes API, OpenShift routes etc. are exposed. When no strategy is
+# specified, the strategy is "External".
+# Default: "External"
+# Valid values: "", "External", "Internal"
+# publish: "External"
+`)
+	assert.Contains(t, template, `
+    # ServiceEndpoints list contains custom endpoints which will override
+    # default service endpoint of AWS Services. There must be only one
+    # ServiceEndpoint for a service.
+    # serviceEndpoints:
+    # -
+    #   # Name is the name of the AWS service. This must be provided and cannot
+    #   # be empty.
+    #   name: ""
+`)
+	assert.NotContains(t, template, "azure:", "other platforms should be left out")
+	assert.NotContains(t, template, "machineCIDR", "deprecated fields should be left out")
+}
+
+func Test_PrintTemplateField(t *testing.T) {
+	schema, err := loadSchema(loadCRD(t))
+	require.NoError(t, err)
+
+	proxy, err := lookup(schema, []string{"proxy"})
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.NoError(t, (templater{Writer: buf}).PrintTemplate(proxy, false))
+	assert.Equal(t, `# HTTPProxy is the URL of the proxy for HTTP requests.
+# httpProxy: ""
+# HTTPSProxy is the URL of the proxy for HTTPS requests.
+# httpsProxy: ""
+# NoProxy is a comma-separated list of domains and CIDRs for which the
+# proxy should not be used.
+# noProxy: ""
+`, buf.String())
+
+	region, err := lookup(schema, []string{"platform", "aws", "region"})
+	require.NoError(t, err)
+	assert.EqualError(t, (templater{Writer: buf}).PrintTemplate(region, false), "templates can only be printed for fields with fields")
+}
@@ -0,0 +1,222 @@
+// Package cluster gathers debugging data from a running cluster through its
+// API, for failures after the bootstrap host is gone.
+package cluster
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	machineclient "github.com/openshift/machine-api-operator/pkg/generated/clientset/versioned"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// machineAPINamespace is the namespace of the Machines and MachineSets.
+	machineAPINamespace = "openshift-machine-api"
+
+	// logTailLines is the number of lines of each container log gathered.
+	logTailLines = 10000
+)
+
+// Gatherer gathers debugging data from a cluster.
+type Gatherer struct {
+	Kube    kubernetes.Interface
+	Config  configclient.Interface
+	Machine machineclient.Interface
+}
+
+// NewGatherer returns a Gatherer for the cluster of config.
+func NewGatherer(config *rest.Config) (*Gatherer, error) {
+	kube, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating a Kubernetes client")
+	}
+	cfg, err := configclient.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating a config client")
+	}
+	machine, err := machineclient.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating a machine client")
+	}
+	return &Gatherer{Kube: kube, Config: cfg, Machine: machine}, nil
+}
+
+// Gather writes the debugging data to cluster-log-bundle-<gatherID>.tar.gz in
+// directory and returns its path. Data that cannot be gathered is logged and
+// skipped, so that as much as possible is gathered from an unhealthy cluster.
+func (g *Gatherer) Gather(ctx context.Context, directory string, gatherID string) (string, error) {
+	name := fmt.Sprintf("cluster-log-bundle-%s", gatherID)
+	file := filepath.Join(directory, name+".tar.gz")
+	f, err := os.Create(file)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create log bundle")
+	}
+	defer f.Close()
+
+	b := &bundle{prefix: name, gz: gzip.NewWriter(f)}
+	b.tar = tar.NewWriter(b.gz)
+
+	resources := []struct {
+		name string
+		list func() (interface{}, error)
+	}{{
+		name: "clusterversion.json",
+		list: func() (interface{}, error) {
+			return g.Config.ConfigV1().ClusterVersions().List(ctx, metav1.ListOptions{})
+		},
+	}, {
+		name: "clusteroperators.json",
+		list: func() (interface{}, error) {
+			return g.Config.ConfigV1().ClusterOperators().List(ctx, metav1.ListOptions{})
+		},
+	}, {
+		name: "nodes.json",
+		list: func() (interface{}, error) {
+			return g.Kube.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		},
+	}, {
+		name: "machines.json",
+		list: func() (interface{}, error) {
+			return g.Machine.MachineV1beta1().Machines(machineAPINamespace).List(ctx, metav1.ListOptions{})
+		},
+	}, {
+		name: "machinesets.json",
+		list: func() (interface{}, error) {
+			return g.Machine.MachineV1beta1().MachineSets(machineAPINamespace).List(ctx, metav1.ListOptions{})
+		},
+	}, {
+		name: "events.json",
+		list: func() (interface{}, error) {
+			return g.Kube.CoreV1().Events(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		},
+	}}
+	for _, resource := range resources {
+		logrus.Debugf("Gathering %s", resource.name)
+		list, err := resource.list()
+		if err != nil {
+			logrus.Warnf("Failed to gather %s: %v", resource.name, err)
+			continue
+		}
+		data, err := json.MarshalIndent(list, "", "  ")
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to marshal %s", resource.name)
+		}
+		if err := b.add(path.Join("resources", resource.name), data); err != nil {
+			return "", err
+		}
+	}
+
+	if err := g.gatherFailingPodLogs(ctx, b); err != nil {
+		return "", err
+	}
+
+	if err := b.close(); err != nil {
+		return "", errors.Wrap(err, "failed to write log bundle")
+	}
+	return file, nil
+}
+
+// gatherFailingPodLogs adds the logs of the failing pods in the operator
+// namespaces to b.
+func (g *Gatherer) gatherFailingPodLogs(ctx context.Context, b *bundle) error {
+	pods, err := g.Kube.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logrus.Warnf("Failed to list pods: %v", err)
+		return nil
+	}
+
+	tailLines := int64(logTailLines)
+	for _, pod := range pods.Items {
+		if !isOperatorNamespace(pod.Namespace) || !isFailing(&pod) {
+			continue
+		}
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			logs := map[string]*corev1.PodLogOptions{
+				status.Name + ".log": {Container: status.Name, TailLines: &tailLines},
+			}
+			if status.RestartCount > 0 {
+				logs[status.Name+".previous.log"] = &corev1.PodLogOptions{Container: status.Name, TailLines: &tailLines, Previous: true}
+			}
+			for name, opts := range logs {
+				data, err := g.Kube.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).DoRaw(ctx)
+				if err != nil {
+					logrus.Warnf("Failed to gather %s logs of pod %s/%s: %v", status.Name, pod.Namespace, pod.Name, err)
+					continue
+				}
+				if err := b.add(path.Join("pods", pod.Namespace, pod.Name, name), data); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isOperatorNamespace returns true if the namespace belongs to the platform
+// rather than to a user.
+func isOperatorNamespace(namespace string) bool {
+	return strings.HasPrefix(namespace, "openshift-") || strings.HasPrefix(namespace, "kube-")
+}
+
+// isFailing returns true if the pod has failed or any of its containers are
+// not ready or have restarted.
+func isFailing(pod *corev1.Pod) bool {
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return false
+	case corev1.PodRunning:
+	default:
+		return true
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if !status.Ready || status.RestartCount > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// bundle is a tarball of files under a common directory.
+type bundle struct {
+	prefix string
+	gz     *gzip.Writer
+	tar    *tar.Writer
+}
+
+func (b *bundle) add(name string, data []byte) error {
+	if err := b.tar.WriteHeader(&tar.Header{
+		Name:    path.Join(b.prefix, name),
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to add %s to log bundle", name)
+	}
+	if _, err := b.tar.Write(data); err != nil {
+		return errors.Wrapf(err, "failed to add %s to log bundle", name)
+	}
+	return nil
+}
+
+func (b *bundle) close() error {
+	if err := b.tar.Close(); err != nil {
+		return err
+	}
+	return b.gz.Close()
+}
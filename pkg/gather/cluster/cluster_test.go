@@ -0,0 +1,111 @@
+package cluster
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configfake "github.com/openshift/client-go/config/clientset/versioned/fake"
+	machinev1beta1 "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+	machinefake "github.com/openshift/machine-api-operator/pkg/generated/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func readBundle(t *testing.T, file string) map[string]string {
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(data)
+	}
+	return files
+}
+
+func pod(namespace, name string, phase corev1.PodPhase, ready bool, restarts int32) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status: corev1.PodStatus{
+			Phase: phase,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "operator",
+				Ready:        ready,
+				RestartCount: restarts,
+			}},
+		},
+	}
+}
+
+func TestGather(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gather-cluster")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	g := &Gatherer{
+		Kube: kubefake.NewSimpleClientset(
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "master-0"}},
+			&corev1.Event{ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-etcd", Name: "etcd.1"}, Reason: "Unhealthy"},
+			pod("openshift-etcd", "etcd-master-0", corev1.PodRunning, true, 0),
+			pod("openshift-kube-apiserver", "kube-apiserver-master-0", corev1.PodRunning, false, 0),
+			pod("openshift-ingress", "router-default-0", corev1.PodRunning, true, 3),
+			pod("openshift-authentication", "oauth-openshift-0", corev1.PodPending, false, 0),
+			pod("user", "crashing-0", corev1.PodFailed, false, 0),
+		),
+		Config: configfake.NewSimpleClientset(
+			&configv1.ClusterVersion{ObjectMeta: metav1.ObjectMeta{Name: "version"}},
+			&configv1.ClusterOperator{ObjectMeta: metav1.ObjectMeta{Name: "authentication"}},
+		),
+		Machine: machinefake.NewSimpleClientset(
+			&machinev1beta1.Machine{ObjectMeta: metav1.ObjectMeta{Namespace: machineAPINamespace, Name: "master-0"}},
+			&machinev1beta1.MachineSet{ObjectMeta: metav1.ObjectMeta{Namespace: machineAPINamespace, Name: "worker-a"}},
+		),
+	}
+
+	file, err := g.Gather(context.Background(), dir, "20201020123828")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "cluster-log-bundle-20201020123828.tar.gz"), file)
+
+	files := readBundle(t, file)
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{
+		"cluster-log-bundle-20201020123828/resources/clusterversion.json",
+		"cluster-log-bundle-20201020123828/resources/clusteroperators.json",
+		"cluster-log-bundle-20201020123828/resources/nodes.json",
+		"cluster-log-bundle-20201020123828/resources/machines.json",
+		"cluster-log-bundle-20201020123828/resources/machinesets.json",
+		"cluster-log-bundle-20201020123828/resources/events.json",
+		"cluster-log-bundle-20201020123828/pods/openshift-kube-apiserver/kube-apiserver-master-0/operator.log",
+		"cluster-log-bundle-20201020123828/pods/openshift-ingress/router-default-0/operator.log",
+		"cluster-log-bundle-20201020123828/pods/openshift-ingress/router-default-0/operator.previous.log",
+		"cluster-log-bundle-20201020123828/pods/openshift-authentication/oauth-openshift-0/operator.log",
+	}, names)
+
+	assert.Contains(t, files["cluster-log-bundle-20201020123828/resources/clusteroperators.json"], `"name": "authentication"`)
+	assert.Contains(t, files["cluster-log-bundle-20201020123828/resources/machines.json"], `"name": "master-0"`)
+	assert.Contains(t, files["cluster-log-bundle-20201020123828/resources/events.json"], `"reason": "Unhealthy"`)
+	assert.Equal(t, "fake logs", files["cluster-log-bundle-20201020123828/pods/openshift-ingress/router-default-0/operator.log"])
+}
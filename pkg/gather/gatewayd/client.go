@@ -0,0 +1,187 @@
+// Package gatewayd streams journal entries from systemd-journal-gatewayd on
+// the bootstrap host.
+package gatewayd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Port is the port systemd-journal-gatewayd listens on.
+const Port = 19531
+
+// Entry is a single journal entry.
+type Entry struct {
+	// Cursor identifies the entry in the journal.
+	Cursor string
+
+	// Unit is the systemd unit which logged the entry.
+	Unit string
+
+	// Message is the logged message.
+	Message string
+
+	// Time is when the entry was logged.
+	Time time.Time
+}
+
+// Client follows journal entries from systemd-journal-gatewayd.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	cursor     string
+}
+
+// NewClient returns a client for the gatewayd on host, authenticating with
+// the PEM-encoded client certificate and key. The server's certificate must
+// be signed by the PEM-encoded CA. Its host name is not verified, because
+// the gatewayd certificate is shared by all bootstrap hosts and carries no
+// host names or addresses.
+func NewClient(host string, certPEM, keyPEM, caPEM []byte) (*Client, error) {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load client certificate")
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("failed to load CA certificate")
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates:       []tls.Certificate{cert},
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyChain(rawCerts, roots)
+		},
+	}
+	return &Client{
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy:               http.ProxyFromEnvironment,
+				TLSClientConfig:     tlsConfig,
+				TLSHandshakeTimeout: 10 * time.Second,
+				DialContext: (&net.Dialer{
+					Timeout:   10 * time.Second,
+					KeepAlive: 30 * time.Second,
+				}).DialContext,
+			},
+		},
+		baseURL: fmt.Sprintf("https://%s", net.JoinHostPort(host, strconv.Itoa(Port))),
+	}, nil
+}
+
+// verifyChain verifies the server certificate chain against roots.
+func verifyChain(rawCerts [][]byte, roots *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return errors.New("no server certificate")
+	}
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse server certificate")
+		}
+		certs = append(certs, cert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	return err
+}
+
+// Follow streams the journal entries of units to fn until ctx is done or
+// the connection is lost. Calling Follow again resumes after the last
+// entry passed to fn.
+func (c *Client) Follow(ctx context.Context, units []string, fn func(Entry)) error {
+	query := url.Values{}
+	for _, unit := range units {
+		query.Add("_SYSTEMD_UNIT", unit)
+	}
+	// gatewayd takes flags without values, so follow cannot go through
+	// url.Values.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/entries?follow&%s", c.baseURL, query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.cursor != "" {
+		req.Header.Set("Range", fmt.Sprintf("entries=%s:1:", c.cursor))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return decodeEntries(resp.Body, func(entry Entry) {
+		c.cursor = entry.Cursor
+		fn(entry)
+	})
+}
+
+// decodeEntries decodes the stream of JSON journal entries in r, calling fn
+// for each, until r is exhausted.
+func decodeEntries(r io.Reader, fn func(Entry)) error {
+	decoder := json.NewDecoder(r)
+	for {
+		var fields map[string]json.RawMessage
+		if err := decoder.Decode(&fields); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrap(err, "failed to decode journal entry")
+		}
+
+		entry := Entry{
+			Cursor:  fieldString(fields["__CURSOR"]),
+			Unit:    fieldString(fields["_SYSTEMD_UNIT"]),
+			Message: fieldString(fields["MESSAGE"]),
+		}
+		if usec, err := strconv.ParseInt(fieldString(fields["__REALTIME_TIMESTAMP"]), 10, 64); err == nil {
+			entry.Time = time.Unix(0, usec*int64(time.Microsecond))
+		}
+		fn(entry)
+	}
+}
+
+// fieldString returns the value of a journal field. The journal exports
+// fields which are not valid UTF-8 as arrays of bytes rather than strings.
+func fieldString(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var ints []int
+	if err := json.Unmarshal(raw, &ints); err == nil {
+		b := make([]byte, 0, len(ints))
+		for _, i := range ints {
+			b = append(b, byte(i))
+		}
+		return string(b)
+	}
+	return ""
+}
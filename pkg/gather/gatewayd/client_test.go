@@ -0,0 +1,38 @@
+package gatewayd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeEntries(t *testing.T) {
+	stream := `{"__CURSOR":"s=1","__REALTIME_TIMESTAMP":"1600000000000000","_SYSTEMD_UNIT":"bootkube.service","MESSAGE":"Starting cluster-bootstrap..."}
+{"__CURSOR":"s=2","_SYSTEMD_UNIT":"release-image.service","MESSAGE":[80,117,108,108]}
+`
+	var entries []Entry
+	err := decodeEntries(strings.NewReader(stream), func(entry Entry) {
+		entries = append(entries, entry)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{
+			Cursor:  "s=1",
+			Unit:    "bootkube.service",
+			Message: "Starting cluster-bootstrap...",
+			Time:    time.Unix(1600000000, 0),
+		},
+		{
+			Cursor:  "s=2",
+			Unit:    "release-image.service",
+			Message: "Pull",
+		},
+	}, entries)
+}
+
+func TestDecodeEntriesTruncated(t *testing.T) {
+	err := decodeEntries(strings.NewReader(`{"MESSAGE":"trunc`), func(Entry) {})
+	assert.Error(t, err)
+}
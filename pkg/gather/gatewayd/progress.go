@@ -0,0 +1,81 @@
+package gatewayd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	pullingRegexp   = regexp.MustCompile(`^Pulling (\S+)\.\.\.$`)
+	renderingRegexp = regexp.MustCompile(`^Rendering (.+) [Mm]anifests\.\.\.$`)
+	etcdRegexp      = regexp.MustCompile(`^(\S+) is (healthy|unhealthy):`)
+	podStatusRegexp = regexp.MustCompile(`Pod Status:\s*(\S+)\s+(\S+)`)
+)
+
+// Progress turns the bootkube.service and release-image.service journal
+// into short progress messages.
+type Progress struct {
+	etcd map[string]bool
+	pods map[string]bool
+	last string
+}
+
+// NewProgress returns a Progress which has not observed any entries.
+func NewProgress() *Progress {
+	return &Progress{
+		etcd: map[string]bool{},
+		pods: map[string]bool{},
+	}
+}
+
+// Observe returns the progress message for the entry, if any, and whether
+// it differs from the previous message returned, so that callers only
+// report changes.
+func (p *Progress) Observe(entry Entry) (string, bool) {
+	message := p.message(strings.TrimSpace(entry.Message))
+	if message == "" || message == p.last {
+		return message, false
+	}
+	p.last = message
+	return message, true
+}
+
+func (p *Progress) message(line string) string {
+	if m := pullingRegexp.FindStringSubmatch(line); m != nil {
+		return fmt.Sprintf("Pulling release image %s", m[1])
+	}
+	if m := renderingRegexp.FindStringSubmatch(line); m != nil {
+		return fmt.Sprintf("Rendering %s manifests", m[1])
+	}
+	if m := etcdRegexp.FindStringSubmatch(line); m != nil {
+		p.etcd[m[1]] = m[2] == "healthy"
+		return fmt.Sprintf("etcd member %d/%d up", count(p.etcd), len(p.etcd))
+	}
+	if m := podStatusRegexp.FindStringSubmatch(line); m != nil {
+		p.pods[m[1]] = m[2] == "Ready"
+		return fmt.Sprintf("Control plane pod %d/%d ready", count(p.pods), len(p.pods))
+	}
+	switch {
+	case strings.HasPrefix(line, "Starting cluster-bootstrap"):
+		return "Starting the temporary control plane"
+	case strings.HasPrefix(line, "All self-hosted control plane components successfully started"):
+		return "Temporary control plane is up"
+	case strings.HasPrefix(line, "Waiting for CEO to finish"):
+		return "Waiting for etcd to scale onto the control plane"
+	case line == "bootkube.service complete":
+		return "Bootstrap services complete"
+	}
+	return ""
+}
+
+// count returns the number of true values in m.
+func count(m map[string]bool) int {
+	n := 0
+	for _, v := range m {
+		if v {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,49 @@
+package gatewayd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress(t *testing.T) {
+	lines := []string{
+		"Pulling quay.io/openshift-release-dev/ocp-release@sha256:abcd...",
+		"Rendering Cluster Version Operator Manifests...",
+		"Rendering CEO Manifests...",
+		"Rendering CEO Manifests...",
+		"some unrelated podman output",
+		"https://10.0.0.4:2379 is healthy: successfully committed proposal: took = 9.2ms",
+		"https://10.0.0.5:2379 is unhealthy: failed to commit proposal: context deadline exceeded",
+		"https://10.0.0.6:2379 is healthy: successfully committed proposal: took = 12ms",
+		"etcdctl failed. Retrying in 5 seconds...",
+		"https://10.0.0.5:2379 is healthy: successfully committed proposal: took = 8ms",
+		"Starting cluster-bootstrap...",
+		"Pod Status:openshift-kube-apiserver/kube-apiserver        Pending",
+		"Pod Status:openshift-kube-scheduler/openshift-kube-scheduler        Ready",
+		"Pod Status:openshift-kube-apiserver/kube-apiserver        Ready",
+		"bootkube.service complete",
+	}
+
+	progress := NewProgress()
+	var messages []string
+	for _, line := range lines {
+		if message, changed := progress.Observe(Entry{Message: line}); changed {
+			messages = append(messages, message)
+		}
+	}
+	assert.Equal(t, []string{
+		"Pulling release image quay.io/openshift-release-dev/ocp-release@sha256:abcd",
+		"Rendering Cluster Version Operator manifests",
+		"Rendering CEO manifests",
+		"etcd member 1/1 up",
+		"etcd member 1/2 up",
+		"etcd member 2/3 up",
+		"etcd member 3/3 up",
+		"Starting the temporary control plane",
+		"Control plane pod 0/1 ready",
+		"Control plane pod 1/2 ready",
+		"Control plane pod 2/2 ready",
+		"Bootstrap services complete",
+	}, messages)
+}
@@ -0,0 +1,140 @@
+package ssh
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// HostKey verifies the host keys of the servers an SSH client connects to.
+type HostKey struct {
+	// Callback verifies the host key presented by a server.
+	Callback ssh.HostKeyCallback
+
+	// Algorithms are the host key algorithms to negotiate, in order of
+	// preference. The SSH package's defaults are used if it is empty.
+	Algorithms []string
+}
+
+// PinnedHostKey returns a HostKey that only accepts the public key, given in
+// authorized_keys format.
+func PinnedHostKey(authorizedKey []byte) (HostKey, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey(authorizedKey)
+	if err != nil {
+		return HostKey{}, errors.Wrap(err, "failed to parse host key")
+	}
+	return HostKey{
+		Callback: func(hostname string, remote net.Addr, presented ssh.PublicKey) error {
+			if !bytes.Equal(presented.Marshal(), key.Marshal()) {
+				return errors.Errorf("host key mismatch for %s: got %s %s, expected %s %s",
+					hostname, presented.Type(), ssh.FingerprintSHA256(presented), key.Type(), ssh.FingerprintSHA256(key))
+			}
+			return nil
+		},
+		// The server has host keys of other types as well, so only
+		// negotiate the type of the pinned key.
+		Algorithms: hostKeyAlgorithms(key),
+	}, nil
+}
+
+// hostKeyAlgorithms returns the host key algorithms which verify key. RSA keys
+// are negotiated with SHA-2 signatures, since servers are free to refuse the
+// SHA-1 signatures of ssh-rsa.
+func hostKeyAlgorithms(key ssh.PublicKey) []string {
+	if key.Type() == ssh.KeyAlgoRSA {
+		return []string{ssh.SigAlgoRSASHA2512, ssh.SigAlgoRSASHA2256}
+	}
+	return []string{key.Type()}
+}
+
+// TrustOnFirstUse returns a HostKey that verifies servers against the
+// known_hosts file at path. The key of a server that is not in the file is
+// trusted and added to it, while a server whose key does not match the file is
+// refused.
+func TrustOnFirstUse(path string) (HostKey, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return HostKey{}, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDONLY, 0600)
+	if err != nil {
+		return HostKey{}, err
+	}
+	f.Close()
+
+	var mu sync.Mutex
+	return HostKey{
+		Callback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			// Reload the file for every host so that keys added
+			// since are taken into account.
+			callback, err := knownhosts.New(path)
+			if err != nil {
+				return errors.Wrapf(err, "failed to load %s", path)
+			}
+			err = callback(hostname, remote, key)
+			keyErr, ok := err.(*knownhosts.KeyError)
+			if !ok {
+				return err
+			}
+			if len(keyErr.Want) > 0 {
+				return errors.Errorf("host key mismatch for %s: got %s %s, expected the key in %s:%d",
+					hostname, key.Type(), ssh.FingerprintSHA256(key), keyErr.Want[0].Filename, keyErr.Want[0].Line)
+			}
+
+			f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if _, err := fmt.Fprintln(f, knownhosts.Line([]string{hostname}, key)); err != nil {
+				return errors.Wrapf(err, "failed to add %s to %s", hostname, path)
+			}
+			logrus.Infof("Permanently added %s (%s) to %s", hostname, ssh.FingerprintSHA256(key), path)
+			return nil
+		},
+	}, nil
+}
+
+// VerifyHostKey connects to the SSH server at address through client and
+// verifies its host key with hostKey, like ssh-keyscan, without
+// authenticating. It returns the verified key.
+func VerifyHostKey(client *ssh.Client, address string, hostKey HostKey) (ssh.PublicKey, error) {
+	conn, err := client.Dial("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var (
+		verified  ssh.PublicKey
+		verifyErr error
+	)
+	_, _, _, err = ssh.NewClientConn(conn, address, &ssh.ClientConfig{
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			verifyErr = hostKey.Callback(hostname, remote, key)
+			if verifyErr == nil {
+				verified = key
+			}
+			return verifyErr
+		},
+		HostKeyAlgorithms: hostKey.Algorithms,
+	})
+	if verifyErr != nil {
+		return nil, verifyErr
+	}
+	if verified == nil {
+		return nil, err
+	}
+	// Authentication is expected to fail, since no credentials were offered.
+	return verified, nil
+}
@@ -0,0 +1,84 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func newPublicKey(t *testing.T) ssh.PublicKey {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+	return key
+}
+
+func TestPinnedHostKey(t *testing.T) {
+	pinned, other := newPublicKey(t), newPublicKey(t)
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}
+
+	hostKey, err := PinnedHostKey(ssh.MarshalAuthorizedKey(pinned))
+	require.NoError(t, err)
+	assert.Equal(t, []string{ssh.KeyAlgoED25519}, hostKey.Algorithms)
+	assert.NoError(t, hostKey.Callback("10.0.0.1:22", remote, pinned))
+	assert.Error(t, hostKey.Callback("10.0.0.1:22", remote, other))
+
+	_, err = PinnedHostKey([]byte("not a key"))
+	assert.Error(t, err)
+}
+
+func TestPinnedHostKeyRSA(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pinned, err := ssh.NewPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+
+	hostKey, err := PinnedHostKey(ssh.MarshalAuthorizedKey(pinned))
+	require.NoError(t, err)
+	assert.Equal(t, []string{ssh.SigAlgoRSASHA2512, ssh.SigAlgoRSASHA2256}, hostKey.Algorithms)
+	assert.NoError(t, hostKey.Callback("10.0.0.1:22", &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}, pinned))
+}
+
+func TestTrustOnFirstUse(t *testing.T) {
+	dir, err := ioutil.TempDir("", "known-hosts")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "known_hosts")
+
+	first, second := newPublicKey(t), newPublicKey(t)
+	masterA := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}
+	masterB := &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 22}
+
+	hostKey, err := TrustOnFirstUse(path)
+	require.NoError(t, err)
+	assert.Empty(t, hostKey.Algorithms)
+
+	// The first key is trusted and recorded.
+	assert.NoError(t, hostKey.Callback("10.0.0.1:22", masterA, first))
+	assert.NoError(t, hostKey.Callback("10.0.0.1:22", masterA, first))
+	assert.Error(t, hostKey.Callback("10.0.0.1:22", masterA, second))
+
+	// Other hosts are trusted independently.
+	assert.NoError(t, hostKey.Callback("10.0.0.2:22", masterB, second))
+
+	// The keys persist in the file.
+	reloaded, err := TrustOnFirstUse(path)
+	require.NoError(t, err)
+	assert.NoError(t, reloaded.Callback("10.0.0.1:22", masterA, first))
+	assert.Error(t, reloaded.Callback("10.0.0.2:22", masterB, first))
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "10.0.0.1 ssh-ed25519 ")
+	assert.Contains(t, string(data), "10.0.0.2 ssh-ed25519 ")
+}
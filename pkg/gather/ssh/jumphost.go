@@ -0,0 +1,81 @@
+package ssh
+
+import (
+	"net"
+	"os/user"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+// JumpHost is an SSH server through which connections to other servers are
+// tunneled, like OpenSSH's ProxyJump.
+type JumpHost struct {
+	// User is the user to authenticate to the jump host as.
+	User string
+
+	// Address is the host:port of the jump host.
+	Address string
+
+	// HostKey verifies the jump host's host key.
+	HostKey HostKey
+}
+
+// ParseJumpHosts parses a comma-separated list of jump hosts in the format
+// of OpenSSH's ProxyJump, [user@]host[:port], in the order in which they are
+// to be connected through. The user defaults to the current user and the port
+// to 22.
+func ParseJumpHosts(spec string) ([]JumpHost, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	var jumpHosts []JumpHost
+	for _, hop := range strings.Split(spec, ",") {
+		jumpHost := JumpHost{}
+		hostport := hop
+		if i := strings.LastIndex(hop, "@"); i >= 0 {
+			jumpHost.User, hostport = hop[:i], hop[i+1:]
+			if jumpHost.User == "" {
+				return nil, errors.Errorf("invalid jump host %q: empty user", hop)
+			}
+		} else {
+			current, err := user.Current()
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid jump host %q: failed to get the current user", hop)
+			}
+			jumpHost.User = current.Username
+		}
+
+		host, port, err := net.SplitHostPort(hostport)
+		if err != nil {
+			// No port, but IPv6 addresses may still be bracketed.
+			host, port = strings.TrimSuffix(strings.TrimPrefix(hostport, "["), "]"), "22"
+		}
+		if host == "" || strings.ContainsAny(host, "[]") {
+			return nil, errors.Errorf("invalid jump host %q: invalid host", hop)
+		}
+		jumpHost.Address = net.JoinHostPort(host, port)
+		jumpHosts = append(jumpHosts, jumpHost)
+	}
+	return jumpHosts, nil
+}
+
+// dial connects to address, tunneling through via if it is not nil.
+func dial(via *ssh.Client, address string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	if via == nil {
+		return ssh.Dial("tcp", address, config)
+	}
+
+	conn, err := via.Dial("tcp", address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to %s through %s", address, via.RemoteAddr())
+	}
+	c, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return ssh.NewClient(c, chans, reqs), nil
+}
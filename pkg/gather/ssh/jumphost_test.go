@@ -0,0 +1,65 @@
+package ssh
+
+import (
+	"os/user"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJumpHosts(t *testing.T) {
+	current, err := user.Current()
+	require.NoError(t, err)
+
+	cases := []struct {
+		spec     string
+		expected []JumpHost
+		err      string
+	}{{
+		spec: "",
+	}, {
+		spec:     "ec2-user@bastion.example.com",
+		expected: []JumpHost{{User: "ec2-user", Address: "bastion.example.com:22"}},
+	}, {
+		spec:     "ec2-user@bastion.example.com:2222",
+		expected: []JumpHost{{User: "ec2-user", Address: "bastion.example.com:2222"}},
+	}, {
+		spec:     "bastion.example.com",
+		expected: []JumpHost{{User: current.Username, Address: "bastion.example.com:22"}},
+	}, {
+		spec:     "admin@[fd00::1]:2222",
+		expected: []JumpHost{{User: "admin", Address: "[fd00::1]:2222"}},
+	}, {
+		spec:     "admin@fd00::1",
+		expected: []JumpHost{{User: "admin", Address: "[fd00::1]:22"}},
+	}, {
+		spec: "admin@outer.example.com,core@10.0.0.5:2222",
+		expected: []JumpHost{
+			{User: "admin", Address: "outer.example.com:22"},
+			{User: "core", Address: "10.0.0.5:2222"},
+		},
+	}, {
+		spec: "@bastion.example.com",
+		err:  `invalid jump host "@bastion.example.com": empty user`,
+	}, {
+		spec: "admin@",
+		err:  `invalid jump host "admin@": invalid host`,
+	}, {
+		spec: "admin@outer.example.com,",
+		err:  `invalid jump host "": invalid host`,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.spec, func(t *testing.T) {
+			jumpHosts, err := ParseJumpHosts(tc.spec)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, jumpHosts)
+			}
+		})
+	}
+}
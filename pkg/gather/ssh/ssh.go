@@ -18,32 +18,68 @@ import (
 )
 
 // NewClient creates a new SSH client which can be used to SSH to address using user and the keys.
+// The server's host key is verified with hostKey. If jumpHosts are given, the connection is
+// tunneled through each of them in order, authenticating to them with the same keys.
 //
 // if keys list is empty, it tries to load the keys from the user's environment.
-func NewClient(user, address string, keys []string) (*ssh.Client, error) {
+func NewClient(user, address string, keys []string, hostKey HostKey, jumpHosts ...JumpHost) (*ssh.Client, error) {
 	ag, agentType, err := getAgent(keys)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to initialize the SSH agent")
 	}
 
-	client, err := ssh.Dial("tcp", address, &ssh.ClientConfig{
-		User: user,
-		Auth: []ssh.AuthMethod{
-			// Use a callback rather than PublicKeys
-			// so we only consult the agent once the remote server
-			// wants it.
-			ssh.PublicKeysCallback(ag.Signers),
-		},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-	})
-	if err != nil {
+	clientConfig := func(user string, hostKey HostKey) *ssh.ClientConfig {
+		return &ssh.ClientConfig{
+			User: user,
+			Auth: []ssh.AuthMethod{
+				// Use a callback rather than PublicKeys
+				// so we only consult the agent once the remote server
+				// wants it.
+				ssh.PublicKeysCallback(ag.Signers),
+			},
+			HostKeyCallback:   hostKey.Callback,
+			HostKeyAlgorithms: hostKey.Algorithms,
+		}
+	}
+	authError := func(err error) error {
 		if strings.Contains(err.Error(), "ssh: handshake failed: ssh: unable to authenticate") {
 			if agentType == "agent" {
-				return nil, errors.Wrap(err, "failed to use pre-existing agent, make sure the appropriate keys exist in the agent for authentication")
+				return errors.Wrap(err, "failed to use pre-existing agent, make sure the appropriate keys exist in the agent for authentication")
 			}
-			return nil, errors.Wrap(err, "failed to use the provided keys for authentication")
+			return errors.Wrap(err, "failed to use the provided keys for authentication")
+		}
+		return err
+	}
+
+	var jumps []*ssh.Client
+	closeJumps := func() {
+		for i := len(jumps) - 1; i >= 0; i-- {
+			jumps[i].Close()
 		}
-		return nil, err
+	}
+	var via *ssh.Client
+	for _, jumpHost := range jumpHosts {
+		logrus.Debugf("Connecting to jump host %s@%s", jumpHost.User, jumpHost.Address)
+		jump, err := dial(via, jumpHost.Address, clientConfig(jumpHost.User, jumpHost.HostKey))
+		if err != nil {
+			closeJumps()
+			return nil, errors.Wrapf(authError(err), "failed to connect to jump host %s", jumpHost.Address)
+		}
+		jumps = append(jumps, jump)
+		via = jump
+	}
+
+	client, err := dial(via, address, clientConfig(user, hostKey))
+	if err != nil {
+		closeJumps()
+		return nil, authError(err)
+	}
+	if len(jumps) > 0 {
+		// Tear the tunnel down along with the client.
+		go func() {
+			client.Wait()
+			closeJumps()
+		}()
 	}
 	if err := agent.ForwardToAgent(client, ag); err != nil {
 		return nil, errors.Wrap(err, "failed to forward agent")
@@ -96,6 +132,26 @@ func PullFileTo(client *ssh.Client, remotePath, localPath string) error {
 	return nil
 }
 
+// WriteFileTo uploads data to remotePath on the remote server using SSH connection.
+func WriteFileTo(client *ssh.Client, data []byte, remotePath string) error {
+	sc, err := sftp.NewClient(client)
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize the sftp client")
+	}
+	defer sc.Close()
+
+	rFile, err := sc.Create(remotePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to create remote file")
+	}
+	defer rFile.Close()
+
+	if _, err := rFile.Write(data); err != nil {
+		return errors.Wrap(err, "failed to write remote file")
+	}
+	return nil
+}
+
 // defaultPrivateSSHKeys returns a list of all the PRIVATE SSH keys from user's home directory.
 // It does not return any intermediate errors if at least one private key was loaded.
 func defaultPrivateSSHKeys() (map[string]interface{}, error) {
@@ -16,6 +16,10 @@ type PushClient struct {
 	URL     string
 	Client  *http.Client
 	JobName string
+
+	// Grouping holds additional grouping labels. Pushes with the same job
+	// name and grouping replace each other's metrics.
+	Grouping map[string]string
 }
 
 // Push uses all the configuration settings from the client and pushes to the prometheus
@@ -24,6 +28,12 @@ type PushClient struct {
 func (p *PushClient) Push(collectors ...prometheus.Collector) error {
 	pushClient := push.New(p.URL, p.JobName).Client(p.Client).Format(expfmt.FmtText)
 
+	// The order of the grouping labels in the URL does not matter to the
+	// Pushgateway, and is not stable.
+	for name, value := range p.Grouping {
+		pushClient.Grouping(name, value)
+	}
+
 	for _, value := range collectors {
 		pushClient.Collector(value)
 	}
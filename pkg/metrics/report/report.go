@@ -0,0 +1,178 @@
+// Package report turns the installer's timed stages into Prometheus
+// metrics and pushes them to a Pushgateway.
+package report
+
+import (
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/openshift/installer/pkg/diagnostics"
+	"github.com/openshift/installer/pkg/metrics/builder"
+	"github.com/openshift/installer/pkg/metrics/pushclient"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	// PushgatewayEnv is the environment variable holding the URL of the
+	// Pushgateway to push metrics to.
+	PushgatewayEnv = "OPENSHIFT_INSTALL_METRICS_PUSHGATEWAY"
+
+	// JobName is the Pushgateway job the metrics are pushed under.
+	JobName = "openshift_install"
+
+	// ResultSuccess is the result label of commands which succeeded.
+	ResultSuccess = "success"
+
+	// ResultFailure is the result label of commands which failed.
+	ResultFailure = "failure"
+
+	// metricName is the name of the stage duration histogram.
+	metricName = "openshift_install_stage_duration_seconds"
+)
+
+// labels are the labels of every metric. The install config options are
+// network_type, publish, fips and proxy. The command and instance labels
+// are added by the Pushgateway from the push's grouping.
+var labels = []string{
+	"stage",
+	"platform",
+	"result",
+	"failure_reason",
+	"network_type",
+	"publish",
+	"fips",
+	"proxy",
+}
+
+// buckets spans durations from 30 seconds to a little over four hours.
+var buckets = prometheus.ExponentialBuckets(30, 2, 10)
+
+// Report holds the metrics of one installer command.
+type Report struct {
+	// Command is the installer command, e.g. "create_cluster". Together
+	// with InfraID, it groups the pushed metrics.
+	Command string
+
+	// Platform is the name of the cluster's platform.
+	Platform string
+
+	// InfraID is the cluster's infrastructure ID. It groups the pushed
+	// metrics, so that installs do not replace each other's metrics. If it
+	// is empty, the host name is used instead.
+	InfraID string
+
+	// Options are the install config option labels, as returned by
+	// InstallConfigOptions.
+	Options map[string]string
+
+	// Stages are the durations of the command's timed stages.
+	Stages map[string]time.Duration
+
+	// Err is the error the command failed with, or nil if it succeeded.
+	Err error
+}
+
+// InstallConfigOptions returns the install config option labels for config.
+func InstallConfigOptions(config *types.InstallConfig) map[string]string {
+	options := map[string]string{
+		"publish": string(config.Publish),
+		"fips":    strconv.FormatBool(config.FIPS),
+		"proxy":   strconv.FormatBool(config.Proxy != nil),
+	}
+	if config.Networking != nil {
+		options["network_type"] = config.Networking.NetworkType
+	}
+	return options
+}
+
+// FailureReason returns the failure reason label for err: the reason of the
+// diagnosed error it wraps, "Unknown" for undiagnosed errors, or an empty
+// string if err is nil.
+func FailureReason(err error) string {
+	if err == nil {
+		return ""
+	}
+	var diagErr *diagnostics.Err
+	if errors.As(err, &diagErr) {
+		return diagErr.Reason
+	}
+	return "Unknown"
+}
+
+// Collectors returns a histogram for each of the report's stages.
+func (r *Report) Collectors() ([]prometheus.Collector, error) {
+	result := ResultSuccess
+	if r.Err != nil {
+		result = ResultFailure
+	}
+
+	stages := make([]string, 0, len(r.Stages))
+	for stage := range r.Stages {
+		stages = append(stages, stage)
+	}
+	sort.Strings(stages)
+
+	collectors := make([]prometheus.Collector, 0, len(stages))
+	for _, stage := range stages {
+		labelValues := map[string]string{
+			"stage":          stage,
+			"platform":       r.Platform,
+			"result":         result,
+			"failure_reason": FailureReason(r.Err),
+		}
+		for key, value := range r.Options {
+			labelValues[key] = value
+		}
+
+		metric, err := builder.NewMetricBuilder(builder.MetricOpts{
+			Labels:     append([]string{}, labels...),
+			Desc:       "Duration of the stages of installer commands.",
+			Name:       metricName,
+			Buckets:    buckets,
+			MetricType: builder.Histogram,
+		}, r.Stages[stage].Seconds(), nil)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range labelValues {
+			if err := metric.AddLabelValue(key, value); err != nil {
+				return nil, err
+			}
+		}
+		collector, err := metric.PromCollector()
+		if err != nil {
+			return nil, err
+		}
+		collectors = append(collectors, collector)
+	}
+	return collectors, nil
+}
+
+// Push pushes the report's metrics to the Pushgateway at url.
+func (r *Report) Push(url string) error {
+	collectors, err := r.Collectors()
+	if err != nil {
+		return errors.Wrap(err, "failed to build metrics")
+	}
+	if len(collectors) == 0 {
+		return nil
+	}
+
+	instance := r.InfraID
+	if instance == "" {
+		instance, _ = os.Hostname()
+	}
+	client := &pushclient.PushClient{
+		URL:      url,
+		Client:   &http.Client{Timeout: 30 * time.Second},
+		JobName:  JobName,
+		Grouping: map[string]string{"instance": instance, "command": r.Command},
+	}
+	return client.Push(collectors...)
+}
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/diagnostics"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestFailureReason(t *testing.T) {
+	assert.Equal(t, "", FailureReason(nil))
+	assert.Equal(t, "Unknown", FailureReason(errors.New("boom")))
+	assert.Equal(t, "AWSQuotaLimitExceeded", FailureReason(errors.Wrap(&diagnostics.Err{Reason: "AWSQuotaLimitExceeded"}, "failed to apply Terraform")))
+}
+
+func TestInstallConfigOptions(t *testing.T) {
+	options := InstallConfigOptions(&types.InstallConfig{
+		Publish:    types.InternalPublishingStrategy,
+		FIPS:       true,
+		Networking: &types.Networking{NetworkType: "OVNKubernetes"},
+	})
+	assert.Equal(t, map[string]string{
+		"network_type": "OVNKubernetes",
+		"publish":      "Internal",
+		"fips":         "true",
+		"proxy":        "false",
+	}, options)
+}
+
+func TestPush(t *testing.T) {
+	var method, path, body string
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		method = req.Method
+		path = req.URL.Path
+		raw, err := ioutil.ReadAll(req.Body)
+		assert.NoError(t, err)
+		body = string(raw)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer gateway.Close()
+
+	report := &Report{
+		Command:  "create_cluster",
+		Platform: "aws",
+		InfraID:  "mycluster-x7k2p",
+		Options: map[string]string{
+			"network_type": "OpenShiftSDN",
+			"publish":      "External",
+			"fips":         "false",
+			"proxy":        "false",
+		},
+		Stages: map[string]time.Duration{
+			"Total":              45 * time.Minute,
+			"Bootstrap Complete": 12 * time.Minute,
+		},
+		Err: errors.Wrap(&diagnostics.Err{Reason: "AWSQuotaLimitExceeded"}, "failed to apply Terraform"),
+	}
+	assert.NoError(t, report.Push(gateway.URL))
+
+	assert.Equal(t, http.MethodPut, method)
+	// The grouping labels are in no particular order.
+	assert.Contains(t, []string{
+		"/metrics/job/openshift_install/command/create_cluster/instance/mycluster-x7k2p",
+		"/metrics/job/openshift_install/instance/mycluster-x7k2p/command/create_cluster",
+	}, path)
+	assert.Contains(t, body, `openshift_install_stage_duration_seconds_sum{failure_reason="AWSQuotaLimitExceeded",fips="false",network_type="OpenShiftSDN",platform="aws",proxy="false",publish="External",result="failure",stage="Total"} 2700`)
+	assert.Contains(t, body, `openshift_install_stage_duration_seconds_count{failure_reason="AWSQuotaLimitExceeded",fips="false",network_type="OpenShiftSDN",platform="aws",proxy="false",publish="External",result="failure",stage="Bootstrap Complete"} 1`)
+}
+
+func TestPushUnknownOption(t *testing.T) {
+	report := &Report{
+		Command: "destroy_cluster",
+		Options: map[string]string{"unknown": "value"},
+		Stages:  map[string]time.Duration{"Total": time.Minute},
+	}
+	assert.Error(t, report.Push("http://127.0.0.1:0"))
+}
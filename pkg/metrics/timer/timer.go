@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/events"
 )
 
 // Timer is the struct that keeps track of each of the sections.
@@ -25,11 +27,15 @@ var timer = NewTimer()
 // StartTimer initiailzes the timer object with the current timestamp information.
 func StartTimer(key string) {
 	timer.StartTimer(key)
+	events.PhaseStarted(key)
 }
 
 // StopTimer records the duration for the current stage sent as the key parameter and stores the information.
 func StopTimer(key string) {
 	timer.StopTimer(key)
+	if duration, found := timer.stageTimes[key]; found {
+		events.PhaseStopped(key, duration)
+	}
 }
 
 // LogSummary prints the summary of all the times collected so far into the INFO section.
@@ -37,6 +43,11 @@ func LogSummary() {
 	timer.LogSummary(logrus.StandardLogger())
 }
 
+// StageTimes returns the duration of each stage collected so far.
+func StageTimes() map[string]time.Duration {
+	return timer.StageTimes()
+}
+
 // NewTimer returns a new timer that can be used to track sections and
 func NewTimer() Timer {
 	return Timer{
@@ -61,6 +72,21 @@ func (t *Timer) StopTimer(key string) time.Duration {
 	return time.Since(time.Now())
 }
 
+// StageTimes returns the duration of each started stage. Stages which have
+// not been stopped report the time elapsed since they were started, so that
+// a failed run still reports how long it ran.
+func (t *Timer) StageTimes() map[string]time.Duration {
+	times := make(map[string]time.Duration, len(t.startTimes))
+	for key, start := range t.startTimes {
+		if duration, found := t.stageTimes[key]; found {
+			times[key] = duration
+		} else {
+			times[key] = time.Since(start).Round(time.Second)
+		}
+	}
+	return times
+}
+
 // LogSummary prints the summary of all the times collected so far into the INFO section.
 // The format of printing will be the following:
 // If there are no stages except the total time stage, then it only prints the following
@@ -129,3 +129,18 @@ func TestNewTimer(t *testing.T) {
 		t.Fatalf("Expected empty list of startTimes property in the new timer created, got %d", len(timer.stageTimes))
 	}
 }
+
+func TestStageTimes(t *testing.T) {
+	timer := NewTimer()
+	timer.startTimes["stopped"] = time.Now().Add(-time.Minute)
+	timer.stageTimes["stopped"] = 30 * time.Second
+	timer.startTimes["running"] = time.Now().Add(-2 * time.Minute)
+
+	times := timer.StageTimes()
+	if times["stopped"] != 30*time.Second {
+		t.Fatalf("Expected the stopped stage to report its recorded duration, got %s", times["stopped"])
+	}
+	if times["running"] < 2*time.Minute || times["running"] > 2*time.Minute+5*time.Second {
+		t.Fatalf("Expected the running stage to report the time since it started, got %s", times["running"])
+	}
+}
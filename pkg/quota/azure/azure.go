@@ -0,0 +1,110 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-10-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+
+	azureconfig "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	"github.com/openshift/installer/pkg/quota"
+)
+
+const (
+	// ComputeService is the service name of the compute quotas, e.g.
+	// "compute/cores" for the total regional vCPUs and
+	// "compute/standardDSv3Family" for the vCPUs of a VM family.
+	ComputeService = "compute"
+
+	// NetworkService is the service name of the network quotas, e.g.
+	// "network/PublicIPAddresses".
+	NetworkService = "network"
+)
+
+// Load loads the quota information for a region. It provides information
+// about the usage and limit for each compute and network resource quota.
+func Load(ctx context.Context, session *azureconfig.Session, region string) ([]quota.Quota, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	computeClient := compute.NewUsageClientWithBaseURI(session.Environment.ResourceManagerEndpoint, session.Credentials.SubscriptionID)
+	computeClient.Authorizer = session.Authorizer
+	var computeUsages []compute.Usage
+	computePage, err := computeClient.List(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list compute usages")
+	}
+	for ; computePage.NotDone(); err = computePage.NextWithContext(ctx) {
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list compute usages")
+		}
+		computeUsages = append(computeUsages, computePage.Values()...)
+	}
+
+	networkClient := network.NewUsagesClientWithBaseURI(session.Environment.ResourceManagerEndpoint, session.Credentials.SubscriptionID)
+	networkClient.Authorizer = session.Authorizer
+	var networkUsages []network.Usage
+	networkPage, err := networkClient.List(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list network usages")
+	}
+	for ; networkPage.NotDone(); err = networkPage.NextWithContext(ctx) {
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list network usages")
+		}
+		networkUsages = append(networkUsages, networkPage.Values()...)
+	}
+
+	return newQuotas(region, computeUsages, networkUsages), nil
+}
+
+// newQuotas converts the compute and network usages of a region to quotas.
+// Usages without a name or a limit are skipped.
+func newQuotas(region string, computeUsages []compute.Usage, networkUsages []network.Usage) []quota.Quota {
+	var quotas []quota.Quota
+	for _, u := range computeUsages {
+		if u.Name == nil || u.Name.Value == nil || u.Limit == nil {
+			continue
+		}
+		quotas = append(quotas, quota.Quota{
+			Service: ComputeService,
+			Name:    fmt.Sprintf("%s/%s", ComputeService, *u.Name.Value),
+			Region:  region,
+			InUse:   int64(to.Int32(u.CurrentValue)),
+			Limit:   *u.Limit,
+		})
+	}
+	for _, u := range networkUsages {
+		if u.Name == nil || u.Name.Value == nil || u.Limit == nil {
+			continue
+		}
+		quotas = append(quotas, quota.Quota{
+			Service: NetworkService,
+			Name:    fmt.Sprintf("%s/%s", NetworkService, *u.Name.Value),
+			Region:  region,
+			InUse:   to.Int64(u.CurrentValue),
+			Limit:   *u.Limit,
+		})
+	}
+	return quotas
+}
+
+// IsUnauthorized checks if the error is un authorized.
+func IsUnauthorized(err error) bool {
+	if err == nil {
+		return false
+	}
+	var dErr autorest.DetailedError
+	if errors.As(err, &dErr) {
+		if statusCode, ok := dErr.StatusCode.(int); ok {
+			return statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-10-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	azureenv "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	azureconfig "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	"github.com/openshift/installer/pkg/quota"
+)
+
+func Test_newQuotas(t *testing.T) {
+	computeUsages := []compute.Usage{{
+		Name:         &compute.UsageName{Value: to.StringPtr("cores"), LocalizedValue: to.StringPtr("Total Regional vCPUs")},
+		CurrentValue: to.Int32Ptr(20),
+		Limit:        to.Int64Ptr(100),
+	}, {
+		Name:         &compute.UsageName{Value: to.StringPtr("standardDSv3Family")},
+		CurrentValue: to.Int32Ptr(16),
+		Limit:        to.Int64Ptr(50),
+	}, {
+		// no limit
+		Name: &compute.UsageName{Value: to.StringPtr("availabilitySets")},
+	}}
+	networkUsages := []network.Usage{{
+		Name:         &network.UsageName{Value: to.StringPtr("PublicIPAddresses")},
+		CurrentValue: to.Int64Ptr(3),
+		Limit:        to.Int64Ptr(10),
+	}, {
+		// no current value
+		Name:  &network.UsageName{Value: to.StringPtr("LoadBalancers")},
+		Limit: to.Int64Ptr(1000),
+	}}
+
+	exp := []quota.Quota{
+		{Service: "compute", Name: "compute/cores", Region: "centralus", InUse: 20, Limit: 100},
+		{Service: "compute", Name: "compute/standardDSv3Family", Region: "centralus", InUse: 16, Limit: 50},
+		{Service: "network", Name: "network/PublicIPAddresses", Region: "centralus", InUse: 3, Limit: 10},
+		{Service: "network", Name: "network/LoadBalancers", Region: "centralus", InUse: 0, Limit: 1000},
+	}
+	assert.Equal(t, exp, newQuotas("centralus", computeUsages, networkUsages))
+}
+
+func TestIsUnauthorized(t *testing.T) {
+	cases := []struct {
+		err error
+		exp bool
+	}{{
+		err: nil,
+	}, {
+		err: errors.New("boom"),
+	}, {
+		err: errors.Wrap(autorest.DetailedError{StatusCode: http.StatusForbidden}, "failed to list compute usages"),
+		exp: true,
+	}, {
+		err: autorest.DetailedError{StatusCode: http.StatusUnauthorized},
+		exp: true,
+	}, {
+		err: autorest.DetailedError{StatusCode: http.StatusNotFound},
+	}}
+	for _, tc := range cases {
+		assert.Equal(t, tc.exp, IsUnauthorized(tc.err), "%v", tc.err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	const (
+		computeUsagesPath = "/subscriptions/sub/providers/Microsoft.Compute/locations/centralus/usages"
+		networkUsagesPath = "/subscriptions/sub/providers/Microsoft.Network/locations/centralus/usages"
+	)
+	usages := map[string]string{
+		computeUsagesPath: `{"value": [{"name": {"value": "cores"}, "currentValue": 20, "limit": 100}], "nextLink": "{{server}}/compute-page-2"}`,
+		"/compute-page-2": `{"value": [{"name": {"value": "standardDSv3Family"}, "currentValue": 16, "limit": 50}]}`,
+		networkUsagesPath: `{"value": [{"name": {"value": "PublicIPAddresses"}, "currentValue": 3, "limit": 10}]}`,
+	}
+
+	cases := []struct {
+		name     string
+		failures map[string]int
+		exp      []quota.Quota
+		err      string
+		unauth   bool
+	}{{
+		name: "all pages",
+		exp: []quota.Quota{
+			{Service: "compute", Name: "compute/cores", Region: "centralus", InUse: 20, Limit: 100},
+			{Service: "compute", Name: "compute/standardDSv3Family", Region: "centralus", InUse: 16, Limit: 50},
+			{Service: "network", Name: "network/PublicIPAddresses", Region: "centralus", InUse: 3, Limit: 10},
+		},
+	}, {
+		name:     "forbidden first compute page",
+		failures: map[string]int{computeUsagesPath: http.StatusForbidden},
+		err:      `^failed to list compute usages: compute.UsageClient#List: .*StatusCode=403`,
+		unauth:   true,
+	}, {
+		name:     "failing second compute page",
+		failures: map[string]int{"/compute-page-2": http.StatusNotFound},
+		err:      `^failed to list compute usages: compute.UsageClient#listNextResults: .*StatusCode=404`,
+	}, {
+		name:     "forbidden first network page",
+		failures: map[string]int{networkUsagesPath: http.StatusForbidden},
+		err:      `^failed to list network usages: network.UsagesClient#List: .*StatusCode=403`,
+		unauth:   true,
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if status, ok := tc.failures[r.URL.Path]; ok {
+					w.WriteHeader(status)
+					w.Write([]byte(`{"error": {"code": "Failed"}}`))
+					return
+				}
+				body, ok := usages[r.URL.Path]
+				if !ok {
+					t.Errorf("unexpected request to %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Write([]byte(strings.Replace(body, "{{server}}", server.URL, 1)))
+			}))
+			defer server.Close()
+
+			session := &azureconfig.Session{
+				Authorizer:  autorest.NullAuthorizer{},
+				Credentials: azureconfig.Credentials{SubscriptionID: "sub"},
+				Environment: azureenv.Environment{ResourceManagerEndpoint: server.URL},
+			}
+			quotas, err := Load(context.Background(), session, "centralus")
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.exp, quotas)
+				return
+			}
+			assert.Regexp(t, tc.err, err)
+			assert.Equal(t, tc.unauth, IsUnauthorized(err))
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package ovirt
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/quota"
+)
+
+const (
+	// Service is the service name of the oVirt quotas.
+	Service = "ovirt"
+
+	// CPU is the name of the quota for the vCPUs of a cluster.
+	CPU = Service + "/cpu"
+
+	// Memory is the name of the quota for the memory of a cluster, in MiB.
+	Memory = Service + "/memory"
+
+	// Storage is the name of the quota for the capacity of a storage domain,
+	// in GiB.
+	Storage = Service + "/storage"
+
+	mib = 1024 * 1024
+	gib = 1024 * mib
+)
+
+// Load loads the capacity of a cluster and a storage domain. The CPU and
+// memory quotas are reported for the cluster and the storage quota for the
+// storage domain, using their IDs as the quotas' regions. Only hosts which
+// are up count towards the cluster's capacity. The CPU in use is the number of
+// vCPUs of the cluster's VMs which are not down, and the memory in use is
+// whatever the hosts can no longer schedule. The memory limit includes the
+// cluster's memory overcommit, but the CPU limit is the hosts' physical
+// topology even though oVirt lets VMs overcommit it.
+func Load(con *ovirtsdk.Connection, clusterID string, storageDomainID string) ([]quota.Quota, error) {
+	clusterResponse, err := con.SystemService().ClustersService().ClusterService(clusterID).Get().Send()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get cluster %s", clusterID)
+	}
+	cluster, ok := clusterResponse.Cluster()
+	if !ok {
+		return nil, errors.Errorf("failed to find cluster with id %s", clusterID)
+	}
+	clusterName := cluster.MustName()
+	search := fmt.Sprintf("cluster=%s", clusterName)
+
+	hostsResponse, err := con.SystemService().HostsService().List().Search(search).Send()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the hosts of cluster %s", clusterName)
+	}
+	var hosts []*ovirtsdk.Host
+	if slice, ok := hostsResponse.Hosts(); ok {
+		hosts = slice.Slice()
+	}
+
+	vmsResponse, err := con.SystemService().VmsService().List().Search(search).Send()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the VMs of cluster %s", clusterName)
+	}
+	var vms []*ovirtsdk.Vm
+	if slice, ok := vmsResponse.Vms(); ok {
+		vms = slice.Slice()
+	}
+
+	sdResponse, err := con.SystemService().StorageDomainsService().StorageDomainService(storageDomainID).Get().Send()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get storage domain %s", storageDomainID)
+	}
+	sd, ok := sdResponse.StorageDomain()
+	if !ok {
+		return nil, errors.Errorf("failed to find storage domain with id %s", storageDomainID)
+	}
+
+	return append(clusterQuotas(clusterID, hosts, vms), storageDomainQuota(storageDomainID, sd)), nil
+}
+
+// clusterQuotas returns the CPU and memory quotas of a cluster with hosts and
+// vms.
+func clusterQuotas(cluster string, hosts []*ovirtsdk.Host, vms []*ovirtsdk.Vm) []quota.Quota {
+	var cpus, cpusInUse, memory, memoryInUse int64
+	for _, host := range hosts {
+		if status, _ := host.Status(); status != ovirtsdk.HOSTSTATUS_UP {
+			continue
+		}
+		if c, ok := host.Cpu(); ok {
+			cpus += vCPUs(c)
+		}
+		// The scheduling memory already allows for the cluster's memory
+		// overcommit, so it can be more than the host's memory.
+		m, _ := host.Memory()
+		s, _ := host.MaxSchedulingMemory()
+		inUse := m - s
+		if inUse < 0 {
+			inUse = 0
+		}
+		memoryInUse += inUse / mib
+		memory += (inUse + s) / mib
+	}
+	for _, vm := range vms {
+		if status, _ := vm.Status(); status == ovirtsdk.VMSTATUS_DOWN {
+			continue
+		}
+		if c, ok := vm.Cpu(); ok {
+			cpusInUse += vCPUs(c)
+		}
+	}
+
+	return []quota.Quota{{
+		Service: Service,
+		Name:    CPU,
+		Region:  cluster,
+		InUse:   cpusInUse,
+		Limit:   cpus,
+	}, {
+		Service: Service,
+		Name:    Memory,
+		Region:  cluster,
+		InUse:   memoryInUse,
+		Limit:   memory,
+	}}
+}
+
+// vCPUs returns the number of vCPUs in the topology of c.
+func vCPUs(c *ovirtsdk.Cpu) int64 {
+	topology, ok := c.Topology()
+	if !ok {
+		return 0
+	}
+	sockets, _ := topology.Sockets()
+	cores, _ := topology.Cores()
+	threads, ok := topology.Threads()
+	if !ok || threads == 0 {
+		threads = 1
+	}
+	return sockets * cores * threads
+}
+
+// storageDomainQuota returns the storage quota of a storage domain.
+func storageDomainQuota(storageDomainID string, sd *ovirtsdk.StorageDomain) quota.Quota {
+	available, _ := sd.Available()
+	used, _ := sd.Used()
+	return quota.Quota{
+		Service: Service,
+		Name:    Storage,
+		Region:  storageDomainID,
+		InUse:   used / gib,
+		Limit:   (available + used) / gib,
+	}
+}
@@ -0,0 +1,105 @@
+package ovirt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/installer/pkg/quota"
+)
+
+// newMockEngine returns a mock oVirt engine serving a cluster with two hosts
+// that are up and one in maintenance, three VMs of which one is down, and a
+// storage domain.
+func newMockEngine(t *testing.T) *httptest.Server {
+	responses := map[string]string{
+		"/ovirt-engine/api/clusters/c1": `<cluster id="c1"><name>Default</name></cluster>`,
+		"/ovirt-engine/api/hosts": `<hosts>
+  <host id="h1"><status>up</status><memory>137438953472</memory><max_scheduling_memory>68719476736</max_scheduling_memory>
+    <cpu><topology><sockets>2</sockets><cores>8</cores><threads>2</threads></topology></cpu></host>
+  <host id="h2"><status>up</status><memory>137438953472</memory><max_scheduling_memory>103079215104</max_scheduling_memory>
+    <cpu><topology><sockets>2</sockets><cores>8</cores><threads>2</threads></topology></cpu></host>
+  <host id="h3"><status>maintenance</status><memory>137438953472</memory><max_scheduling_memory>137438953472</max_scheduling_memory>
+    <cpu><topology><sockets>2</sockets><cores>8</cores><threads>2</threads></topology></cpu></host>
+</hosts>`,
+		"/ovirt-engine/api/vms": `<vms>
+  <vm id="v1"><status>up</status><cpu><topology><sockets>4</sockets><cores>1</cores><threads>1</threads></topology></cpu></vm>
+  <vm id="v2"><status>up</status><cpu><topology><sockets>1</sockets><cores>4</cores></topology></cpu></vm>
+  <vm id="v3"><status>down</status><cpu><topology><sockets>16</sockets><cores>1</cores><threads>1</threads></topology></cpu></vm>
+</vms>`,
+		"/ovirt-engine/api/storagedomains/sd1": `<storage_domain id="sd1"><name>data</name><available>322122547200</available><used>751619276800</used></storage_domain>`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ovirt-engine/sso/oauth/token" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token": "token"}`))
+			return
+		}
+		if r.URL.Path == "/ovirt-engine/api/hosts" || r.URL.Path == "/ovirt-engine/api/vms" {
+			assert.Equal(t, "cluster=Default", r.URL.Query().Get("search"))
+		}
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<fault><reason>Operation Failed</reason><detail>Entity not found</detail></fault>`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write([]byte(body))
+	}))
+}
+
+func TestLoad(t *testing.T) {
+	engine := newMockEngine(t)
+	defer engine.Close()
+
+	con, err := ovirtsdk.NewConnectionBuilder().
+		URL(engine.URL + "/ovirt-engine/api").
+		Username("admin@internal").
+		Password("password").
+		Build()
+	require.NoError(t, err)
+	defer con.Close()
+
+	quotas, err := Load(con, "c1", "sd1")
+	require.NoError(t, err)
+	exp := []quota.Quota{
+		{Service: "ovirt", Name: "ovirt/cpu", Region: "c1", InUse: 8, Limit: 64},
+		{Service: "ovirt", Name: "ovirt/memory", Region: "c1", InUse: 98304, Limit: 262144},
+		{Service: "ovirt", Name: "ovirt/storage", Region: "sd1", InUse: 700, Limit: 1000},
+	}
+	assert.Equal(t, exp, quotas)
+
+	_, err = Load(con, "c1", "missing")
+	assert.Error(t, err)
+}
+
+func TestClusterQuotasOvercommit(t *testing.T) {
+	topology := func(sockets int64) *ovirtsdk.Cpu {
+		return ovirtsdk.NewCpuBuilder().
+			Topology(ovirtsdk.NewCpuTopologyBuilder().Sockets(sockets).Cores(1).Threads(1).MustBuild()).
+			MustBuild()
+	}
+	// With 150% memory overcommit, the host can schedule more memory than it
+	// has, and its VMs can have more vCPUs than it has threads.
+	hosts := []*ovirtsdk.Host{
+		ovirtsdk.NewHostBuilder().
+			Status(ovirtsdk.HOSTSTATUS_UP).
+			Memory(64 * gib).
+			MaxSchedulingMemory(80 * gib).
+			Cpu(topology(16)).
+			MustBuild(),
+	}
+	vms := []*ovirtsdk.Vm{
+		ovirtsdk.NewVmBuilder().Status(ovirtsdk.VMSTATUS_UP).Cpu(topology(24)).MustBuild(),
+	}
+	exp := []quota.Quota{
+		{Service: "ovirt", Name: "ovirt/cpu", Region: "c1", InUse: 24, Limit: 16},
+		{Service: "ovirt", Name: "ovirt/memory", Region: "c1", InUse: 0, Limit: 81920},
+	}
+	assert.Equal(t, exp, clusterQuotas("c1", hosts, vms))
+}
@@ -0,0 +1,125 @@
+package vsphere
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/openshift/installer/pkg/quota"
+)
+
+const (
+	// Service is the service name of the vSphere quotas.
+	Service = "vsphere"
+
+	// CPU is the name of the quota for the CPU cores of a cluster.
+	CPU = Service + "/cpu"
+
+	// Memory is the name of the quota for the memory of a cluster, in MiB.
+	Memory = Service + "/memory"
+
+	// Storage is the name of the quota for the capacity of a datastore, in
+	// GiB.
+	Storage = Service + "/storage"
+
+	mib = 1024 * 1024
+	gib = 1024 * mib
+)
+
+// Load loads the capacity of a cluster and a datastore in a datacenter. The
+// CPU and memory quotas are reported for the cluster and the storage quota for
+// the datastore, using their names as the quotas' regions. Only hosts which
+// are connected and not in maintenance mode count towards the cluster's
+// capacity, and the CPU in use is the number of cores needed to deliver the
+// hosts' current CPU usage.
+func Load(ctx context.Context, client *vim25.Client, datacenter string, cluster string, datastore string) ([]quota.Quota, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	finder := find.NewFinder(client, true)
+	dc, err := finder.Datacenter(ctx, datacenter)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find datacenter %s", datacenter)
+	}
+	finder.SetDatacenter(dc)
+
+	ccr, err := finder.ClusterComputeResource(ctx, cluster)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find cluster %s", cluster)
+	}
+	ds, err := finder.Datastore(ctx, datastore)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find datastore %s", datastore)
+	}
+
+	pc := property.DefaultCollector(client)
+	var ccrMo mo.ClusterComputeResource
+	if err := pc.RetrieveOne(ctx, ccr.Reference(), []string{"host"}, &ccrMo); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the hosts of cluster %s", cluster)
+	}
+	var hosts []mo.HostSystem
+	if len(ccrMo.Host) > 0 {
+		if err := pc.Retrieve(ctx, ccrMo.Host, []string{"summary"}, &hosts); err != nil {
+			return nil, errors.Wrapf(err, "failed to get the hosts of cluster %s", cluster)
+		}
+	}
+	var dsMo mo.Datastore
+	if err := pc.RetrieveOne(ctx, ds.Reference(), []string{"summary"}, &dsMo); err != nil {
+		return nil, errors.Wrapf(err, "failed to get datastore %s", datastore)
+	}
+
+	return append(clusterQuotas(cluster, hosts), datastoreQuota(datastore, dsMo.Summary)), nil
+}
+
+// clusterQuotas returns the CPU and memory quotas of a cluster with hosts.
+func clusterQuotas(cluster string, hosts []mo.HostSystem) []quota.Quota {
+	var cores, memory, memoryInUse int64
+	var coresInUse float64
+	for _, host := range hosts {
+		hw := host.Summary.Hardware
+		if hw == nil ||
+			host.Summary.Runtime == nil ||
+			host.Summary.Runtime.ConnectionState != types.HostSystemConnectionStateConnected ||
+			host.Summary.Runtime.InMaintenanceMode {
+			continue
+		}
+		cores += int64(hw.NumCpuCores)
+		memory += hw.MemorySize / mib
+		if hw.CpuMhz > 0 {
+			coresInUse += float64(host.Summary.QuickStats.OverallCpuUsage) / float64(hw.CpuMhz)
+		}
+		memoryInUse += int64(host.Summary.QuickStats.OverallMemoryUsage)
+	}
+	return []quota.Quota{{
+		Service: Service,
+		Name:    CPU,
+		Region:  cluster,
+		InUse:   int64(math.Ceil(coresInUse)),
+		Limit:   cores,
+	}, {
+		Service: Service,
+		Name:    Memory,
+		Region:  cluster,
+		InUse:   memoryInUse,
+		Limit:   memory,
+	}}
+}
+
+// datastoreQuota returns the storage quota of a datastore.
+func datastoreQuota(datastore string, summary types.DatastoreSummary) quota.Quota {
+	capacity := summary.Capacity / gib
+	return quota.Quota{
+		Service: Service,
+		Name:    Storage,
+		Region:  datastore,
+		InUse:   capacity - summary.FreeSpace/gib,
+		Limit:   capacity,
+	}
+}
@@ -0,0 +1,74 @@
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/openshift/installer/pkg/quota"
+)
+
+func TestLoad(t *testing.T) {
+	simulator.Test(func(ctx context.Context, client *vim25.Client) {
+		quotas, err := Load(ctx, client, "DC0", "DC0_C0", "LocalDS_0")
+		if !assert.NoError(t, err) {
+			return
+		}
+		if assert.Len(t, quotas, 3) {
+			for i, name := range []string{CPU, Memory, Storage} {
+				assert.Equal(t, name, quotas[i].Name)
+				assert.True(t, quotas[i].Limit > 0, "%s has no capacity", name)
+				assert.True(t, quotas[i].InUse <= quotas[i].Limit, "%s uses more than its capacity", name)
+			}
+			assert.Equal(t, "DC0_C0", quotas[0].Region)
+			assert.Equal(t, "LocalDS_0", quotas[2].Region)
+		}
+
+		_, err = Load(ctx, client, "DC0", "missing", "LocalDS_0")
+		assert.EqualError(t, err, "failed to find cluster missing: cluster 'missing' not found")
+	})
+}
+
+func host(cores int16, mhz int32, memoryMiB int64, cpuUsage int32, memoryUsage int32, state types.HostSystemConnectionState, maintenance bool) mo.HostSystem {
+	return mo.HostSystem{
+		Summary: types.HostListSummary{
+			Hardware: &types.HostHardwareSummary{
+				NumCpuCores: cores,
+				CpuMhz:      mhz,
+				MemorySize:  memoryMiB * mib,
+			},
+			Runtime: &types.HostRuntimeInfo{
+				ConnectionState:   state,
+				InMaintenanceMode: maintenance,
+			},
+			QuickStats: types.HostListSummaryQuickStats{
+				OverallCpuUsage:    cpuUsage,
+				OverallMemoryUsage: memoryUsage,
+			},
+		},
+	}
+}
+
+func Test_clusterQuotas(t *testing.T) {
+	hosts := []mo.HostSystem{
+		host(16, 2000, 131072, 5000, 65536, types.HostSystemConnectionStateConnected, false),
+		host(16, 2000, 131072, 3000, 32768, types.HostSystemConnectionStateConnected, false),
+		host(16, 2000, 131072, 0, 0, types.HostSystemConnectionStateConnected, true),
+		host(16, 2000, 131072, 0, 0, types.HostSystemConnectionStateDisconnected, false),
+	}
+	exp := []quota.Quota{
+		{Service: "vsphere", Name: "vsphere/cpu", Region: "cluster", InUse: 4, Limit: 32},
+		{Service: "vsphere", Name: "vsphere/memory", Region: "cluster", InUse: 98304, Limit: 262144},
+	}
+	assert.Equal(t, exp, clusterQuotas("cluster", hosts))
+}
+
+func Test_datastoreQuota(t *testing.T) {
+	exp := quota.Quota{Service: "vsphere", Name: "vsphere/storage", Region: "ds", InUse: 700, Limit: 1024}
+	assert.Equal(t, exp, datastoreQuota("ds", types.DatastoreSummary{Capacity: 1024 * gib, FreeSpace: 324 * gib}))
+}
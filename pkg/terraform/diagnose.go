@@ -1,91 +1,200 @@
 package terraform
 
 import (
+	"io/ioutil"
+	"os"
 	"regexp"
+	"sort"
+	"sync"
 
+	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 
+	"github.com/openshift/installer/data"
 	"github.com/openshift/installer/pkg/diagnostics"
+	"github.com/openshift/installer/pkg/events"
 )
 
-// Diagnose accepts an error from terraform runs and tries to diagnose the
-// underlying cause.
-func Diagnose(message string) error {
-	for _, cand := range conditions {
-		if cand.match.MatchString(message) {
-			return &diagnostics.Err{
-				Source:  "Infrastructure Provider",
-				Reason:  cand.reason,
-				Message: cand.message,
-			}
+const (
+	// DiagnosticsRulesEnv is the environment variable naming a file of
+	// additional rules for diagnosing Terraform failures.
+	DiagnosticsRulesEnv = "OPENSHIFT_INSTALL_DIAGNOSTICS_RULES"
+
+	// defaultRulesFileName is the data asset holding the built-in rules.
+	defaultRulesFileName = "diagnostics/terraform.yaml"
+)
+
+// Rule describes a known Terraform failure.
+type Rule struct {
+	// Reason is a CamelCase string that summarizes the failure.
+	Reason string `json:"reason"`
+
+	// Platforms are the platforms the rule applies to. A rule without
+	// platforms applies to every platform.
+	Platforms []string `json:"platforms,omitempty"`
+
+	// Match is a regular expression matched against Terraform's error
+	// output.
+	Match string `json:"match"`
+
+	// Message explains the failure to end users.
+	Message string `json:"message"`
+
+	// Remediation links to documentation on how to fix or avoid the
+	// failure.
+	Remediation string `json:"remediation,omitempty"`
+
+	// Rank orders the diagnoses when several rules match; higher ranks are
+	// reported first.
+	Rank int `json:"rank,omitempty"`
+
+	match *regexp.Regexp
+}
+
+type rulesFile struct {
+	Rules []Rule `json:"rules"`
+}
+
+var (
+	defaultRulesOnce sync.Once
+	defaultRules     []Rule
+	defaultRulesErr  error
+
+	// userRules are the rules loaded by LoadUserRules.
+	userRules []Rule
+)
+
+// ParseRules parses and compiles rules in the YAML format of the built-in
+// rules file.
+func ParseRules(raw []byte) ([]Rule, error) {
+	var file rulesFile
+	if err := yaml.Unmarshal(raw, &file); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal rules")
+	}
+	for i := range file.Rules {
+		rule := &file.Rules[i]
+		if rule.Reason == "" {
+			return nil, errors.Errorf("rules[%d]: reason is required", i)
+		}
+		if rule.Match == "" {
+			return nil, errors.Errorf("rules[%d] (%s): match is required", i, rule.Reason)
+		}
+		var err error
+		rule.match, err = regexp.Compile(rule.Match)
+		if err != nil {
+			return nil, errors.Wrapf(err, "rules[%d] (%s): invalid match", i, rule.Reason)
 		}
 	}
+	return file.Rules, nil
+}
 
-	return errors.New("failed to complete the change")
+// LoadUserRules loads additional rules from the file at path, or from the
+// file named by the OPENSHIFT_INSTALL_DIAGNOSTICS_RULES environment variable
+// if path is empty. User rules are ranked ahead of built-in rules of the
+// same rank.
+func LoadUserRules(path string) error {
+	if path == "" {
+		path = os.Getenv(DiagnosticsRulesEnv)
+	}
+	if path == "" {
+		return nil
+	}
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read diagnostics rules")
+	}
+	rules, err := ParseRules(raw)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load diagnostics rules from %s", path)
+	}
+	logrus.Debugf("Loaded %d diagnostics rules from %s", len(rules), path)
+	userRules = rules
+	return nil
 }
 
-type condition struct {
-	match *regexp.Regexp
+func loadDefaultRules() ([]Rule, error) {
+	defaultRulesOnce.Do(func() {
+		file, err := data.Assets.Open(defaultRulesFileName)
+		if err != nil {
+			defaultRulesErr = err
+			return
+		}
+		defer file.Close()
+		raw, err := ioutil.ReadAll(file)
+		if err != nil {
+			defaultRulesErr = err
+			return
+		}
+		defaultRules, defaultRulesErr = ParseRules(raw)
+	})
+	return defaultRules, defaultRulesErr
+}
+
+// Diagnose accepts an error from terraform runs and tries to diagnose the
+// underlying cause. It returns the most likely diagnosis.
+func Diagnose(message string) error {
+	return diagnose("", message)
+}
+
+// diagnose returns the most likely diagnosis for the error output of a
+// terraform run on platform, and logs any other matching diagnoses.
+func diagnose(platform string, message string) error {
+	diagnoses := Diagnoses(platform, message)
+	if len(diagnoses) == 0 {
+		return errors.New("failed to complete the change")
+	}
+	for _, d := range diagnoses[1:] {
+		logrus.Infof("Another possible cause: %v", d)
+	}
+	events.Diagnosed(diagnoses[0])
+	return diagnoses[0]
+}
+
+// Diagnoses returns every diagnosis matching the error output of a
+// terraform run on platform, most likely first. An empty platform matches
+// the rules of every platform.
+func Diagnoses(platform string, message string) []*diagnostics.Err {
+	defaults, err := loadDefaultRules()
+	if err != nil {
+		logrus.Debugf("Failed to load the built-in diagnostics rules: %v", err)
+	}
+	return match(append(append([]Rule{}, userRules...), defaults...), platform, message)
+}
 
-	reason  string
-	message string
+// match returns the diagnoses for the rules matching message, ordered by
+// rank and then by the order of the rules.
+func match(rules []Rule, platform string, message string) []*diagnostics.Err {
+	var matched []Rule
+	for _, rule := range rules {
+		if rule.appliesTo(platform) && rule.match.MatchString(message) {
+			matched = append(matched, rule)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].Rank > matched[j].Rank
+	})
+
+	diagnoses := make([]*diagnostics.Err, 0, len(matched))
+	for _, rule := range matched {
+		diagnoses = append(diagnoses, &diagnostics.Err{
+			Source:      "Infrastructure Provider",
+			Reason:      rule.Reason,
+			Message:     rule.Message,
+			Remediation: rule.Remediation,
+		})
+	}
+	return diagnoses
 }
 
-// conditions is a list matches for the error string from terraform.
-// specific on the top, generic matches on the bottom.
-var conditions = []condition{{
-	match: regexp.MustCompile(`Error: Error creating Blob .*: Error copy/waiting`),
-
-	reason:  "Timeout",
-	message: `Copying the VHD to user environment was too slow, and timeout was reached for the success.`,
-}, {
-	match: regexp.MustCompile(`Error: Error Creating/Updating Subnet .*: network.SubnetsClient#CreateOrUpdate: .* Code="AnotherOperationInProgress" Message="Another operation on this or dependent resource is in progress`),
-
-	reason:  "AzureMultiOperationFailure",
-	message: `Creating Subnets failed because Azure could not process multiple operations.`,
-}, {
-	match: regexp.MustCompile(`Error: Error Creating/Updating Public IP .*: network.PublicIPAddressesClient#CreateOrUpdate: .* Code="PublicIPCountLimitReached" Message="Cannot create more than .* public IP addresses for this subscription in this region`),
-
-	reason:  "AzureQuotaLimitExceeded",
-	message: `Service limits exceeded for Public IPs in the the subscriptions for the region. Requesting increase in quota should fix the error.`,
-}, {
-	match: regexp.MustCompile(`Error: compute\.VirtualMachinesClient#CreateOrUpdate: .* Code="OperationNotAllowed" Message="Operation could not be completed as it results in exceeding approved Total Regional Cores quota`),
-
-	reason:  "AzureQuotaLimitExceeded",
-	message: `Service limits exceeded for Virtual Machine cores in the the subscriptions for the region. Requesting increase in quota should fix the error.`,
-}, {
-	match: regexp.MustCompile(`Error: Code="OSProvisioningTimedOut"`),
-
-	reason:  "AzureVirtualMachineFailure",
-	message: `Some virtual machines failed to provision in alloted time. Virtual machines can fail to provision if the bootstap virtual machine has failing services.`,
-}, {
-	match: regexp.MustCompile(`Status=404 Code="ResourceGroupNotFound"`),
-
-	reason:  "AzureEventualConsistencyFailure",
-	message: `Failed to find a resource that was recently created usualy caused by Azure's eventual consistency delays.`,
-}, {
-	match: regexp.MustCompile(`Error: Error applying IAM policy to project .*: Too many conflicts`),
-
-	reason:  "GCPTooManyIAMUpdatesInFlight",
-	message: `There are a lot of IAM updates to the project in flight. Failed after reaching a limit of read-modify-write on conflict backoffs.`,
-}, {
-	match: regexp.MustCompile(`Error: .*: googleapi: Error 503: .*, backendError`),
-
-	reason:  "GCPBackendInternalError",
-	message: `GCP is experiencing backend service interuptions. Please try again or contact Google Support`,
-}, {
-	match: regexp.MustCompile(`Error: Error waiting for instance to create: Internal error`),
-
-	reason:  "GCPComputeBackendTimeout",
-	message: `GCP is experiencing backend service interuptions, the compute instance failed to create in reasonable time.`,
-}, {
-	match: regexp.MustCompile(`Error: could not contact Ironic API: timeout reached`),
-
-	reason:  "BaremetalIronicAPITimeout",
-	message: `Timed out waiting for provisioning service. This failure can be caused by misconfiguration or inability to download the machine operating system images. Please check the bootstrap host for failing services.`,
-}, {
-	match: regexp.MustCompile(`Error: could not inspect: could not inspect node, node is currently 'inspect failed', last error was 'timeout reached while inspecting the node'`),
-
-	reason:  "BaremetalIronicInspectTimeout",
-	message: `Timed out waiting for node inspection to complete. Please check the console on the host for more details.`,
-}}
+func (r *Rule) appliesTo(platform string) bool {
+	if platform == "" || len(r.Platforms) == 0 {
+		return true
+	}
+	for _, p := range r.Platforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
@@ -1,11 +1,22 @@
 package terraform
 
 import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/data"
 )
 
+func init() {
+	// Load the built-in rules from the source tree.
+	data.Assets = http.Dir(filepath.Join("..", "..", "data", "data"))
+}
+
 func TestDiagnose(t *testing.T) {
 	cases := []struct {
 		input string
@@ -120,3 +131,119 @@ Error: could not inspect: could not inspect node, node is currently 'inspect fai
 		})
 	}
 }
+
+func TestParseRules(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  string
+		err  string
+	}{{
+		name: "valid",
+		raw: `rules:
+- reason: Foo
+  match: 'foo .* bar'
+  message: Foo failed.
+`,
+	}, {
+		name: "missing reason",
+		raw: `rules:
+- match: foo
+`,
+		err: `^rules\[0\]: reason is required$`,
+	}, {
+		name: "missing match",
+		raw: `rules:
+- reason: Foo
+`,
+		err: `^rules\[0\] \(Foo\): match is required$`,
+	}, {
+		name: "invalid match",
+		raw: `rules:
+- reason: Foo
+  match: 'foo ('
+`,
+		err: `^rules\[0\] \(Foo\): invalid match: error parsing regexp`,
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseRules([]byte(test.raw))
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, test.err, err)
+			}
+		})
+	}
+}
+
+func TestDefaultRules(t *testing.T) {
+	rules, err := loadDefaultRules()
+	if assert.NoError(t, err) {
+		assert.NotEmpty(t, rules)
+	}
+}
+
+func TestDiagnoses(t *testing.T) {
+	rules, err := ParseRules([]byte(`rules:
+- reason: Generic
+  match: 'Error: '
+  message: Something failed.
+- reason: Quota
+  platforms: [aws]
+  match: 'LimitExceeded'
+  message: Out of quota.
+  remediation: https://example.com/limits
+  rank: 10
+- reason: OtherPlatform
+  platforms: [gcp]
+  match: 'LimitExceeded'
+  rank: 20
+`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	reasons := func(platform string) []string {
+		var r []string
+		for _, d := range match(rules, platform, "Error: VcpuLimitExceeded") {
+			r = append(r, d.Reason)
+		}
+		return r
+	}
+	assert.Equal(t, []string{"Quota", "Generic"}, reasons("aws"))
+	assert.Equal(t, []string{"OtherPlatform", "Generic"}, reasons("gcp"))
+	assert.Equal(t, []string{"OtherPlatform", "Quota", "Generic"}, reasons(""))
+
+	diagnoses := match(rules, "aws", "Error: VcpuLimitExceeded")
+	assert.EqualError(t, diagnoses[0], "error(Quota) from Infrastructure Provider: Out of quota. (see https://example.com/limits)")
+}
+
+func TestLoadUserRules(t *testing.T) {
+	dir, err := ioutil.TempDir("", "diagnose")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	defer func() { userRules = nil }()
+
+	path := filepath.Join(dir, "rules.yaml")
+	err = ioutil.WriteFile(path, []byte(`rules:
+- reason: AccountPolicyDenied
+  match: 'explicit deny in a service control policy'
+  message: Our account policy blocks this request.
+  rank: 20
+`), 0600)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	os.Setenv(DiagnosticsRulesEnv, path)
+	defer os.Unsetenv(DiagnosticsRulesEnv)
+	if !assert.NoError(t, LoadUserRules("")) {
+		return
+	}
+
+	err = Diagnose("Error: AccessDenied: not authorized to perform: ec2:RunInstances with an explicit deny in a service control policy")
+	assert.Regexp(t, `^error\(AccountPolicyDenied\) from Infrastructure Provider: Our account policy blocks this request\.$`, err)
+}
@@ -0,0 +1,60 @@
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/openshift/installer/pkg/events"
+	"github.com/openshift/installer/pkg/lineprinter"
+)
+
+var (
+	ansiRegexp     = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	progressRegexp = regexp.MustCompile(`^(\S+): (Creating|Still creating|Creation complete|Modifying|Still modifying|Modifications complete|Destroying|Still destroying|Destruction complete|Reading|Still reading|Read complete|Refreshing state)\.*(?:.*\[id=([^\]]+)\])?`)
+
+	progressStates = map[string][2]string{
+		"Creating":               {"create", "started"},
+		"Still creating":         {"create", "in-progress"},
+		"Creation complete":      {"create", "complete"},
+		"Modifying":              {"modify", "started"},
+		"Still modifying":        {"modify", "in-progress"},
+		"Modifications complete": {"modify", "complete"},
+		"Destroying":             {"destroy", "started"},
+		"Still destroying":       {"destroy", "in-progress"},
+		"Destruction complete":   {"destroy", "complete"},
+		"Reading":                {"read", "started"},
+		"Still reading":          {"read", "in-progress"},
+		"Read complete":          {"read", "complete"},
+		"Refreshing state":       {"read", "complete"},
+	}
+)
+
+// parseProgress parses a line of Terraform output into the resource progress
+// it reports. It returns nil for lines which are not resource progress.
+func parseProgress(line string) *events.Terraform {
+	m := progressRegexp.FindStringSubmatch(ansiRegexp.ReplaceAllString(line, ""))
+	if m == nil {
+		return nil
+	}
+	state := progressStates[m[2]]
+	return &events.Terraform{
+		Resource: m[1],
+		Action:   state[0],
+		Status:   state[1],
+		ID:       m[3],
+	}
+}
+
+// progressPrinter wraps print so that every line of Terraform output which
+// reports resource progress is also emitted as a terraform-progress event.
+func progressPrinter(print lineprinter.Print) lineprinter.Print {
+	return func(args ...interface{}) {
+		print(args...)
+		if !events.Enabled() {
+			return
+		}
+		if progress := parseProgress(fmt.Sprint(args...)); progress != nil {
+			events.Emit(events.Event{Type: events.TerraformProgress, Terraform: progress})
+		}
+	}
+}
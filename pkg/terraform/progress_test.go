@@ -0,0 +1,41 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/events"
+)
+
+func TestParseProgress(t *testing.T) {
+	cases := []struct {
+		line     string
+		expected *events.Terraform
+	}{{
+		line:     "module.vpc.aws_vpc.new_vpc[0]: Creating...",
+		expected: &events.Terraform{Resource: "module.vpc.aws_vpc.new_vpc[0]", Action: "create", Status: "started"},
+	}, {
+		line:     "module.vpc.aws_vpc.new_vpc[0]: Still creating... [10s elapsed]",
+		expected: &events.Terraform{Resource: "module.vpc.aws_vpc.new_vpc[0]", Action: "create", Status: "in-progress"},
+	}, {
+		line:     "module.vpc.aws_vpc.new_vpc[0]: Creation complete after 2s [id=vpc-0123456789abcdef0]",
+		expected: &events.Terraform{Resource: "module.vpc.aws_vpc.new_vpc[0]", Action: "create", Status: "complete", ID: "vpc-0123456789abcdef0"},
+	}, {
+		line:     "\x1b[0m\x1b[1maws_instance.bootstrap: Destruction complete after 31s\x1b[0m",
+		expected: &events.Terraform{Resource: "aws_instance.bootstrap", Action: "destroy", Status: "complete"},
+	}, {
+		line:     "data.aws_ami.rhcos: Refreshing state... [id=ami-0123]",
+		expected: &events.Terraform{Resource: "data.aws_ami.rhcos", Action: "read", Status: "complete", ID: "ami-0123"},
+	}, {
+		line: "Apply complete! Resources: 12 added, 0 changed, 0 destroyed.",
+	}, {
+		line: "Error: Error creating VPC: VpcLimitExceeded: The maximum number of VPCs has been reached.",
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.line, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseProgress(tc.line))
+		})
+	}
+}
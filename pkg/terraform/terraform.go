@@ -45,14 +45,14 @@ func Apply(dir string, platform string, extraArgs ...string) (path string, err e
 	args = append(args, dir)
 	sf := filepath.Join(dir, StateFileName)
 
-	lpDebug := &lineprinter.LinePrinter{Print: (&lineprinter.Trimmer{WrappedPrint: logrus.Debug}).Print}
+	lpDebug := &lineprinter.LinePrinter{Print: progressPrinter((&lineprinter.Trimmer{WrappedPrint: logrus.Debug}).Print)}
 	lpError := &lineprinter.LinePrinter{Print: (&lineprinter.Trimmer{WrappedPrint: logrus.Error}).Print}
 	defer lpDebug.Close()
 	defer lpError.Close()
 
 	errBuf := &bytes.Buffer{}
 	if exitCode := texec.Apply(dir, args, lpDebug, io.MultiWriter(errBuf, lpError)); exitCode != 0 {
-		return sf, errors.Wrap(Diagnose(errBuf.String()), "failed to apply Terraform")
+		return sf, errors.Wrap(diagnose(platform, errBuf.String()), "failed to apply Terraform")
 	}
 	return sf, nil
 }
@@ -75,7 +75,7 @@ func Destroy(dir string, platform string, extraArgs ...string) (err error) {
 	args := append(defaultArgs, extraArgs...)
 	args = append(args, dir)
 
-	lpDebug := &lineprinter.LinePrinter{Print: (&lineprinter.Trimmer{WrappedPrint: logrus.Debug}).Print}
+	lpDebug := &lineprinter.LinePrinter{Print: progressPrinter((&lineprinter.Trimmer{WrappedPrint: logrus.Debug}).Print)}
 	lpError := &lineprinter.LinePrinter{Print: (&lineprinter.Trimmer{WrappedPrint: logrus.Error}).Print}
 	defer lpDebug.Close()
 	defer lpError.Close()
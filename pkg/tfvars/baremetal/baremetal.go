@@ -9,10 +9,13 @@ import (
 	"path"
 	"strings"
 
+	ignutil "github.com/coreos/ignition/v2/config/util"
 	igntypes "github.com/coreos/ignition/v2/config/v3_1/types"
 
 	"github.com/metal3-io/baremetal-operator/pkg/bmc"
 	"github.com/metal3-io/baremetal-operator/pkg/hardware"
+	"github.com/openshift/installer/pkg/asset/ignition"
+	baremetalignition "github.com/openshift/installer/pkg/asset/ignition/bootstrap/baremetal"
 	"github.com/openshift/installer/pkg/tfvars/internal/cache"
 	"github.com/openshift/installer/pkg/types/baremetal"
 	"github.com/pkg/errors"
@@ -31,6 +34,10 @@ type config struct {
 	MasterIgnitionURLCACert  string            `json:"master_ignition_url_ca_cert,omitempty"`
 	MasterIgnitionURLHeaders map[string]string `json:"master_ignition_url_headers,omitempty"`
 
+	// Per-host ignition for hosts with a static network configuration,
+	// empty for hosts that use the master ignition URL.
+	UserData []string `json:"user_data"`
+
 	// Data required for control plane deployment - several maps per host, because of terraform's limitations
 	Hosts         []map[string]interface{} `json:"hosts"`
 	RootDevices   []map[string]interface{} `json:"root_devices"`
@@ -40,7 +47,7 @@ type config struct {
 }
 
 // TFVars generates bare metal specific Terraform variables.
-func TFVars(libvirtURI, bootstrapProvisioningIP, bootstrapOSImage, externalBridge, externalMAC, provisioningBridge, provisioningMAC string, platformHosts []*baremetal.Host, image, ironicUsername, ironicPassword, ignition string) ([]byte, error) {
+func TFVars(libvirtURI, bootstrapProvisioningIP, bootstrapOSImage, externalBridge, externalMAC, provisioningBridge, provisioningMAC string, platformHosts []*baremetal.Host, image, ironicUsername, ironicPassword, masterIgnition string) ([]byte, error) {
 	bootstrapOSImage, err := cache.DownloadImageFile(bootstrapOSImage)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to use cached bootstrap libvirt image")
@@ -163,7 +170,7 @@ func TFVars(libvirtURI, bootstrapProvisioningIP, bootstrapOSImage, externalBridg
 	}
 
 	var masterIgn igntypes.Config
-	if err := json.Unmarshal([]byte(ignition), &masterIgn); err != nil {
+	if err := json.Unmarshal([]byte(masterIgnition), &masterIgn); err != nil {
 		return nil, err
 	}
 	if len(masterIgn.Ignition.Config.Merge) == 0 {
@@ -184,6 +191,30 @@ func TFVars(libvirtURI, bootstrapProvisioningIP, bootstrapOSImage, externalBridg
 			masterIgn.Ignition.Version),
 	}
 
+	// Hosts with a static network configuration get their own pointer
+	// ignition, which carries the NetworkManager keyfiles of the host.
+	userData := make([]string, len(platformHosts))
+	for i, host := range platformHosts {
+		if host.NetworkConfig == nil {
+			continue
+		}
+		files, err := baremetalignition.NetworkFiles(host.NetworkConfig)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to generate network configuration of host %s", host.Name)
+		}
+		hostIgn := masterIgn
+		hostIgn.Ignition.Config.Merge = []igntypes.Resource{{
+			Source:      masterIgn.Ignition.Config.Merge[0].Source,
+			HTTPHeaders: []igntypes.HTTPHeader{{Name: "Accept", Value: ignutil.StrToPtr(ignitionURLHeaders["Accept"])}},
+		}}
+		hostIgn.Storage.Files = append(append([]igntypes.File{}, masterIgn.Storage.Files...), files...)
+		data, err := ignition.Marshal(hostIgn)
+		if err != nil {
+			return nil, err
+		}
+		userData[i] = string(data)
+	}
+
 	cfg := &config{
 		LibvirtURI:               libvirtURI,
 		BootstrapProvisioningIP:  bootstrapProvisioningIP,
@@ -199,6 +230,7 @@ func TFVars(libvirtURI, bootstrapProvisioningIP, bootstrapOSImage, externalBridg
 		MasterIgnitionURL:        ignitionURL,
 		MasterIgnitionURLCACert:  ignitionURLCACert,
 		MasterIgnitionURLHeaders: ignitionURLHeaders,
+		UserData:                 userData,
 	}
 
 	return json.MarshalIndent(cfg, "", "  ")
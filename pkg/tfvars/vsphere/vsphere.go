@@ -2,14 +2,24 @@ package vsphere
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	vsphereapis "github.com/openshift/machine-api-operator/pkg/apis/vsphereprovider/v1beta1"
 	"github.com/pkg/errors"
 
 	"github.com/openshift/installer/pkg/tfvars/internal/cache"
+	"github.com/openshift/installer/pkg/types/vsphere"
 )
 
+type failureDomain struct {
+	Datacenter string `json:"datacenter"`
+	Cluster    string `json:"cluster"`
+	Datastore  string `json:"datastore"`
+	Network    string `json:"network"`
+	Folder     string `json:"folder"`
+}
+
 type config struct {
 	VSphereURL        string `json:"vsphere_url"`
 	VSphereUsername   string `json:"vsphere_username"`
@@ -26,6 +36,18 @@ type config struct {
 	Template          string `json:"vsphere_template"`
 	OvaFilePath       string `json:"vsphere_ova_filepath"`
 	PreexistingFolder bool   `json:"vsphere_preexisting_folder"`
+
+	ControlPlaneFailureDomains []failureDomain `json:"vsphere_control_plane_failure_domains"`
+	BootstrapNetworkKargs      string          `json:"vsphere_bootstrap_network_kargs"`
+	ControlPlaneNetworkKargs   []string        `json:"vsphere_control_plane_network_kargs"`
+
+	ComputeFailureDomains    []failureDomain `json:"vsphere_compute_failure_domains"`
+	ComputeNetworkKargs      []string        `json:"vsphere_compute_network_kargs"`
+	ComputeIgnition          string          `json:"vsphere_compute_ignition"`
+	ComputeMemoryMiB         int64           `json:"vsphere_compute_memory_mib"`
+	ComputeDiskGiB           int32           `json:"vsphere_compute_disk_gib"`
+	ComputeNumCPUs           int32           `json:"vsphere_compute_num_cpus"`
+	ComputeNumCoresPerSocket int32           `json:"vsphere_compute_cores_per_socket"`
 }
 
 // TFVarsSources contains the parameters to be converted into Terraform variables
@@ -33,9 +55,15 @@ type TFVarsSources struct {
 	ControlPlaneConfigs []*vsphereapis.VSphereMachineProviderSpec
 	Username            string
 	Password            string
-	Cluster             string
 	ImageURL            string
 	PreexistingFolder   bool
+	InfraID             string
+	StaticIPs           *vsphere.StaticIPConfig
+
+	// ComputeConfigs are the provider specs of the compute machines that
+	// the installer creates itself, which it only does with static IPs.
+	ComputeConfigs  []*vsphereapis.VSphereMachineProviderSpec
+	ComputeIgnition string
 }
 
 //TFVars generate vSphere-specific Terraform variables
@@ -52,6 +80,32 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 	// /<datacenter>/vm/<folder_path> so we can split on "vm/".
 	folderRelPath := strings.SplitAfterN(controlPlaneConfig.Workspace.Folder, "vm/", 2)[1]
 
+	failureDomains := machineFailureDomains(sources.ControlPlaneConfigs, controlPlaneConfig.Workspace.Folder)
+	computeFailureDomains := machineFailureDomains(sources.ComputeConfigs, controlPlaneConfig.Workspace.Folder)
+
+	// Without static IPs, the machines get their network configuration
+	// from DHCP.
+	bootstrapKargs := ""
+	controlPlaneKargs := make([]string, len(sources.ControlPlaneConfigs))
+	computeKargs := make([]string, len(sources.ComputeConfigs))
+	if sources.StaticIPs != nil {
+		ips, err := sources.StaticIPs.ControlPlane.IPs(len(sources.ControlPlaneConfigs))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to assign the control plane IP addresses")
+		}
+		bootstrapKargs = sources.StaticIPs.KernelArgs(sources.StaticIPs.Bootstrap, fmt.Sprintf("%s-bootstrap", sources.InfraID))
+		for i, ip := range ips {
+			controlPlaneKargs[i] = sources.StaticIPs.KernelArgs(ip, fmt.Sprintf("%s-master-%d", sources.InfraID, i))
+		}
+		ips, err = sources.StaticIPs.Compute.IPs(len(sources.ComputeConfigs))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to assign the compute IP addresses")
+		}
+		for i, ip := range ips {
+			computeKargs[i] = sources.StaticIPs.KernelArgs(ip, fmt.Sprintf("%s-worker-%d", sources.InfraID, i))
+		}
+	}
+
 	cfg := &config{
 		VSphereURL:        controlPlaneConfig.Workspace.Server,
 		VSphereUsername:   sources.Username,
@@ -60,7 +114,7 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		DiskGiB:           controlPlaneConfig.DiskGiB,
 		NumCPUs:           controlPlaneConfig.NumCPUs,
 		NumCoresPerSocket: controlPlaneConfig.NumCoresPerSocket,
-		Cluster:           sources.Cluster,
+		Cluster:           failureDomains[0].Cluster,
 		Datacenter:        controlPlaneConfig.Workspace.Datacenter,
 		Datastore:         controlPlaneConfig.Workspace.Datastore,
 		Folder:            folderRelPath,
@@ -68,7 +122,46 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		Template:          controlPlaneConfig.Template,
 		OvaFilePath:       cachedImage,
 		PreexistingFolder: sources.PreexistingFolder,
+
+		ControlPlaneFailureDomains: failureDomains,
+		BootstrapNetworkKargs:      bootstrapKargs,
+		ControlPlaneNetworkKargs:   controlPlaneKargs,
+
+		ComputeFailureDomains: computeFailureDomains,
+		ComputeNetworkKargs:   computeKargs,
+	}
+	if len(sources.ComputeConfigs) > 0 {
+		computeConfig := sources.ComputeConfigs[0]
+		cfg.ComputeIgnition = sources.ComputeIgnition
+		cfg.ComputeMemoryMiB = computeConfig.MemoryMiB
+		cfg.ComputeDiskGiB = computeConfig.DiskGiB
+		cfg.ComputeNumCPUs = computeConfig.NumCPUs
+		cfg.ComputeNumCoresPerSocket = computeConfig.NumCoresPerSocket
 	}
 
 	return json.MarshalIndent(cfg, "", "  ")
 }
+
+// machineFailureDomains returns the failure domain chosen for each machine in its
+// provider spec. The folder is left empty when the machine goes in the
+// default folder, the folder of the first control plane machine, so that
+// Terraform creates it first.
+func machineFailureDomains(configs []*vsphereapis.VSphereMachineProviderSpec, defaultFolder string) []failureDomain {
+	domains := make([]failureDomain, len(configs))
+	for i, c := range configs {
+		folder := ""
+		if c.Workspace.Folder != defaultFolder {
+			folder = strings.SplitAfterN(c.Workspace.Folder, "vm/", 2)[1]
+		}
+		// The resource pool is always of the form /<datacenter>/host/<cluster>/Resources.
+		cluster := strings.TrimSuffix(strings.SplitAfterN(c.Workspace.ResourcePool, "host/", 2)[1], "/Resources")
+		domains[i] = failureDomain{
+			Datacenter: c.Workspace.Datacenter,
+			Cluster:    cluster,
+			Datastore:  c.Workspace.Datastore,
+			Network:    c.Network.Devices[0].NetworkName,
+			Folder:     folder,
+		}
+	}
+	return domains
+}
@@ -0,0 +1,447 @@
+package baremetal
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// NetworkConfig is a static network configuration for a host, described
+// with the NMState schema (https://nmstate.io). Only the subset of the
+// schema needed to bring up a host without DHCP is supported: ethernet,
+// bond and VLAN interfaces with static addresses, routes and DNS
+// resolvers.
+type NetworkConfig struct {
+	// Interfaces are the network interfaces to configure.
+	Interfaces []NetworkInterface `json:"interfaces"`
+
+	// Routes are the static routes to configure.
+	// +optional
+	Routes *NetworkRoutes `json:"routes,omitempty"`
+
+	// DNSResolver holds the DNS servers and search domains to use.
+	// +optional
+	DNSResolver *DNSResolver `json:"dns-resolver,omitempty"`
+}
+
+// NetworkInterfaceType is the type of a network interface.
+// +kubebuilder:validation:Enum=ethernet;bond;vlan
+type NetworkInterfaceType string
+
+const (
+	// EthernetInterface is a physical ethernet interface.
+	EthernetInterface NetworkInterfaceType = "ethernet"
+	// BondInterface aggregates several ethernet interfaces.
+	BondInterface NetworkInterfaceType = "bond"
+	// VLANInterface is a VLAN on top of another interface.
+	VLANInterface NetworkInterfaceType = "vlan"
+)
+
+// NetworkInterface is the configuration of a single network interface.
+type NetworkInterface struct {
+	// Name is the name of the interface, e.g. "eno1" or "bond0".
+	Name string `json:"name"`
+
+	// Type is the type of the interface.
+	Type NetworkInterfaceType `json:"type"`
+
+	// State is the state of the interface. Only "up" and "down" are
+	// supported. The default is "up".
+	// +kubebuilder:validation:Enum="";up;down
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// MACAddress pins the configuration of an ethernet interface to the
+	// device with the given MAC address.
+	// +optional
+	MACAddress string `json:"mac-address,omitempty"`
+
+	// MTU is the maximum transmission unit of the interface.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MTU int `json:"mtu,omitempty"`
+
+	// IPv4 is the IPv4 configuration of the interface.
+	// +optional
+	IPv4 *InterfaceIP `json:"ipv4,omitempty"`
+
+	// IPv6 is the IPv6 configuration of the interface.
+	// +optional
+	IPv6 *InterfaceIP `json:"ipv6,omitempty"`
+
+	// LinkAggregation is the configuration of a bond interface.
+	// +optional
+	LinkAggregation *LinkAggregation `json:"link-aggregation,omitempty"`
+
+	// VLAN is the configuration of a VLAN interface.
+	// +optional
+	VLAN *VLAN `json:"vlan,omitempty"`
+}
+
+// InterfaceIP is the IPv4 or IPv6 configuration of an interface.
+type InterfaceIP struct {
+	// Enabled enables the address family on the interface.
+	Enabled bool `json:"enabled"`
+
+	// DHCP enables DHCP (or SLAAC/DHCPv6 for IPv6) on the interface.
+	// +optional
+	DHCP bool `json:"dhcp,omitempty"`
+
+	// Address is the list of static addresses of the interface.
+	// +optional
+	Address []InterfaceAddress `json:"address,omitempty"`
+}
+
+// InterfaceAddress is a static address of an interface.
+type InterfaceAddress struct {
+	// IP is the address.
+	// +kubebuilder:validation:Format=ip
+	IP string `json:"ip"`
+
+	// PrefixLength is the length of the network prefix of the address.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=128
+	PrefixLength int `json:"prefix-length"`
+}
+
+// LinkAggregation is the configuration of a bond interface.
+type LinkAggregation struct {
+	// Mode is the bonding mode, e.g. "active-backup" or "802.3ad".
+	Mode string `json:"mode"`
+
+	// Slaves are the names of the ethernet interfaces in the bond.
+	Slaves []string `json:"slaves"`
+
+	// Options are additional bonding options, e.g. "miimon".
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// VLAN is the configuration of a VLAN interface.
+type VLAN struct {
+	// BaseIface is the name of the interface the VLAN is on.
+	BaseIface string `json:"base-iface"`
+
+	// ID is the VLAN ID.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4094
+	ID int `json:"id"`
+}
+
+// NetworkRoutes holds the static routes of a host.
+type NetworkRoutes struct {
+	// Config is the list of static routes.
+	Config []NetworkRoute `json:"config"`
+}
+
+// NetworkRoute is a static route.
+type NetworkRoute struct {
+	// Destination is the destination network in CIDR notation, e.g.
+	// "0.0.0.0/0" for the default route.
+	Destination string `json:"destination"`
+
+	// NextHopAddress is the address of the gateway.
+	// +kubebuilder:validation:Format=ip
+	NextHopAddress string `json:"next-hop-address"`
+
+	// NextHopInterface is the name of the interface to route through.
+	NextHopInterface string `json:"next-hop-interface"`
+
+	// Metric is the metric of the route.
+	// +optional
+	Metric int `json:"metric,omitempty"`
+}
+
+// DNSResolver holds the DNS configuration of a host.
+type DNSResolver struct {
+	// Config is the DNS configuration.
+	Config DNSResolverConfig `json:"config"`
+}
+
+// DNSResolverConfig holds the DNS servers and search domains.
+type DNSResolverConfig struct {
+	// Server is the list of DNS server addresses.
+	// +optional
+	Server []string `json:"server,omitempty"`
+
+	// Search is the list of DNS search domains.
+	// +optional
+	Search []string `json:"search,omitempty"`
+}
+
+// Addresses returns all the static addresses of the interfaces.
+func (source *NetworkConfig) Addresses() []net.IP {
+	var ips []net.IP
+	if source == nil {
+		return ips
+	}
+	for _, iface := range source.Interfaces {
+		for _, family := range []*InterfaceIP{iface.IPv4, iface.IPv6} {
+			if family == nil || !family.Enabled {
+				continue
+			}
+			for _, address := range family.Address {
+				if ip := net.ParseIP(address.IP); ip != nil {
+					ips = append(ips, ip)
+				}
+			}
+		}
+	}
+	return ips
+}
+
+// MakeKeyfiles converts a NetworkConfig into NetworkManager keyfiles,
+// indexed by connection name, suitable to be written to
+// /etc/NetworkManager/system-connections.
+func (source *NetworkConfig) MakeKeyfiles() (map[string]string, error) {
+	keyfiles := map[string]string{}
+	if source == nil {
+		return keyfiles, nil
+	}
+
+	// Bonds own their slaves, which then carry no IP configuration.
+	masters := map[string]string{}
+	for _, iface := range source.Interfaces {
+		if iface.Type == BondInterface && iface.LinkAggregation != nil {
+			for _, slave := range iface.LinkAggregation.Slaves {
+				masters[slave] = iface.Name
+			}
+		}
+	}
+
+	for _, iface := range source.Interfaces {
+		var b strings.Builder
+		section := func(name string, keys ...string) {
+			fmt.Fprintf(&b, "[%s]\n", name)
+			for i := 0; i+1 < len(keys); i += 2 {
+				if keys[i+1] != "" {
+					fmt.Fprintf(&b, "%s=%s\n", keys[i], keys[i+1])
+				}
+			}
+			b.WriteString("\n")
+		}
+
+		autoconnect := "true"
+		if iface.State == "down" {
+			autoconnect = "false"
+		}
+		connection := []string{
+			"id", iface.Name,
+			"type", string(iface.Type),
+			"interface-name", iface.Name,
+			"autoconnect", autoconnect,
+		}
+		if master, ok := masters[iface.Name]; ok {
+			connection = append(connection, "master", master, "slave-type", "bond")
+		}
+		section("connection", connection...)
+
+		mtu := ""
+		if iface.MTU > 0 {
+			mtu = fmt.Sprint(iface.MTU)
+		}
+		switch iface.Type {
+		case EthernetInterface:
+			section("ethernet", "mac-address", strings.ToUpper(iface.MACAddress), "mtu", mtu)
+		case BondInterface:
+			if iface.LinkAggregation == nil {
+				return nil, fmt.Errorf("interface %s: bond without link-aggregation", iface.Name)
+			}
+			options := []string{"mode", iface.LinkAggregation.Mode}
+			names := make([]string, 0, len(iface.LinkAggregation.Options))
+			for name := range iface.LinkAggregation.Options {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				options = append(options, name, iface.LinkAggregation.Options[name])
+			}
+			section("bond", options...)
+		case VLANInterface:
+			if iface.VLAN == nil {
+				return nil, fmt.Errorf("interface %s: vlan without vlan configuration", iface.Name)
+			}
+			section("vlan", "parent", iface.VLAN.BaseIface, "id", fmt.Sprint(iface.VLAN.ID), "mtu", mtu)
+		default:
+			return nil, fmt.Errorf("interface %s: unsupported type %q", iface.Name, iface.Type)
+		}
+
+		if _, ok := masters[iface.Name]; !ok {
+			section("ipv4", source.ipKeys(iface.Name, iface.IPv4, false)...)
+			section("ipv6", source.ipKeys(iface.Name, iface.IPv6, true)...)
+		}
+
+		keyfiles[iface.Name] = b.String()
+	}
+
+	return keyfiles, nil
+}
+
+// ipKeys returns the keyfile keys of the ipv4 or ipv6 section of an
+// interface. Routes through the interface and the DNS resolvers are added
+// to the section of each address family that has static addresses.
+func (source *NetworkConfig) ipKeys(name string, family *InterfaceIP, ipv6 bool) []string {
+	switch {
+	case family == nil || !family.Enabled:
+		if ipv6 {
+			return []string{"method", "ignore"}
+		}
+		return []string{"method", "disabled"}
+	case family.DHCP:
+		return []string{"method", "auto"}
+	case len(family.Address) == 0:
+		if ipv6 {
+			return []string{"method", "link-local"}
+		}
+		return []string{"method", "disabled"}
+	}
+
+	keys := []string{"method", "manual"}
+	for i, address := range family.Address {
+		keys = append(keys, fmt.Sprintf("address%d", i+1), fmt.Sprintf("%s/%d", address.IP, address.PrefixLength))
+	}
+
+	if source.Routes != nil {
+		index := 1
+		for _, route := range source.Routes.Config {
+			if route.NextHopInterface != name {
+				continue
+			}
+			destination, _, err := net.ParseCIDR(route.Destination)
+			if err != nil || (destination.To4() == nil) != ipv6 {
+				continue
+			}
+			if destination.IsUnspecified() {
+				keys = append(keys, "gateway", route.NextHopAddress)
+				if route.Metric > 0 {
+					keys = append(keys, "route-metric", fmt.Sprint(route.Metric))
+				}
+				continue
+			}
+			value := fmt.Sprintf("%s,%s", route.Destination, route.NextHopAddress)
+			if route.Metric > 0 {
+				value = fmt.Sprintf("%s,%d", value, route.Metric)
+			}
+			keys = append(keys, fmt.Sprintf("route%d", index), value)
+			index++
+		}
+	}
+
+	if source.DNSResolver != nil {
+		var servers []string
+		for _, server := range source.DNSResolver.Config.Server {
+			if ip := net.ParseIP(server); ip != nil && (ip.To4() == nil) == ipv6 {
+				servers = append(servers, server)
+			}
+		}
+		if len(servers) > 0 {
+			keys = append(keys, "dns", strings.Join(servers, ";")+";")
+		}
+		if len(source.DNSResolver.Config.Search) > 0 {
+			keys = append(keys, "dns-search", strings.Join(source.DNSResolver.Config.Search, ";")+";")
+		}
+	}
+
+	return keys
+}
+
+// MakeNetworkData converts a NetworkConfig into the OpenStack
+// network_data.json format that the baremetal-operator writes to the
+// config drive of a host.
+func (source *NetworkConfig) MakeNetworkData() map[string]interface{} {
+	links := []interface{}{}
+	networks := []interface{}{}
+	services := []interface{}{}
+	if source == nil {
+		return map[string]interface{}{"links": links, "networks": networks, "services": services}
+	}
+
+	for _, iface := range source.Interfaces {
+		link := map[string]interface{}{"id": iface.Name}
+		switch iface.Type {
+		case BondInterface:
+			link["type"] = "bond"
+			if iface.LinkAggregation != nil {
+				link["bond_mode"] = iface.LinkAggregation.Mode
+				link["bond_links"] = iface.LinkAggregation.Slaves
+			}
+		case VLANInterface:
+			link["type"] = "vlan"
+			if iface.VLAN != nil {
+				link["vlan_link"] = iface.VLAN.BaseIface
+				link["vlan_id"] = iface.VLAN.ID
+			}
+		default:
+			link["type"] = "phy"
+		}
+		if iface.MACAddress != "" {
+			link["ethernet_mac_address"] = strings.ToLower(iface.MACAddress)
+		}
+		if iface.MTU > 0 {
+			link["mtu"] = iface.MTU
+		}
+		links = append(links, link)
+
+		for _, family := range []struct {
+			name string
+			ip   *InterfaceIP
+		}{{"ipv4", iface.IPv4}, {"ipv6", iface.IPv6}} {
+			if family.ip == nil || !family.ip.Enabled {
+				continue
+			}
+			if family.ip.DHCP {
+				networks = append(networks, map[string]interface{}{
+					"id":   fmt.Sprintf("network%d", len(networks)),
+					"type": family.name + "_dhcp",
+					"link": iface.Name,
+				})
+				continue
+			}
+			for _, address := range family.ip.Address {
+				bits := 32
+				if family.name == "ipv6" {
+					bits = 128
+				}
+				network := map[string]interface{}{
+					"id":         fmt.Sprintf("network%d", len(networks)),
+					"type":       family.name,
+					"link":       iface.Name,
+					"ip_address": address.IP,
+					"netmask":    net.IP(net.CIDRMask(address.PrefixLength, bits)).String(),
+					"routes":     source.networkDataRoutes(iface.Name, family.name == "ipv6"),
+				}
+				networks = append(networks, network)
+			}
+		}
+	}
+
+	if source.DNSResolver != nil {
+		for _, server := range source.DNSResolver.Config.Server {
+			services = append(services, map[string]interface{}{"type": "dns", "address": server})
+		}
+	}
+
+	return map[string]interface{}{"links": links, "networks": networks, "services": services}
+}
+
+// networkDataRoutes returns the routes through an interface in the
+// network_data.json format.
+func (source *NetworkConfig) networkDataRoutes(name string, ipv6 bool) []interface{} {
+	routes := []interface{}{}
+	if source.Routes == nil {
+		return routes
+	}
+	for _, route := range source.Routes.Config {
+		_, destination, err := net.ParseCIDR(route.Destination)
+		if err != nil || route.NextHopInterface != name || (destination.IP.To4() == nil) != ipv6 {
+			continue
+		}
+		routes = append(routes, map[string]interface{}{
+			"network": destination.IP.String(),
+			"netmask": net.IP(destination.Mask).String(),
+			"gateway": route.NextHopAddress,
+		})
+	}
+	return routes
+}
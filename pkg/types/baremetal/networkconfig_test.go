@@ -0,0 +1,152 @@
+package baremetal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testNetworkConfig() *NetworkConfig {
+	return &NetworkConfig{
+		Interfaces: []NetworkInterface{
+			{
+				Name:       "eno1",
+				Type:       EthernetInterface,
+				MACAddress: "ca:fe:ca:fe:00:00",
+			},
+			{
+				Name:       "eno2",
+				Type:       EthernetInterface,
+				MACAddress: "ca:fe:ca:fe:00:01",
+			},
+			{
+				Name: "bond0",
+				Type: BondInterface,
+				MTU:  9000,
+				LinkAggregation: &LinkAggregation{
+					Mode:    "active-backup",
+					Slaves:  []string{"eno1", "eno2"},
+					Options: map[string]string{"miimon": "100"},
+				},
+				IPv4: &InterfaceIP{
+					Enabled: true,
+					Address: []InterfaceAddress{{IP: "192.168.111.20", PrefixLength: 24}},
+				},
+			},
+			{
+				Name: "bond0.100",
+				Type: VLANInterface,
+				VLAN: &VLAN{BaseIface: "bond0", ID: 100},
+				IPv4: &InterfaceIP{Enabled: true, DHCP: true},
+			},
+		},
+		Routes: &NetworkRoutes{
+			Config: []NetworkRoute{
+				{Destination: "0.0.0.0/0", NextHopAddress: "192.168.111.1", NextHopInterface: "bond0"},
+				{Destination: "10.0.0.0/8", NextHopAddress: "192.168.111.254", NextHopInterface: "bond0", Metric: 100},
+			},
+		},
+		DNSResolver: &DNSResolver{
+			Config: DNSResolverConfig{
+				Server: []string{"192.168.111.1"},
+				Search: []string{"example.com"},
+			},
+		},
+	}
+}
+
+func TestMakeKeyfiles(t *testing.T) {
+	keyfiles, err := testNetworkConfig().MakeKeyfiles()
+	assert.NoError(t, err)
+
+	assert.Equal(t, `[connection]
+id=eno1
+type=ethernet
+interface-name=eno1
+autoconnect=true
+master=bond0
+slave-type=bond
+
+[ethernet]
+mac-address=CA:FE:CA:FE:00:00
+
+`, keyfiles["eno1"])
+
+	assert.Equal(t, `[connection]
+id=bond0
+type=bond
+interface-name=bond0
+autoconnect=true
+
+[bond]
+mode=active-backup
+miimon=100
+
+[ipv4]
+method=manual
+address1=192.168.111.20/24
+gateway=192.168.111.1
+route1=10.0.0.0/8,192.168.111.254,100
+dns=192.168.111.1;
+dns-search=example.com;
+
+[ipv6]
+method=ignore
+
+`, keyfiles["bond0"])
+
+	assert.Equal(t, `[connection]
+id=bond0.100
+type=vlan
+interface-name=bond0.100
+autoconnect=true
+
+[vlan]
+parent=bond0
+id=100
+
+[ipv4]
+method=auto
+
+[ipv6]
+method=ignore
+
+`, keyfiles["bond0.100"])
+}
+
+func TestMakeKeyfilesNil(t *testing.T) {
+	var config *NetworkConfig
+	keyfiles, err := config.MakeKeyfiles()
+	assert.NoError(t, err)
+	assert.Empty(t, keyfiles)
+}
+
+func TestMakeNetworkData(t *testing.T) {
+	networkData := testNetworkConfig().MakeNetworkData()
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": "eno1", "type": "phy", "ethernet_mac_address": "ca:fe:ca:fe:00:00"},
+		map[string]interface{}{"id": "eno2", "type": "phy", "ethernet_mac_address": "ca:fe:ca:fe:00:01"},
+		map[string]interface{}{"id": "bond0", "type": "bond", "bond_mode": "active-backup", "bond_links": []string{"eno1", "eno2"}, "mtu": 9000},
+		map[string]interface{}{"id": "bond0.100", "type": "vlan", "vlan_link": "bond0", "vlan_id": 100},
+	}, networkData["links"])
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"id":         "network0",
+			"type":       "ipv4",
+			"link":       "bond0",
+			"ip_address": "192.168.111.20",
+			"netmask":    "255.255.255.0",
+			"routes": []interface{}{
+				map[string]interface{}{"network": "0.0.0.0", "netmask": "0.0.0.0", "gateway": "192.168.111.1"},
+				map[string]interface{}{"network": "10.0.0.0", "netmask": "255.0.0.0", "gateway": "192.168.111.254"},
+			},
+		},
+		map[string]interface{}{"id": "network1", "type": "ipv4_dhcp", "link": "bond0.100"},
+	}, networkData["networks"])
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "dns", "address": "192.168.111.1"},
+	}, networkData["services"])
+}
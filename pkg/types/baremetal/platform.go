@@ -2,14 +2,16 @@ package baremetal
 
 import (
 	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types/secret"
 )
 
 // BMC stores the information about a baremetal host's management controller.
 type BMC struct {
-	Username                       string `json:"username" validate:"required"`
-	Password                       string `json:"password" validate:"required"`
-	Address                        string `json:"address" validate:"required,uniqueField"`
-	DisableCertificateVerification bool   `json:"disableCertificateVerification"`
+	Username                       string            `json:"username" validate:"required"`
+	Password                       string            `json:"password,omitempty" validate:"required"`
+	PasswordRef                    *secret.Reference `json:"passwordRef,omitempty"`
+	Address                        string            `json:"address" validate:"required,uniqueField"`
+	DisableCertificateVerification bool              `json:"disableCertificateVerification"`
 }
 
 // BootMode puts the server in legacy (BIOS) or UEFI mode for
@@ -32,6 +34,7 @@ type Host struct {
 	HardwareProfile string           `json:"hardwareProfile"`
 	RootDeviceHints *RootDeviceHints `json:"rootDeviceHints,omitempty"`
 	BootMode        BootMode         `json:"bootMode,omitempty"`
+	NetworkConfig   *NetworkConfig   `json:"networkConfig,omitempty"`
 }
 
 // ProvisioningNetwork determines how we will use the provisioning network.
@@ -96,6 +99,12 @@ type Platform struct {
 	// +optional
 	ExternalMACAddress string `json:"externalMACAddress,omitempty"`
 
+	// BootstrapNetworkConfig is the static network configuration of the
+	// bootstrap VM's interfaces, for external networks without DHCP. The
+	// external interface of the bootstrap VM is ens3.
+	// +optional
+	BootstrapNetworkConfig *NetworkConfig `json:"bootstrapNetworkConfig,omitempty"`
+
 	// ProvisioningNetwork is used to indicate if we will have a provisioning network, and how it will be managed.
 	// +kubebuilder:default=Managed
 	// +optional
@@ -0,0 +1,190 @@
+package validation
+
+import (
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/validate"
+)
+
+// validateNetworkConfig checks that a static network configuration is
+// consistent and that it puts the host on one of the machine networks.
+func validateNetworkConfig(c *baremetal.NetworkConfig, n *types.Networking, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(c.Interfaces) == 0 {
+		return append(allErrs, field.Required(fldPath.Child("interfaces"), "at least one interface must be configured"))
+	}
+
+	ifaceTypes := map[string]baremetal.NetworkInterfaceType{}
+	for idx, iface := range c.Interfaces {
+		ifacePath := fldPath.Child("interfaces").Index(idx)
+		if iface.Name == "" {
+			allErrs = append(allErrs, field.Required(ifacePath.Child("name"), "missing name"))
+			continue
+		}
+		if _, ok := ifaceTypes[iface.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(ifacePath.Child("name"), iface.Name))
+		}
+		ifaceTypes[iface.Name] = iface.Type
+	}
+
+	inMachineNetwork := false
+	for idx, iface := range c.Interfaces {
+		ifacePath := fldPath.Child("interfaces").Index(idx)
+
+		switch iface.Type {
+		case baremetal.EthernetInterface:
+		case baremetal.BondInterface:
+			if iface.LinkAggregation == nil {
+				allErrs = append(allErrs, field.Required(ifacePath.Child("link-aggregation"), "bond interfaces must set link-aggregation"))
+				break
+			}
+			if iface.LinkAggregation.Mode == "" {
+				allErrs = append(allErrs, field.Required(ifacePath.Child("link-aggregation", "mode"), "missing bonding mode"))
+			}
+			if len(iface.LinkAggregation.Slaves) == 0 {
+				allErrs = append(allErrs, field.Required(ifacePath.Child("link-aggregation", "slaves"), "bond interfaces must have at least one slave"))
+			}
+			for i, slave := range iface.LinkAggregation.Slaves {
+				if t, ok := ifaceTypes[slave]; !ok || t != baremetal.EthernetInterface {
+					allErrs = append(allErrs, field.Invalid(ifacePath.Child("link-aggregation", "slaves").Index(i), slave, "must be the name of an ethernet interface"))
+				}
+			}
+		case baremetal.VLANInterface:
+			if iface.VLAN == nil {
+				allErrs = append(allErrs, field.Required(ifacePath.Child("vlan"), "vlan interfaces must set vlan"))
+				break
+			}
+			if _, ok := ifaceTypes[iface.VLAN.BaseIface]; !ok {
+				allErrs = append(allErrs, field.Invalid(ifacePath.Child("vlan", "base-iface"), iface.VLAN.BaseIface, "must be the name of an interface"))
+			}
+			if iface.VLAN.ID < 1 || iface.VLAN.ID > 4094 {
+				allErrs = append(allErrs, field.Invalid(ifacePath.Child("vlan", "id"), iface.VLAN.ID, "must be between 1 and 4094"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(ifacePath.Child("type"), iface.Type, []string{string(baremetal.EthernetInterface), string(baremetal.BondInterface), string(baremetal.VLANInterface)}))
+		}
+
+		switch iface.State {
+		case "", "up", "down":
+		default:
+			allErrs = append(allErrs, field.NotSupported(ifacePath.Child("state"), iface.State, []string{"up", "down"}))
+		}
+
+		if iface.MACAddress != "" {
+			if err := validate.MAC(iface.MACAddress); err != nil {
+				allErrs = append(allErrs, field.Invalid(ifacePath.Child("mac-address"), iface.MACAddress, err.Error()))
+			}
+		}
+
+		for _, family := range []struct {
+			name string
+			ip   *baremetal.InterfaceIP
+		}{{"ipv4", iface.IPv4}, {"ipv6", iface.IPv6}} {
+			if family.ip == nil || !family.ip.Enabled {
+				continue
+			}
+			if family.ip.DHCP {
+				// The address will come from DHCP on this interface.
+				inMachineNetwork = true
+				continue
+			}
+			for i, address := range family.ip.Address {
+				addressPath := ifacePath.Child(family.name, "address").Index(i)
+				ip := net.ParseIP(address.IP)
+				if ip == nil || (ip.To4() == nil) != (family.name == "ipv6") {
+					allErrs = append(allErrs, field.Invalid(addressPath.Child("ip"), address.IP, fmt.Sprintf("must be an %s address", family.name)))
+					continue
+				}
+				bits := 32
+				if family.name == "ipv6" {
+					bits = 128
+				}
+				if address.PrefixLength < 1 || address.PrefixLength > bits {
+					allErrs = append(allErrs, field.Invalid(addressPath.Child("prefix-length"), address.PrefixLength, fmt.Sprintf("must be between 1 and %d", bits)))
+					continue
+				}
+				for _, network := range n.MachineNetwork {
+					if !network.CIDR.Contains(ip) {
+						continue
+					}
+					inMachineNetwork = true
+					if ones, _ := network.CIDR.Mask.Size(); ones != address.PrefixLength {
+						allErrs = append(allErrs, field.Invalid(addressPath.Child("prefix-length"), address.PrefixLength, fmt.Sprintf("does not match the prefix length of machine network %s", network.CIDR.String())))
+					}
+				}
+			}
+		}
+	}
+
+	if !inMachineNetwork && len(allErrs) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("interfaces"), len(c.Interfaces), "no static address is in one of the machine networks and DHCP is not enabled on any interface"))
+	}
+
+	if c.Routes != nil {
+		for idx, route := range c.Routes.Config {
+			routePath := fldPath.Child("routes", "config").Index(idx)
+			destination, _, err := net.ParseCIDR(route.Destination)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(routePath.Child("destination"), route.Destination, err.Error()))
+			}
+			nextHop := net.ParseIP(route.NextHopAddress)
+			if nextHop == nil {
+				allErrs = append(allErrs, field.Invalid(routePath.Child("next-hop-address"), route.NextHopAddress, "must be an IP address"))
+			} else if destination != nil && (destination.To4() == nil) != (nextHop.To4() == nil) {
+				allErrs = append(allErrs, field.Invalid(routePath.Child("next-hop-address"), route.NextHopAddress, "must be of the same IP family as the destination"))
+			}
+			if _, ok := ifaceTypes[route.NextHopInterface]; !ok {
+				allErrs = append(allErrs, field.Invalid(routePath.Child("next-hop-interface"), route.NextHopInterface, "must be the name of an interface"))
+			}
+		}
+	}
+
+	if c.DNSResolver != nil {
+		for idx, server := range c.DNSResolver.Config.Server {
+			if err := validate.IP(server); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("dns-resolver", "config", "server").Index(idx), server, err.Error()))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateNetworkConfigs validates the static network configuration of
+// the hosts and of the bootstrap VM, and ensures that no static address is
+// used twice or collides with one of the VIPs.
+func validateNetworkConfigs(p *baremetal.Platform, n *types.Networking, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	used := sets.NewString(p.APIVIP, p.IngressVIP)
+	check := func(c *baremetal.NetworkConfig, path *field.Path) {
+		if c == nil {
+			return
+		}
+		errs := validateNetworkConfig(c, n, path)
+		allErrs = append(allErrs, errs...)
+		if len(errs) > 0 {
+			return
+		}
+		for _, ip := range c.Addresses() {
+			if used.Has(ip.String()) {
+				allErrs = append(allErrs, field.Duplicate(path, ip.String()))
+			}
+			used.Insert(ip.String())
+		}
+	}
+
+	check(p.BootstrapNetworkConfig, fldPath.Child("bootstrapNetworkConfig"))
+	for idx, host := range p.Hosts {
+		check(host.NetworkConfig, fldPath.Child("hosts").Index(idx).Child("networkConfig"))
+	}
+
+	return allErrs
+}
@@ -347,6 +347,8 @@ func ValidatePlatform(p *baremetal.Platform, n *types.Networking, fldPath *field
 
 	allErrs = append(allErrs, validateBootMode(p.Hosts, fldPath.Child("Hosts"))...)
 
+	allErrs = append(allErrs, validateNetworkConfigs(p, n, fldPath)...)
+
 	return allErrs
 }
 
@@ -128,6 +128,64 @@ func TestValidatePlatform(t *testing.T) {
 				Hosts(host1().BootMode("legacy")).build(),
 			expected: "",
 		},
+		{
+			name: "valid_network_config",
+			platform: platform().
+				Hosts(host1().NetworkConfig(staticNetworkConfig("192.168.111.20", 24))).
+				BootstrapNetworkConfig(staticNetworkConfig("192.168.111.10", 24)).build(),
+		},
+		{
+			name: "network_config_outside_machine_network",
+			platform: platform().
+				Hosts(host1().NetworkConfig(staticNetworkConfig("192.168.222.20", 24))).build(),
+			expected: "baremetal.hosts\\[0\\].networkConfig.interfaces: Invalid value: 1: no static address is in one of the machine networks",
+		},
+		{
+			name: "network_config_prefix_mismatch",
+			platform: platform().
+				Hosts(host1().NetworkConfig(staticNetworkConfig("192.168.111.20", 16))).build(),
+			expected: "baremetal.hosts\\[0\\].networkConfig.interfaces\\[0\\].ipv4.address\\[0\\].prefix-length: Invalid value: 16: does not match the prefix length of machine network 192.168.111.0/24",
+		},
+		{
+			name: "network_config_duplicate_address",
+			platform: platform().
+				Hosts(
+					host1().NetworkConfig(staticNetworkConfig("192.168.111.20", 24)),
+					host2().NetworkConfig(staticNetworkConfig("192.168.111.20", 24))).build(),
+			expected: "baremetal.hosts\\[1\\].networkConfig: Duplicate value: \"192.168.111.20\"",
+		},
+		{
+			name: "network_config_vip_address",
+			platform: platform().
+				BootstrapNetworkConfig(staticNetworkConfig("192.168.111.2", 24)).build(),
+			expected: "baremetal.bootstrapNetworkConfig: Duplicate value: \"192.168.111.2\"",
+		},
+		{
+			name: "network_config_unknown_route_interface",
+			platform: platform().
+				Hosts(host1().NetworkConfig(func() *baremetal.NetworkConfig {
+					c := staticNetworkConfig("192.168.111.20", 24)
+					c.Routes.Config[0].NextHopInterface = "eno2"
+					return c
+				}())).build(),
+			expected: "baremetal.hosts\\[0\\].networkConfig.routes.config\\[0\\].next-hop-interface: Invalid value: \"eno2\": must be the name of an interface",
+		},
+		{
+			name: "network_config_bond_unknown_slave",
+			platform: platform().
+				Hosts(host1().NetworkConfig(&baremetal.NetworkConfig{
+					Interfaces: []baremetal.NetworkInterface{{
+						Name: "bond0",
+						Type: baremetal.BondInterface,
+						LinkAggregation: &baremetal.LinkAggregation{
+							Mode:   "active-backup",
+							Slaves: []string{"eno1"},
+						},
+						IPv4: &baremetal.InterfaceIP{Enabled: true, DHCP: true},
+					}},
+				})).build(),
+			expected: "baremetal.hosts\\[0\\].networkConfig.interfaces\\[0\\].link-aggregation.slaves\\[0\\]: Invalid value: \"eno1\": must be the name of an ethernet interface",
+		},
 		{
 			name:     "provisioningNetwork_disabled_valid",
 			platform: platform().ProvisioningNetwork(baremetal.DisabledProvisioningNetwork).build(),
@@ -503,6 +561,11 @@ func (hb *hostBuilder) BootMode(value string) *hostBuilder {
 	return hb
 }
 
+func (hb *hostBuilder) NetworkConfig(value *baremetal.NetworkConfig) *hostBuilder {
+	hb.Host.NetworkConfig = value
+	return hb
+}
+
 func (hb *hostBuilder) BMCAddress(value string) *hostBuilder {
 	hb.Host.BMC.Address = value
 	return hb
@@ -596,6 +659,11 @@ func (pb *platformBuilder) Hosts(builders ...*hostBuilder) *platformBuilder {
 	return pb
 }
 
+func (pb *platformBuilder) BootstrapNetworkConfig(value *baremetal.NetworkConfig) *platformBuilder {
+	pb.Platform.BootstrapNetworkConfig = value
+	return pb
+}
+
 func (pb *platformBuilder) LibvirtURI(value string) *platformBuilder {
 	pb.Platform.LibvirtURI = value
 	return pb
@@ -626,6 +694,29 @@ func (pb *platformBuilder) ProvisioningNetworkInterface(value string) *platformB
 	return pb
 }
 
+func staticNetworkConfig(ip string, prefixLength int) *baremetal.NetworkConfig {
+	return &baremetal.NetworkConfig{
+		Interfaces: []baremetal.NetworkInterface{{
+			Name: "eno1",
+			Type: baremetal.EthernetInterface,
+			IPv4: &baremetal.InterfaceIP{
+				Enabled: true,
+				Address: []baremetal.InterfaceAddress{{IP: ip, PrefixLength: prefixLength}},
+			},
+		}},
+		Routes: &baremetal.NetworkRoutes{
+			Config: []baremetal.NetworkRoute{{
+				Destination:      "0.0.0.0/0",
+				NextHopAddress:   "192.168.111.1",
+				NextHopInterface: "eno1",
+			}},
+		},
+		DNSResolver: &baremetal.DNSResolver{
+			Config: baremetal.DNSResolverConfig{Server: []string{"192.168.111.1"}},
+		},
+	}
+}
+
 func network() *types.Networking {
 	return &types.Networking{MachineNetwork: []types.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR("192.168.111.0/24")}}}
 }
@@ -0,0 +1,3 @@
+// Package secret contains references to secrets that are kept outside of the
+// installer configuration, and the resolvers that read them.
+package secret
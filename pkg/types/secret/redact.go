@@ -0,0 +1,109 @@
+package secret
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// The encodings of a secret that Redact finds in generated data: as it is
+// written in a JSON string by encoding/json, which escapes HTML characters, and
+// by other encoders, which do not (this is also how most secrets are written
+// in YAML), and in base64, as in the data of a Kubernetes Secret.
+const (
+	encodingJSON   = "json"
+	encodingText   = "text"
+	encodingBase64 = "base64"
+)
+
+var (
+	resolvedMu sync.Mutex
+	// resolved holds the secrets that Resolve has returned, by reference.
+	resolved = map[Reference]string{}
+
+	placeholderRegexp = regexp.MustCompile(`\$\{secret:(json|text|base64):([A-Za-z0-9_-]*)\}`)
+)
+
+func record(ref Reference, secret string) {
+	resolvedMu.Lock()
+	defer resolvedMu.Unlock()
+	resolved[ref] = secret
+}
+
+// Redact returns a copy of data in which the secrets that Resolve has
+// returned are replaced by placeholders that name their references, so that
+// data can be saved without them. Restore puts the secrets back.
+func Redact(data []byte) []byte {
+	resolvedMu.Lock()
+	defer resolvedMu.Unlock()
+
+	var oldnew []string
+	for ref, secret := range resolved {
+		if secret == "" {
+			continue
+		}
+		refJSON, err := json.Marshal(ref)
+		if err != nil {
+			continue
+		}
+		name := base64.RawURLEncoding.EncodeToString(refJSON)
+		for _, encoding := range []string{encodingJSON, encodingText, encodingBase64} {
+			oldnew = append(oldnew, encode(secret, encoding), fmt.Sprintf("${secret:%s:%s}", encoding, name))
+		}
+	}
+	if len(oldnew) == 0 {
+		return data
+	}
+	return []byte(strings.NewReplacer(oldnew...).Replace(string(data)))
+}
+
+// Restore returns a copy of data in which the placeholders left by Redact
+// are replaced by the secrets that they refer to, which are resolved again.
+func Restore(data []byte) ([]byte, error) {
+	var restoreErr error
+	restored := placeholderRegexp.ReplaceAllFunc(data, func(placeholder []byte) []byte {
+		match := placeholderRegexp.FindSubmatch(placeholder)
+		var ref Reference
+		refJSON, err := base64.RawURLEncoding.DecodeString(string(match[2]))
+		if err == nil {
+			err = json.Unmarshal(refJSON, &ref)
+		}
+		if err != nil {
+			restoreErr = errors.Errorf("invalid secret placeholder %s", placeholder)
+			return placeholder
+		}
+		secret, err := Resolve(&ref)
+		if err != nil {
+			restoreErr = errors.Wrapf(err, "failed to resolve secret %s", refJSON)
+			return placeholder
+		}
+		return []byte(encode(secret, string(match[1])))
+	})
+	if restoreErr != nil {
+		return nil, restoreErr
+	}
+	return restored, nil
+}
+
+func encode(secret string, encoding string) string {
+	switch encoding {
+	case encodingBase64:
+		return base64.StdEncoding.EncodeToString([]byte(secret))
+	case encodingText:
+		buf := &bytes.Buffer{}
+		encoder := json.NewEncoder(buf)
+		encoder.SetEscapeHTML(false)
+		encoder.Encode(secret)
+		data := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+		return string(data[1 : len(data)-1])
+	default:
+		data, _ := json.Marshal(secret)
+		return string(data[1 : len(data)-1])
+	}
+}
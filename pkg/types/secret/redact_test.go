@@ -0,0 +1,46 @@
+package secret
+
+import (
+	"encoding/base64"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactRestore(t *testing.T) {
+	saved := resolved
+	resolved = map[Reference]string{}
+	defer func() { resolved = saved }()
+	os.Setenv("SECRET_TEST_PASSWORD", `pa"ss<word>`)
+	defer os.Unsetenv("SECRET_TEST_PASSWORD")
+
+	data := []byte(`{"vsphere_password": "pa\"ss\u003cword\u003e"}
+password: "pa\"ss<word>"
+data: ` + base64.StdEncoding.EncodeToString([]byte(`pa"ss<word>`)) + "\n")
+
+	// Nothing is redacted before the secret is resolved.
+	assert.Equal(t, data, Redact(data))
+
+	_, err := Resolve(&Reference{Env: "SECRET_TEST_PASSWORD"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	redacted := Redact(data)
+	assert.Equal(t, `{"vsphere_password": "${secret:json:eyJlbnYiOiJTRUNSRVRfVEVTVF9QQVNTV09SRCJ9}"}
+password: "${secret:text:eyJlbnYiOiJTRUNSRVRfVEVTVF9QQVNTV09SRCJ9}"
+data: ${secret:base64:eyJlbnYiOiJTRUNSRVRfVEVTVF9QQVNTV09SRCJ9}
+`, string(redacted))
+
+	restored, err := Restore(redacted)
+	if assert.NoError(t, err) {
+		assert.Equal(t, data, restored)
+	}
+
+	os.Unsetenv("SECRET_TEST_PASSWORD")
+	_, err = Restore(redacted)
+	assert.EqualError(t, err, `failed to resolve secret {"env":"SECRET_TEST_PASSWORD"}: environment variable SECRET_TEST_PASSWORD is not set`)
+
+	_, err = Restore([]byte("${secret:json:e30x}"))
+	assert.EqualError(t, err, "invalid secret placeholder ${secret:json:e30x}")
+}
@@ -0,0 +1,88 @@
+package secret
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Reference refers to a secret, such as a password, that is kept outside of
+// the installer configuration. Exactly one of its fields must be set.
+type Reference struct {
+	// File is the path of a file that contains the secret. A trailing
+	// newline is not part of the secret.
+	// +optional
+	File string `json:"file,omitempty" yaml:"file,omitempty"`
+
+	// Env is the name of an environment variable that contains the secret.
+	// +optional
+	Env string `json:"env,omitempty" yaml:"env,omitempty"`
+}
+
+// Resolver reads the secrets of one kind of reference.
+type Resolver interface {
+	// Resolve returns the secret that ref refers to. The second return is
+	// false if ref is not of the kind of the resolver.
+	Resolve(ref *Reference) (string, bool, error)
+}
+
+var resolvers = []Resolver{fileResolver{}, envResolver{}}
+
+// RegisterResolver adds a resolver for a new kind of reference.
+func RegisterResolver(r Resolver) {
+	resolvers = append(resolvers, r)
+}
+
+// Resolve returns the secret that ref refers to. The secret is remembered, so
+// that Redact can find it in generated data.
+func Resolve(ref *Reference) (string, error) {
+	var secret string
+	found := 0
+	for _, r := range resolvers {
+		s, ok, err := r.Resolve(ref)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			secret = s
+			found++
+		}
+	}
+	switch found {
+	case 0:
+		return "", errors.New("no source is set for the secret")
+	case 1:
+		record(*ref, secret)
+		return secret, nil
+	default:
+		return "", errors.New("only one source may be set for the secret")
+	}
+}
+
+type fileResolver struct{}
+
+func (fileResolver) Resolve(ref *Reference) (string, bool, error) {
+	if ref.File == "" {
+		return "", false, nil
+	}
+	data, err := ioutil.ReadFile(ref.File)
+	if err != nil {
+		return "", true, errors.Wrap(err, "failed to read the secret")
+	}
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+type envResolver struct{}
+
+func (envResolver) Resolve(ref *Reference) (string, bool, error) {
+	if ref.Env == "" {
+		return "", false, nil
+	}
+	value, ok := os.LookupEnv(ref.Env)
+	if !ok {
+		return "", true, errors.Errorf("environment variable %s is not set", ref.Env)
+	}
+	return value, true, nil
+}
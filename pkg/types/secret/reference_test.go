@@ -0,0 +1,91 @@
+package secret
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type staticResolver struct{}
+
+func (staticResolver) Resolve(ref *Reference) (string, bool, error) {
+	if ref.File != "" || ref.Env != "" {
+		return "", false, nil
+	}
+	return "static-secret", true, nil
+}
+
+func TestResolve(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secret")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "password")
+	if !assert.NoError(t, ioutil.WriteFile(file, []byte("file-secret\n"), 0600)) {
+		return
+	}
+	os.Setenv("SECRET_TEST_PASSWORD", "env-secret")
+	defer os.Unsetenv("SECRET_TEST_PASSWORD")
+
+	cases := []struct {
+		name     string
+		ref      Reference
+		expected string
+		err      string
+	}{
+		{
+			name:     "file",
+			ref:      Reference{File: file},
+			expected: "file-secret",
+		},
+		{
+			name: "missing file",
+			ref:  Reference{File: filepath.Join(dir, "missing")},
+			err:  "^failed to read the secret: open .*/missing: no such file or directory$",
+		},
+		{
+			name:     "env",
+			ref:      Reference{Env: "SECRET_TEST_PASSWORD"},
+			expected: "env-secret",
+		},
+		{
+			name: "unset env",
+			ref:  Reference{Env: "SECRET_TEST_UNSET"},
+			err:  "^environment variable SECRET_TEST_UNSET is not set$",
+		},
+		{
+			name: "empty",
+			err:  "^no source is set for the secret$",
+		},
+		{
+			name: "file and env",
+			ref:  Reference{File: file, Env: "SECRET_TEST_PASSWORD"},
+			err:  "^only one source may be set for the secret$",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			secret, err := Resolve(&tc.ref)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, secret)
+			} else {
+				assert.Regexp(t, tc.err, err)
+			}
+		})
+	}
+}
+
+func TestRegisterResolver(t *testing.T) {
+	saved := resolvers
+	defer func() { resolvers = saved }()
+
+	RegisterResolver(staticResolver{})
+	secret, err := Resolve(&Reference{})
+	assert.NoError(t, err)
+	assert.Equal(t, "static-secret", secret)
+}
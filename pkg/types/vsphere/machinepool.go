@@ -23,6 +23,13 @@ type MachinePool struct {
 	//
 	// +optional
 	OSDisk `json:"osDisk"`
+
+	// Zones are the names of the failure domains the machines of the pool
+	// are spread across. The default is all the failure domains of the
+	// platform.
+	//
+	// +optional
+	Zones []string `json:"zones,omitempty"`
 }
 
 // OSDisk defines the disk for a virtual machine.
@@ -54,4 +61,8 @@ func (p *MachinePool) Set(required *MachinePool) {
 	if required.OSDisk.DiskSizeGB != 0 {
 		p.OSDisk.DiskSizeGB = required.OSDisk.DiskSizeGB
 	}
+
+	if len(required.Zones) > 0 {
+		p.Zones = required.Zones
+	}
 }
@@ -1,5 +1,9 @@
 package vsphere
 
+import (
+	"github.com/openshift/installer/pkg/types/secret"
+)
+
 // Platform stores any global configuration used for vsphere platforms.
 type Platform struct {
 	// VCenter is the domain name or IP address of the vCenter.
@@ -9,7 +13,13 @@ type Platform struct {
 	Username string `json:"username"`
 
 	// Password is the password for the user to use to connect to the vCenter.
-	Password string `json:"password"`
+	// +optional
+	Password string `json:"password,omitempty"`
+
+	// PasswordRef refers to the password when it is kept outside of the
+	// install-config. It replaces Password.
+	// +optional
+	PasswordRef *secret.Reference `json:"passwordRef,omitempty"`
 
 	// Datacenter is the name of the datacenter to use in the vCenter.
 	Datacenter string `json:"datacenter"`
@@ -47,4 +57,45 @@ type Platform struct {
 
 	// Network specifies the name of the network to be used by the cluster.
 	Network string `json:"network,omitempty"`
+
+	// FailureDomains are the vSphere locations that the machines of the
+	// cluster are spread across. Machine pools reference failure domains by
+	// name in their zones. When unset, all machines are created in the
+	// datacenter, cluster, datastore and network above.
+	// +optional
+	FailureDomains []FailureDomain `json:"failureDomains,omitempty"`
+
+	// StaticIPs configures static IP addresses for the bootstrap, control
+	// plane and compute machines. When unset, the machines get their
+	// addresses from DHCP.
+	// +optional
+	StaticIPs *StaticIPConfig `json:"staticIPs,omitempty"`
+}
+
+// FailureDomain is a vSphere location, such as a vSphere cluster with its
+// own datastore, that machines can be placed in.
+type FailureDomain struct {
+	// Name is the name of the failure domain, used as the zone of the
+	// machines placed in it.
+	Name string `json:"name"`
+
+	// Datacenter is the name of the datacenter of the failure domain. It
+	// must be the datacenter of the platform, which the RHCOS template is
+	// imported into.
+	Datacenter string `json:"datacenter"`
+
+	// Cluster is the name of the cluster virtual machines will be cloned into.
+	Cluster string `json:"cluster"`
+
+	// Datastore is the name of the datastore for the disks of the virtual machines.
+	Datastore string `json:"datastore"`
+
+	// Network is the name of the network of the virtual machines.
+	Network string `json:"network"`
+
+	// Folder is the absolute path of an existing folder for the virtual
+	// machines, of the form /<datacenter>/vm/<folder>/<subfolder>. It
+	// defaults to the folder of the platform.
+	// +optional
+	Folder string `json:"folder,omitempty"`
 }
@@ -0,0 +1,107 @@
+package vsphere
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// StaticIPConfig configures static IPv4 addresses for the machines that
+// the installer creates, for networks without DHCP.
+type StaticIPConfig struct {
+	// Gateway is the IP address of the default gateway of the machines.
+	Gateway string `json:"gateway"`
+
+	// Netmask is the netmask of the network of the machines, in dotted
+	// decimal notation.
+	Netmask string `json:"netmask"`
+
+	// Nameservers are the IP addresses of the DNS servers of the machines.
+	// +optional
+	Nameservers []string `json:"nameservers,omitempty"`
+
+	// Interface is the name of the network interface which the addresses
+	// are configured on. When unset, the addresses are configured on
+	// whichever interface comes up first.
+	// +optional
+	Interface string `json:"interface,omitempty"`
+
+	// Bootstrap is the IP address of the bootstrap machine.
+	Bootstrap string `json:"bootstrap"`
+
+	// ControlPlane is the pool of IP addresses of the control plane
+	// machines.
+	ControlPlane IPPool `json:"controlPlane"`
+
+	// Compute is the pool of IP addresses of the compute machines. It must
+	// have an address for each compute replica.
+	// +optional
+	Compute IPPool `json:"compute,omitempty"`
+}
+
+// IPPool is a set of IP addresses that are assigned to machines in order.
+// Either a range or a list of addresses must be set.
+type IPPool struct {
+	// Start is the first IP address of a range of addresses.
+	// +optional
+	Start string `json:"start,omitempty"`
+
+	// End is the last IP address of a range of addresses.
+	// +optional
+	End string `json:"end,omitempty"`
+
+	// Addresses is the list of the IP addresses of the machines, by
+	// machine index.
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// Size returns the number of IP addresses in the pool.
+func (p *IPPool) Size() int {
+	if len(p.Addresses) > 0 {
+		return len(p.Addresses)
+	}
+	start, end := ipv4ToUint32(p.Start), ipv4ToUint32(p.End)
+	if start == 0 || end < start {
+		return 0
+	}
+	return int(end-start) + 1
+}
+
+// IPs returns the first count IP addresses of the pool.
+func (p *IPPool) IPs(count int) ([]string, error) {
+	if size := p.Size(); count > size {
+		return nil, fmt.Errorf("the pool has %d addresses but %d are needed", size, count)
+	}
+	if len(p.Addresses) > 0 {
+		return p.Addresses[:count], nil
+	}
+	start := ipv4ToUint32(p.Start)
+	ips := make([]string, count)
+	for i := range ips {
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, start+uint32(i))
+		ips[i] = ip.String()
+	}
+	return ips, nil
+}
+
+// KernelArgs returns the kernel arguments, in dracut syntax, that configure
+// the network of the machine with the IP address ip and the host name
+// hostname.
+func (c *StaticIPConfig) KernelArgs(ip, hostname string) string {
+	args := []string{fmt.Sprintf("ip=%s::%s:%s:%s:%s:none", ip, c.Gateway, c.Netmask, hostname, c.Interface)}
+	for _, ns := range c.Nameservers {
+		args = append(args, fmt.Sprintf("nameserver=%s", ns))
+	}
+	return strings.Join(args, " ")
+}
+
+func ipv4ToUint32(s string) uint32 {
+	ip := net.ParseIP(s).To4()
+	if ip == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(ip)
+}
@@ -0,0 +1,44 @@
+package vsphere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPPoolRange(t *testing.T) {
+	pool := &IPPool{Start: "192.168.1.254", End: "192.168.2.1"}
+	assert.Equal(t, 4, pool.Size())
+
+	ips, err := pool.IPs(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.168.1.254", "192.168.1.255", "192.168.2.0"}, ips)
+
+	_, err = pool.IPs(5)
+	assert.EqualError(t, err, "the pool has 4 addresses but 5 are needed")
+}
+
+func TestIPPoolAddresses(t *testing.T) {
+	pool := &IPPool{Addresses: []string{"192.168.1.10", "192.168.1.5"}}
+	assert.Equal(t, 2, pool.Size())
+
+	ips, err := pool.IPs(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.168.1.10", "192.168.1.5"}, ips)
+}
+
+func TestKernelArgs(t *testing.T) {
+	config := &StaticIPConfig{
+		Gateway:     "192.168.1.1",
+		Netmask:     "255.255.255.0",
+		Nameservers: []string{"192.168.1.2", "192.168.1.3"},
+	}
+	assert.Equal(t,
+		"ip=192.168.1.10::192.168.1.1:255.255.255.0:cluster-master-0::none nameserver=192.168.1.2 nameserver=192.168.1.3",
+		config.KernelArgs("192.168.1.10", "cluster-master-0"))
+
+	config.Interface = "ens192"
+	assert.Equal(t,
+		"ip=192.168.1.10::192.168.1.1:255.255.255.0:cluster-master-0:ens192:none nameserver=192.168.1.2 nameserver=192.168.1.3",
+		config.KernelArgs("192.168.1.10", "cluster-master-0"))
+}
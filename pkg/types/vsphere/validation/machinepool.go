@@ -1,14 +1,26 @@
 package validation
 
 import (
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types/vsphere"
 )
 
 // ValidateMachinePool checks that the specified machine pool is valid.
-func ValidateMachinePool(p *vsphere.MachinePool, fldPath *field.Path) field.ErrorList {
+func ValidateMachinePool(platform *vsphere.Platform, p *vsphere.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
+	if len(p.Zones) > 0 {
+		domains := sets.NewString()
+		for _, fd := range platform.FailureDomains {
+			domains.Insert(fd.Name)
+		}
+		for i, zone := range p.Zones {
+			if !domains.Has(zone) {
+				allErrs = append(allErrs, field.NotSupported(fldPath.Child("zones").Index(i), zone, domains.List()))
+			}
+		}
+	}
 	if p.DiskSizeGB < 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("diskSizeGB"), p.DiskSizeGB, "storage disk size must be positive"))
 	}
@@ -45,11 +45,24 @@ func TestValidateMachinePool(t *testing.T) {
 				MemoryMiB: -1,
 			},
 			expectedErrMsg: `^test-path\.memoryMB: Invalid value: -1: memory size must be positive$`,
+		}, {
+			name: "known zone",
+			pool: &vsphere.MachinePool{
+				Zones: []string{"a"},
+			},
+		}, {
+			name: "unknown zone",
+			pool: &vsphere.MachinePool{
+				Zones: []string{"a", "c"},
+			},
+			expectedErrMsg: `^test-path\.zones\[1\]: Unsupported value: "c": supported values: "a", "b"$`,
 		},
 	}
+	platform := validPlatform()
+	platform.FailureDomains = []vsphere.FailureDomain{validFailureDomain("a"), validFailureDomain("b")}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := ValidateMachinePool(tc.pool, field.NewPath("test-path")).ToAggregate()
+			err := ValidateMachinePool(platform, tc.pool, field.NewPath("test-path")).ToAggregate()
 			if tc.expectedErrMsg == "" {
 				assert.NoError(t, err)
 			} else {
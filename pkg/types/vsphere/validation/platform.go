@@ -2,16 +2,19 @@ package validation
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/vsphere"
 	"github.com/openshift/installer/pkg/validate"
 )
 
 // ValidatePlatform checks that the specified platform is valid.
-func ValidatePlatform(p *vsphere.Platform, fldPath *field.Path) field.ErrorList {
+func ValidatePlatform(p *vsphere.Platform, n *types.Networking, fldPath *field.Path, c *types.InstallConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if len(p.VCenter) == 0 {
 		allErrs = append(allErrs, field.Required(fldPath.Child("vCenter"), "must specify the name of the vCenter"))
@@ -43,6 +46,12 @@ func ValidatePlatform(p *vsphere.Platform, fldPath *field.Path) field.ErrorList
 		allErrs = append(allErrs, validateFolder(p, fldPath)...)
 	}
 
+	allErrs = append(allErrs, validateFailureDomains(p, fldPath.Child("failureDomains"))...)
+
+	if p.StaticIPs != nil {
+		allErrs = append(allErrs, validateStaticIPs(p, n, fldPath.Child("staticIPs"), c)...)
+	}
+
 	return allErrs
 }
 
@@ -87,18 +96,179 @@ func validateVIPs(p *vsphere.Platform, fldPath *field.Path) field.ErrorList {
 
 // validateFolder checks that a provided folder is in absolute path in the correct datacenter.
 func validateFolder(p *vsphere.Platform, fldPath *field.Path) field.ErrorList {
+	return validateFolderPath(p.Folder, p.Datacenter, fldPath.Child("folder"))
+}
+
+// validateFolderPath checks that a folder is an absolute path in the datacenter.
+func validateFolderPath(folder, datacenter string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	dc := p.Datacenter
+	dc := datacenter
 	if len(dc) == 0 {
 		dc = "<datacenter>"
 	}
 	expectedPrefix := fmt.Sprintf("/%s/vm/", dc)
 
-	if !strings.HasPrefix(p.Folder, expectedPrefix) {
+	if !strings.HasPrefix(folder, expectedPrefix) {
 		errMsg := fmt.Sprintf("folder must be absolute path: expected prefix %s", expectedPrefix)
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("folder"), p.Folder, errMsg))
+		allErrs = append(allErrs, field.Invalid(fldPath, folder, errMsg))
+	}
+
+	return allErrs
+}
+
+// validateFailureDomains checks that the failure domains are named uniquely
+// and fully describe a vSphere location.
+func validateFailureDomains(p *vsphere.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := map[string]bool{}
+	for i, fd := range p.FailureDomains {
+		fdPath := fldPath.Index(i)
+		if len(fd.Name) == 0 {
+			allErrs = append(allErrs, field.Required(fdPath.Child("name"), "must specify the name"))
+		} else if names[fd.Name] {
+			allErrs = append(allErrs, field.Duplicate(fdPath.Child("name"), fd.Name))
+		}
+		names[fd.Name] = true
+
+		// The RHCOS template is only imported into the platform's
+		// datacenter, so machines cannot be cloned in any other.
+		if len(fd.Datacenter) == 0 {
+			allErrs = append(allErrs, field.Required(fdPath.Child("datacenter"), "must specify the datacenter"))
+		} else if fd.Datacenter != p.Datacenter {
+			allErrs = append(allErrs, field.Invalid(fdPath.Child("datacenter"), fd.Datacenter, fmt.Sprintf("must be the platform datacenter %s", p.Datacenter)))
+		}
+		if len(fd.Cluster) == 0 {
+			allErrs = append(allErrs, field.Required(fdPath.Child("cluster"), "must specify the cluster"))
+		}
+		if len(fd.Datastore) == 0 {
+			allErrs = append(allErrs, field.Required(fdPath.Child("datastore"), "must specify the datastore"))
+		}
+		if len(fd.Network) == 0 {
+			allErrs = append(allErrs, field.Required(fdPath.Child("network"), "must specify the network"))
+		}
+
+		if len(fd.Folder) != 0 {
+			allErrs = append(allErrs, validateFolderPath(fd.Folder, p.Datacenter, fdPath.Child("folder"))...)
+		}
+	}
+
+	return allErrs
+}
+
+// validateStaticIPs checks that the static IP addresses are IPv4 addresses in
+// the machine network, that there are enough of them for the control plane and
+// compute machines, and that none of them is used twice or collides with a VIP
+// or the gateway.
+func validateStaticIPs(p *vsphere.Platform, n *types.Networking, fldPath *field.Path, c *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	config := p.StaticIPs
+
+	controlPlaneReplicas := 3
+	if c != nil && c.ControlPlane != nil && c.ControlPlane.Replicas != nil {
+		controlPlaneReplicas = int(*c.ControlPlane.Replicas)
+	}
+	computeReplicas := 0
+	if c != nil {
+		for _, pool := range c.Compute {
+			if pool.Replicas == nil {
+				computeReplicas += 3
+			} else {
+				computeReplicas += int(*pool.Replicas)
+			}
+		}
+	}
+
+	inMachineNetwork := func(ip string) bool {
+		for _, network := range n.MachineNetwork {
+			if network.CIDR.Contains(net.ParseIP(ip)) {
+				return true
+			}
+		}
+		return false
+	}
+	validateIPv4 := func(ip string, path *field.Path) bool {
+		if ip == "" {
+			allErrs = append(allErrs, field.Required(path, "must specify an IP address"))
+			return false
+		}
+		if parsed := net.ParseIP(ip); parsed == nil || parsed.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(path, ip, "must be an IPv4 address"))
+			return false
+		}
+		return true
+	}
+	validatePool := func(pool vsphere.IPPool, poolPath *field.Path) {
+		switch {
+		case len(pool.Addresses) > 0 && (pool.Start != "" || pool.End != ""):
+			allErrs = append(allErrs, field.Forbidden(poolPath.Child("addresses"), "must not be set together with a range"))
+		case len(pool.Addresses) > 0:
+			for i, ip := range pool.Addresses {
+				validateIPv4(ip, poolPath.Child("addresses").Index(i))
+			}
+		default:
+			if validateIPv4(pool.Start, poolPath.Child("start")) && validateIPv4(pool.End, poolPath.Child("end")) && pool.Size() == 0 {
+				allErrs = append(allErrs, field.Invalid(poolPath.Child("end"), pool.End, "must not be before the start of the range"))
+			}
+		}
+	}
+
+	if validateIPv4(config.Gateway, fldPath.Child("gateway")) && !inMachineNetwork(config.Gateway) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("gateway"), config.Gateway, "must be in one of the machine networks"))
+	}
+
+	if config.Netmask == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("netmask"), "must specify the netmask"))
+	} else if mask := net.ParseIP(config.Netmask).To4(); mask == nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("netmask"), config.Netmask, "must be an IPv4 netmask in dotted decimal notation"))
+	} else if ones, bits := net.IPMask(mask).Size(); ones == 0 && bits == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("netmask"), config.Netmask, "must be an IPv4 netmask in dotted decimal notation"))
+	}
+
+	for i, ns := range config.Nameservers {
+		validateIPv4(ns, fldPath.Child("nameservers").Index(i))
+	}
+
+	validatePool(config.ControlPlane, fldPath.Child("controlPlane"))
+	// The compute pool may be left empty when there are no compute machines.
+	if computeReplicas > 0 || len(config.Compute.Addresses) > 0 || config.Compute.Start != "" || config.Compute.End != "" {
+		validatePool(config.Compute, fldPath.Child("compute"))
+	}
+	validateIPv4(config.Bootstrap, fldPath.Child("bootstrap"))
+
+	// The remaining checks need valid addresses.
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	used := sets.NewString(p.APIVIP, p.IngressVIP, config.Gateway)
+	check := func(ip string, path *field.Path) {
+		if !inMachineNetwork(ip) {
+			allErrs = append(allErrs, field.Invalid(path, ip, "must be in one of the machine networks"))
+		}
+		if used.Has(ip) {
+			allErrs = append(allErrs, field.Duplicate(path, ip))
+		}
+		used.Insert(ip)
+	}
+	checkPool := func(pool vsphere.IPPool, poolPath *field.Path, replicas int, machines string) {
+		ips, err := pool.IPs(replicas)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(poolPath, pool.Size(), fmt.Sprintf("must have an address for each of the %d %s machines", replicas, machines)))
+			return
+		}
+		for i, ip := range ips {
+			if len(pool.Addresses) > 0 {
+				check(ip, poolPath.Child("addresses").Index(i))
+			} else {
+				check(ip, poolPath.Child("start"))
+			}
+		}
 	}
+	check(config.Bootstrap, fldPath.Child("bootstrap"))
+	checkPool(config.ControlPlane, fldPath.Child("controlPlane"), controlPlaneReplicas, "control plane")
+	checkPool(config.Compute, fldPath.Child("compute"), computeReplicas, "compute")
 
 	return allErrs
 }
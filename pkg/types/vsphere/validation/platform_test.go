@@ -5,7 +5,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
 
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/vsphere"
 )
 
@@ -19,10 +22,42 @@ func validPlatform() *vsphere.Platform {
 	}
 }
 
+func validFailureDomain(name string) vsphere.FailureDomain {
+	return vsphere.FailureDomain{
+		Name:       name,
+		Datacenter: "test-datacenter",
+		Cluster:    "test-cluster-" + name,
+		Datastore:  "test-datastore-" + name,
+		Network:    "test-network",
+	}
+}
+
+func validStaticIPs() *vsphere.StaticIPConfig {
+	return &vsphere.StaticIPConfig{
+		Gateway:     "192.168.111.1",
+		Netmask:     "255.255.255.0",
+		Nameservers: []string{"192.168.111.2"},
+		Bootstrap:   "192.168.111.10",
+		ControlPlane: vsphere.IPPool{
+			Start: "192.168.111.20",
+			End:   "192.168.111.29",
+		},
+	}
+}
+
+func validNetworking() *types.Networking {
+	return &types.Networking{
+		MachineNetwork: []types.MachineNetworkEntry{
+			{CIDR: *ipnet.MustParseCIDR("192.168.111.0/24")},
+		},
+	}
+}
+
 func TestValidatePlatform(t *testing.T) {
 	cases := []struct {
 		name          string
 		platform      *vsphere.Platform
+		config        *types.InstallConfig
 		expectedError string
 	}{
 		{
@@ -143,10 +178,241 @@ func TestValidatePlatform(t *testing.T) {
 			}(),
 			expectedError: `^test-path.vCenter: Invalid value: "tEsT-vCenter": must be all lower case`,
 		},
+		{
+			name: "valid failure domains",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.FailureDomains = []vsphere.FailureDomain{
+					validFailureDomain("a"),
+					func() vsphere.FailureDomain {
+						fd := validFailureDomain("b")
+						fd.Folder = "/test-datacenter/vm/cluster"
+						return fd
+					}(),
+				}
+				return p
+			}(),
+		},
+		{
+			name: "duplicate failure domain",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.FailureDomains = []vsphere.FailureDomain{validFailureDomain("a"), validFailureDomain("a")}
+				return p
+			}(),
+			expectedError: `^test-path\.failureDomains\[1\]\.name: Duplicate value: "a"$`,
+		},
+		{
+			name: "failure domain missing cluster",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				fd := validFailureDomain("a")
+				fd.Cluster = ""
+				p.FailureDomains = []vsphere.FailureDomain{fd}
+				return p
+			}(),
+			expectedError: `^test-path\.failureDomains\[0\]\.cluster: Required value: must specify the cluster$`,
+		},
+		{
+			name: "failure domain in other datacenter",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				fd := validFailureDomain("a")
+				fd.Datacenter = "other-datacenter"
+				p.FailureDomains = []vsphere.FailureDomain{fd}
+				return p
+			}(),
+			expectedError: `^test-path\.failureDomains\[0\]\.datacenter: Invalid value: "other-datacenter": must be the platform datacenter test-datacenter$`,
+		},
+		{
+			name: "failure domain folder in wrong datacenter",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				fd := validFailureDomain("a")
+				fd.Folder = "/other-datacenter/vm/cluster"
+				p.FailureDomains = []vsphere.FailureDomain{fd}
+				return p
+			}(),
+			expectedError: `^test-path\.failureDomains\[0\]\.folder: Invalid value: "/other-datacenter/vm/cluster": folder must be absolute path: expected prefix /test-datacenter/vm/$`,
+		},
+		{
+			name: "valid static IPs",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.StaticIPs = validStaticIPs()
+				return p
+			}(),
+		},
+		{
+			name: "valid static IP addresses",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.StaticIPs = validStaticIPs()
+				p.StaticIPs.ControlPlane = vsphere.IPPool{Addresses: []string{"192.168.111.21", "192.168.111.23", "192.168.111.25"}}
+				return p
+			}(),
+		},
+		{
+			name: "static IPs invalid netmask",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.StaticIPs = validStaticIPs()
+				p.StaticIPs.Netmask = "255.0.255.0"
+				return p
+			}(),
+			expectedError: `^test-path\.staticIPs\.netmask: Invalid value: "255\.0\.255\.0": must be an IPv4 netmask in dotted decimal notation$`,
+		},
+		{
+			name: "static IPs gateway outside machine network",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.StaticIPs = validStaticIPs()
+				p.StaticIPs.Gateway = "10.0.0.1"
+				return p
+			}(),
+			expectedError: `^test-path\.staticIPs\.gateway: Invalid value: "10\.0\.0\.1": must be in one of the machine networks$`,
+		},
+		{
+			name: "static IPs range and addresses",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.StaticIPs = validStaticIPs()
+				p.StaticIPs.ControlPlane.Addresses = []string{"192.168.111.21"}
+				return p
+			}(),
+			expectedError: `^test-path\.staticIPs\.controlPlane\.addresses: Forbidden: must not be set together with a range$`,
+		},
+		{
+			name: "static IPs reversed range",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.StaticIPs = validStaticIPs()
+				p.StaticIPs.ControlPlane.End = "192.168.111.19"
+				return p
+			}(),
+			expectedError: `^test-path\.staticIPs\.controlPlane\.end: Invalid value: "192\.168\.111\.19": must not be before the start of the range$`,
+		},
+		{
+			name: "static IPs too few control plane addresses",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.StaticIPs = validStaticIPs()
+				p.StaticIPs.ControlPlane.End = "192.168.111.21"
+				return p
+			}(),
+			expectedError: `^test-path\.staticIPs\.controlPlane: Invalid value: 2: must have an address for each of the 3 control plane machines$`,
+		},
+		{
+			name: "static IPs control plane address outside machine network",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.StaticIPs = validStaticIPs()
+				p.StaticIPs.ControlPlane = vsphere.IPPool{Addresses: []string{"192.168.111.21", "192.168.112.23", "192.168.111.25"}}
+				return p
+			}(),
+			expectedError: `^test-path\.staticIPs\.controlPlane\.addresses\[1\]: Invalid value: "192\.168\.112\.23": must be in one of the machine networks$`,
+		},
+		{
+			name: "static IPs control plane address is the API VIP",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.APIVIP = "192.168.111.22"
+				p.IngressVIP = "192.168.111.5"
+				p.StaticIPs = validStaticIPs()
+				return p
+			}(),
+			expectedError: `^test-path\.staticIPs\.controlPlane\.start: Duplicate value: "192\.168\.111\.22"$`,
+		},
+		{
+			name: "static IPs bootstrap address is the ingress VIP",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.APIVIP = "192.168.111.5"
+				p.IngressVIP = "192.168.111.10"
+				p.StaticIPs = validStaticIPs()
+				return p
+			}(),
+			expectedError: `^test-path\.staticIPs\.bootstrap: Duplicate value: "192\.168\.111\.10"$`,
+		},
+		{
+			name: "static IPs missing bootstrap address",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.StaticIPs = validStaticIPs()
+				p.StaticIPs.Bootstrap = ""
+				return p
+			}(),
+			expectedError: `^test-path\.staticIPs\.bootstrap: Required value: must specify an IP address$`,
+		},
+		{
+			name: "static IPs without compute replicas",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.StaticIPs = validStaticIPs()
+				return p
+			}(),
+			config: &types.InstallConfig{
+				Compute: []types.MachinePool{{Name: "worker", Replicas: pointer.Int64Ptr(0)}},
+			},
+		},
+		{
+			name: "static IPs with compute replicas",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.StaticIPs = validStaticIPs()
+				p.StaticIPs.Compute = vsphere.IPPool{Start: "192.168.111.30", End: "192.168.111.39"}
+				return p
+			}(),
+			config: &types.InstallConfig{
+				Compute: []types.MachinePool{{Name: "worker", Replicas: pointer.Int64Ptr(3)}},
+			},
+		},
+		{
+			name: "static IPs with compute replicas and no compute pool",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.StaticIPs = validStaticIPs()
+				return p
+			}(),
+			config: &types.InstallConfig{
+				Compute: []types.MachinePool{{Name: "worker", Replicas: pointer.Int64Ptr(3)}},
+			},
+			expectedError: `^test-path\.staticIPs\.compute\.start: Required value: must specify an IP address$`,
+		},
+		{
+			name: "static IPs with too few compute addresses",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.StaticIPs = validStaticIPs()
+				p.StaticIPs.Compute = vsphere.IPPool{Addresses: []string{"192.168.111.30", "192.168.111.31"}}
+				return p
+			}(),
+			config: &types.InstallConfig{
+				Compute: []types.MachinePool{{Name: "worker", Replicas: pointer.Int64Ptr(3)}},
+			},
+			expectedError: `^test-path\.staticIPs\.compute: Invalid value: 2: must have an address for each of the 3 compute machines$`,
+		},
+		{
+			name: "static IPs with compute address in control plane pool",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.StaticIPs = validStaticIPs()
+				p.StaticIPs.Compute = vsphere.IPPool{Addresses: []string{"192.168.111.30", "192.168.111.21"}}
+				return p
+			}(),
+			config: &types.InstallConfig{
+				Compute: []types.MachinePool{{Name: "worker", Replicas: pointer.Int64Ptr(2)}},
+			},
+			expectedError: `^test-path\.staticIPs\.compute\.addresses\[1\]: Duplicate value: "192\.168\.111\.21"$`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := ValidatePlatform(tc.platform, field.NewPath("test-path")).ToAggregate()
+			config := tc.config
+			if config == nil {
+				config = &types.InstallConfig{}
+			}
+			err := ValidatePlatform(tc.platform, validNetworking(), field.NewPath("test-path"), config).ToAggregate()
 			if tc.expectedError == "" {
 				assert.NoError(t, err)
 			} else {